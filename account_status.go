@@ -0,0 +1,198 @@
+package ldapmanager
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// Accounts are locked using the pwdAccountLockedTime attribute of the password policy schema
+// and expire using the shadowExpire attribute of the shadowAccount object class.
+
+const (
+	// NeverExpires removes the expiry date of an account
+	NeverExpires = "never"
+	// ExpiryDateFormat is the format of account expiry dates
+	ExpiryDateFormat = "2006-01-02"
+
+	// permanentLockTime marks an account as locked until it is unlocked by an admin
+	permanentLockTime = "000001010000Z"
+	secondsPerDay     = 24 * 60 * 60
+)
+
+// AccountLockedError is returned when a locked account tries to authenticate
+type AccountLockedError struct {
+	ApplicationError
+	Username string
+}
+
+// Error ...
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("account %q is locked", e.Username)
+}
+
+// Code ...
+func (e *AccountLockedError) Code() codes.Code {
+	return codes.PermissionDenied
+}
+
+// AccountExpiredError is returned when an expired account tries to authenticate
+type AccountExpiredError struct {
+	ApplicationError
+	Username string
+}
+
+// Error ...
+func (e *AccountExpiredError) Error() string {
+	return fmt.Sprintf("account %q is expired", e.Username)
+}
+
+// Code ...
+func (e *AccountExpiredError) Code() codes.Code {
+	return codes.FailedPrecondition
+}
+
+// parseExpiryDate returns the shadowExpire value (days since 1970-01-01) of an expiry date
+func parseExpiryDate(date string) (int64, error) {
+	expires, err := time.Parse(ExpiryDateFormat, date)
+	if err != nil {
+		return 0, &ValidationError{Message: fmt.Sprintf("invalid expiry date %q: expected YYYY-MM-DD", date), Field: "expires"}
+	}
+	return expires.Unix() / secondsPerDay, nil
+}
+
+func validExpiryDate(date string) bool {
+	if date == "" || date == NeverExpires {
+		return true
+	}
+	_, err := parseExpiryDate(date)
+	return err == nil
+}
+
+func accountExpired(entry *ldap.Entry, now time.Time) bool {
+	expires, err := strconv.ParseInt(entry.GetAttributeValue("shadowExpire"), 10, 64)
+	if err != nil || expires < 0 {
+		// a missing value or -1 means the account never expires
+		return false
+	}
+	return now.Unix()/secondsPerDay >= expires
+}
+
+// checkAccountStatus rejects locked and expired accounts. Temporary lockouts after failed logins
+// are enforced by the password policy overlay and end when their duration elapsed.
+func checkAccountStatus(username string, entry *ldap.Entry) error {
+	if entry.GetAttributeValue("pwdAccountLockedTime") == permanentLockTime {
+		return &AccountLockedError{Username: username}
+	}
+	if accountExpired(entry, time.Now()) {
+		return &AccountExpiredError{Username: username}
+	}
+	return nil
+}
+
+// accountStatusFilter returns the LDAP filter and, because shadowExpire can not be compared on the server,
+// an additional in-memory match for listing accounts with the given status
+func accountStatusFilter(status pb.AccountStatus) (string, func(*ldap.Entry) bool) {
+	switch status {
+	case pb.AccountStatus_ACTIVE:
+		return "(!(pwdAccountLockedTime=*))", func(entry *ldap.Entry) bool {
+			return !accountExpired(entry, time.Now())
+		}
+	case pb.AccountStatus_LOCKED:
+		return "(pwdAccountLockedTime=*)", nil
+	case pb.AccountStatus_EXPIRED:
+		return "(shadowExpire=*)", func(entry *ldap.Entry) bool {
+			return accountExpired(entry, time.Now())
+		}
+	}
+	return "", nil
+}
+
+// setAccountExpiry adds the changes that set or remove the expiry date of an account to the modify request
+func setAccountExpiry(modifyRequest *ldap.ModifyRequest, entry *ldap.Entry, date string) error {
+	if date == NeverExpires {
+		if entry.GetAttributeValue("shadowExpire") != "" {
+			modifyRequest.Delete("shadowExpire", []string{})
+		}
+		return nil
+	}
+	expires, err := parseExpiryDate(date)
+	if err != nil {
+		return err
+	}
+	if !hasObjectClass(entry, "shadowAccount") {
+		modifyRequest.Add("objectClass", []string{"shadowAccount"})
+	}
+	modifyRequest.Replace("shadowExpire", []string{strconv.FormatInt(expires, 10)})
+	return nil
+}
+
+func (m *LDAPManager) findAccount(username string, attributes []string) (*ldap.Entry, error) {
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.UserGroupDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&(objectClass=posixAccount)(%s=%s))", m.AccountAttribute, escapeFilter(username)),
+		attributes,
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find existing user: %v", err)
+	}
+	if len(result.Entries) != 1 {
+		return nil, &ZeroOrMultipleAccountsError{Username: username, Count: len(result.Entries)}
+	}
+	return result.Entries[0], nil
+}
+
+// LockAccount disables an account until it is unlocked again
+func (m *LDAPManager) LockAccount(req *pb.LockAccountRequest) error {
+	if req.GetUsername() == "" {
+		return &ValidationError{Message: "username must not be empty"}
+	}
+	entry, err := m.findAccount(req.GetUsername(), []string{"pwdAccountLockedTime"})
+	if err != nil {
+		return err
+	}
+	modifyRequest := ldap.NewModifyRequest(entry.DN, []ldap.Control{})
+	modifyRequest.Replace("pwdAccountLockedTime", []string{permanentLockTime})
+	if err := m.modify(modifyRequest); err != nil {
+		return fmt.Errorf("failed to lock account %q: %v", req.GetUsername(), err)
+	}
+	if m.SessionsEnabled() {
+		if err := m.deleteSessions(entry.DN, false); err != nil {
+			log.Warnf("failed to revoke sessions of locked account %q: %v", req.GetUsername(), err)
+		}
+	}
+	log.Infof("locked account %q", req.GetUsername())
+	return nil
+}
+
+// UnlockAccount enables a locked account. It also resets failed login attempts recorded by the password policy.
+func (m *LDAPManager) UnlockAccount(req *pb.UnlockAccountRequest) error {
+	if req.GetUsername() == "" {
+		return &ValidationError{Message: "username must not be empty"}
+	}
+	entry, err := m.findAccount(req.GetUsername(), []string{"pwdAccountLockedTime", "pwdFailureTime"})
+	if err != nil {
+		return err
+	}
+	modifyRequest := ldap.NewModifyRequest(entry.DN, []ldap.Control{})
+	for _, attribute := range []string{"pwdAccountLockedTime", "pwdFailureTime"} {
+		if len(entry.GetAttributeValues(attribute)) > 0 {
+			modifyRequest.Delete(attribute, []string{})
+		}
+	}
+	if len(modifyRequest.Changes) == 0 {
+		return nil
+	}
+	if err := m.modify(modifyRequest); err != nil {
+		return fmt.Errorf("failed to unlock account %q: %v", req.GetUsername(), err)
+	}
+	log.Infof("unlocked account %q", req.GetUsername())
+	return nil
+}
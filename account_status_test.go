@@ -0,0 +1,150 @@
+package ldapmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestAccountExpiry ...
+func TestAccountExpiry(t *testing.T) {
+	days, err := parseExpiryDate("1970-01-11")
+	if err != nil || days != 10 {
+		t.Errorf("expected 10 days since epoch but got %d (%v)", days, err)
+	}
+	for _, invalid := range []string{"2021-13-01", "01.02.2021", "tomorrow"} {
+		if validExpiryDate(invalid) {
+			t.Errorf("expected %q to be an invalid expiry date", invalid)
+		}
+	}
+	for _, valid := range []string{"", NeverExpires, "2030-12-31"} {
+		if !validExpiryDate(valid) {
+			t.Errorf("expected %q to be a valid expiry date", valid)
+		}
+	}
+
+	// the account can no longer be used from the expiry date on
+	now := time.Date(2021, 6, 15, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		shadowExpire string
+		expired      bool
+	}{
+		{"", false},
+		{"-1", false},
+		{"18792", true},  // 2021-06-14
+		{"18793", true},  // 2021-06-15
+		{"18794", false}, // 2021-06-16
+	}
+	for _, c := range cases {
+		attributes := map[string][]string{}
+		if c.shadowExpire != "" {
+			attributes["shadowExpire"] = []string{c.shadowExpire}
+		}
+		entry := ldap.NewEntry("uid=romnn,ou=users,dc=example,dc=org", attributes)
+		if expired := accountExpired(entry, now); expired != c.expired {
+			t.Errorf("expected shadowExpire=%q to be expired=%t but got %t", c.shadowExpire, c.expired, expired)
+		}
+	}
+}
+
+// TestCheckAccountStatus ...
+func TestCheckAccountStatus(t *testing.T) {
+	active := ldap.NewEntry("uid=a,ou=users,dc=example,dc=org", map[string][]string{"shadowExpire": {"-1"}})
+	if err := checkAccountStatus("a", active); err != nil {
+		t.Errorf("expected active account to be accepted but got %v", err)
+	}
+	locked := ldap.NewEntry("uid=b,ou=users,dc=example,dc=org", map[string][]string{"pwdAccountLockedTime": {permanentLockTime}})
+	if _, ok := checkAccountStatus("b", locked).(*AccountLockedError); !ok {
+		t.Error("expected locked account to be rejected")
+	}
+	expired := ldap.NewEntry("uid=c,ou=users,dc=example,dc=org", map[string][]string{"shadowExpire": {"1"}})
+	if _, ok := checkAccountStatus("c", expired).(*AccountExpiredError); !ok {
+		t.Error("expected expired account to be rejected")
+	}
+	if (&AccountLockedError{}).Code() == (&AccountExpiredError{}).Code() {
+		t.Error("expected distinct error codes for locked and expired accounts")
+	}
+}
+
+// TestLockAndExpireAccounts ...
+func TestLockAndExpireAccounts(t *testing.T) {
+	if skipAccountStatusTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	tomorrow := time.Now().AddDate(0, 0, 1).UTC().Format(ExpiryDateFormat)
+	for _, account := range []*pb.Account{
+		{Username: "active", Expires: tomorrow},
+		{Username: "locked"},
+		{Username: "expired"},
+	} {
+		account.Password = "Hallo Welt"
+		account.Email = account.GetUsername() + "@example.org"
+		account.FirstName = "roman"
+		account.LastName = "d"
+		if err := test.Manager.NewAccount(&pb.NewAccountRequest{Account: account}, pb.HashingAlgorithm_DEFAULT); err != nil {
+			t.Fatalf("failed to add user %q: %v", account.GetUsername(), err)
+		}
+	}
+	isAdmin := true
+	if _, _, err := test.Manager.UpdateAccount(&pb.UpdateAccountRequest{
+		Username: "expired",
+		Update:   &pb.Account{Expires: "2000-01-01"},
+	}, pb.HashingAlgorithm_DEFAULT, isAdmin); err != nil {
+		t.Fatalf("failed to set expiry date: %v", err)
+	}
+	if err := test.Manager.LockAccount(&pb.LockAccountRequest{Username: "locked"}); err != nil {
+		t.Fatalf("failed to lock account: %v", err)
+	}
+
+	login := func(username string) error {
+		_, err := test.Manager.AuthenticateUser(&pb.LoginRequest{Username: username, Password: "Hallo Welt"})
+		return err
+	}
+	if err := login("active"); err != nil {
+		t.Errorf("expected active account to authenticate but got %v", err)
+	}
+	if _, ok := login("locked").(*AccountLockedError); !ok {
+		t.Error("expected locked account to be rejected")
+	}
+	if _, ok := login("expired").(*AccountExpiredError); !ok {
+		t.Error("expected expired account to be rejected")
+	}
+
+	statuses := map[pb.AccountStatus][]string{
+		pb.AccountStatus_ACTIVE:  {"active"},
+		pb.AccountStatus_LOCKED:  {"locked"},
+		pb.AccountStatus_EXPIRED: {"expired"},
+	}
+	for status, expected := range statuses {
+		users, err := test.Manager.GetUserList(&pb.GetUserListRequest{Status: status, PageSize: 10})
+		if err != nil {
+			t.Fatalf("failed to list %s accounts: %v", status, err)
+		}
+		if len(users.GetUsers()) != len(expected) {
+			t.Errorf("expected %d %s accounts but got %v", len(expected), status, users.GetUsers())
+		}
+		if err := containsUsers(users, expected, test.Manager.AccountAttribute); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if err := test.Manager.UnlockAccount(&pb.UnlockAccountRequest{Username: "locked"}); err != nil {
+		t.Fatalf("failed to unlock account: %v", err)
+	}
+	if _, _, err := test.Manager.UpdateAccount(&pb.UpdateAccountRequest{
+		Username: "expired",
+		Update:   &pb.Account{Expires: NeverExpires},
+	}, pb.HashingAlgorithm_DEFAULT, isAdmin); err != nil {
+		t.Fatalf("failed to remove expiry date: %v", err)
+	}
+	for _, username := range []string{"locked", "expired"} {
+		if err := login(username); err != nil {
+			t.Errorf("expected %q to authenticate again but got %v", username, err)
+		}
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
-	"sort"
 	"strconv"
+	"strings"
 
 	"google.golang.org/grpc/codes"
 
@@ -79,11 +79,6 @@ func validEmail(e string) bool {
 	return emailRegex.MatchString(e)
 }
 
-func validPassword(pw string) bool {
-	// TODO: maybe we enforce some password length in the future
-	return true
-}
-
 func validUsername(un string) bool {
 	// TODO: maybe we enforce some username regex in the future
 	return true
@@ -92,7 +87,8 @@ func validUsername(un string) bool {
 func (m *LDAPManager) defaultUserFields() []string {
 	return []string{
 		m.AccountAttribute,
-		"givenName", "sn", "cn", "displayName", "uidNumber", "gidNumber", "loginShell", "homeDirectory", "mail"}
+		"givenName", "sn", "cn", "displayName", "uidNumber", "gidNumber", "loginShell", "homeDirectory", "mail",
+		"shadowExpire", "pwdAccountLockedTime"}
 }
 
 func parseUser(entry *ldap.Entry) *pb.User {
@@ -135,76 +131,61 @@ func (m *LDAPManager) AccountNamed(name string) string {
 	return fmt.Sprintf("%s=%s,%s", m.AccountAttribute, escapeDN(name), m.UserGroupDN)
 }
 
-func (m *LDAPManager) countAccounts() (int, error) {
-	result, err := m.ldap.Search(ldap.NewSearchRequest(
-		m.UserGroupDN,
-		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
-		fmt.Sprintf("(%s=*)", m.AccountAttribute),
-		[]string{"dn"},
-		[]ldap.Control{},
-	))
-	if err != nil {
-		return 0, err
-	}
-	return len(result.Entries), nil
-}
-
 // GetUserList ...
 func (m *LDAPManager) GetUserList(req *pb.GetUserListRequest) (*pb.UserList, error) {
 	if req.GetSortKey() == "" {
 		req.SortKey = m.AccountAttribute
 	}
-	filter := parseFilter(req.Filter)
-	result, err := m.ldap.Search(ldap.NewSearchRequest(
+	// the sort key is sent to the server in a critical sort control and must be one of the returned fields
+	sortKey := ""
+	for _, field := range m.defaultUserFields() {
+		if strings.EqualFold(field, req.GetSortKey()) {
+			sortKey = field
+		}
+	}
+	if sortKey == "" {
+		return nil, &ValidationError{Message: fmt.Sprintf("can not sort by %q", req.GetSortKey()), Field: "sort_key"}
+	}
+	req.SortKey = sortKey
+	statusFilter, match := accountStatusFilter(req.GetStatus())
+	filter := parseFilter(req.Filter) + statusFilter
+	entries, total, nextPageToken, err := m.listPage(
 		m.UserGroupDN,
-		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 		fmt.Sprintf("(&(%s=*)%s)", m.AccountAttribute, filter),
 		m.defaultUserFields(),
-		[]ldap.Control{},
-	))
+		&pageRequest{
+			start:   req.GetStart(),
+			end:     req.GetEnd(),
+			size:    req.GetPageSize(),
+			token:   req.GetPageToken(),
+			sortKey: req.GetSortKey(),
+			order:   req.GetSortOrder(),
+			filter:  filter,
+			match:   match,
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
-	total, err := m.countAccounts()
-	if err != nil {
-		return nil, err
+	users := &pb.UserList{Total: int64(total), NextPageToken: nextPageToken}
+	for _, entry := range entries {
+		users.Users = append(users.Users, parseUser(entry))
 	}
-	users := make(map[string]*pb.User)
-	for _, entry := range result.Entries {
-		if entryKey := entry.GetAttributeValue(req.GetSortKey()); entryKey != "" {
-			users[entryKey] = parseUser(entry)
-		}
-	}
-	// Sort for deterministic clipping
-	keys := make([]string, 0, len(users))
-	for k := range users {
-		keys = append(keys, k)
-	}
-	// Sort
-	sort.Slice(keys, func(i, j int) bool {
-		asc := keys[i] < keys[j]
-		if req.GetSortOrder() == pb.SortOrder_ASCENDING {
-			return !asc
-		}
-		return asc
-	})
-	// Clip
-	clippedKeys := keys
-	if req.GetStart() >= 0 && req.GetEnd() < int32(len(keys)) && req.GetStart() < req.GetEnd() {
-		clippedKeys = keys[req.GetStart():req.GetEnd()]
-	}
-	clipped := &pb.UserList{Total: int64(total)}
-	for _, key := range clippedKeys {
-		clipped.Users = append(clipped.Users, users[key])
-	}
-	return clipped, nil
+	return users, nil
 }
 
 // AuthenticateUser ...
 func (m *LDAPManager) AuthenticateUser(req *pb.LoginRequest) (*ldap.Entry, error) {
+	entry, _, err := m.AuthenticateUserWithPolicy(req)
+	return entry, err
+}
+
+// AuthenticateUserWithPolicy authenticates a user and returns the state of the password
+// reported by the password policy overlay
+func (m *LDAPManager) AuthenticateUserWithPolicy(req *pb.LoginRequest) (*ldap.Entry, *PasswordPolicyStatus, error) {
 	// Validate
 	if req.GetUsername() == "" || req.GetPassword() == "" {
-		return nil, &ValidationError{Message: "must provide username and password"}
+		return nil, nil, &ValidationError{Message: "must provide username and password"}
 	}
 	// Search for the DN for the given username. If found, try binding with the DN and user's password.
 	// If the binding succeeds, return the DN.
@@ -216,18 +197,45 @@ func (m *LDAPManager) AuthenticateUser(req *pb.LoginRequest) (*ldap.Entry, error
 		[]ldap.Control{},
 	))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if len(result.Entries) != 1 {
-		return nil, &ZeroOrMultipleAccountsError{Username: req.GetUsername(), Count: len(result.Entries)}
+		return nil, nil, &ZeroOrMultipleAccountsError{Username: req.GetUsername(), Count: len(result.Entries)}
 	}
-	// Make sure to always re-bind as admin afterwards
-	defer m.BindAdmin()
 	userDN := result.Entries[0].DN
-	if err := m.ldap.Bind(userDN, req.GetPassword()); err != nil {
-		return nil, fmt.Errorf("unable to bind as %q", req.GetUsername())
+	policy, err := m.bindUser(userDN, req.GetPassword())
+	if err != nil {
+		log.Debugf("unable to bind as %q: %v", userDN, err)
+		// the overlay only reports the reason of a failed bind for valid credentials
+		if policyErr := passwordPolicyError(req.GetUsername(), policy); policyErr != nil {
+			return nil, nil, policyErr
+		}
+		return nil, nil, fmt.Errorf("unable to bind as %q", req.GetUsername())
+	}
+	// the status is only revealed to users that know the password
+	if err := checkAccountStatus(req.GetUsername(), result.Entries[0]); err != nil {
+		return nil, nil, err
 	}
-	return result.Entries[0], nil
+	if m.UpgradeHashesOnLogin {
+		if err := m.upgradePasswordHash(req.GetUsername(), userDN, req.GetPassword()); err != nil {
+			log.Warnf("failed to upgrade the password hash of %q: %v", req.GetUsername(), err)
+		}
+	}
+	return result.Entries[0], newPasswordPolicyStatus(policy), nil
+}
+
+// bindUser verifies the credentials of a user on a separate, short-lived connection
+// so that the admin connections of the pool never change their identity
+func (m *LDAPManager) bindUser(userDN, password string) (*ldap.ControlBeheraPasswordPolicy, error) {
+	if m.PPolicyOverlay {
+		return m.passwordPolicyBind(userDN, password)
+	}
+	conn, err := m.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return nil, conn.Bind(userDN, password)
 }
 
 // GetAccount ...
@@ -258,7 +266,7 @@ func ValidAccountRequest(acc *pb.Account) error {
 	if acc.GetUsername() == "" || !validUsername(acc.GetUsername()) {
 		invalid = append(invalid, "username")
 	}
-	if acc.GetPassword() == "" || !validPassword(acc.GetPassword()) {
+	if acc.GetPassword() == "" {
 		invalid = append(invalid, "password")
 	}
 	if acc.GetEmail() == "" || !validEmail(acc.GetEmail()) {
@@ -270,6 +278,9 @@ func ValidAccountRequest(acc *pb.Account) error {
 	if acc.GetLastName() == "" {
 		invalid = append(invalid, "last name")
 	}
+	if !validExpiryDate(acc.GetExpires()) {
+		invalid = append(invalid, "expires")
+	}
 	if len(invalid) > 0 {
 		return &AccountValidationError{Invalid: invalid}
 	}
@@ -283,6 +294,9 @@ func (m *LDAPManager) NewAccount(req *pb.NewAccountRequest, algorithm pb.Hashing
 	if err := ValidAccountRequest(account); err != nil {
 		return err
 	}
+	if err := m.validPassword(account.GetPassword(), account); err != nil {
+		return err
+	}
 	// Check for existing user with the same username
 	account.Username = escapeDN(account.GetUsername())
 	result, err := m.ldap.Search(ldap.NewSearchRequest(
@@ -322,19 +336,13 @@ func (m *LDAPManager) NewAccount(req *pb.NewAccountRequest, algorithm pb.Hashing
 	}
 
 	newUID := int(account.GetUid())
-	if newUID < MinUID {
-		highestUID, err := m.getHighestID(m.AccountAttribute)
-		if err != nil {
-			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
-				// Try to recover by running the setup
-				_ = m.setupLastUID()
-				highestUID, err = m.getHighestID(m.AccountAttribute)
-			}
-			if err != nil {
-				return fmt.Errorf("failed to get highest %s: %v", m.AccountAttribute, err)
-			}
+	explicitUID := newUID > 0
+	if explicitUID {
+		if !m.isConfiguredUID(newUID) {
+			return &ValidationError{Message: fmt.Sprintf("uid %d is outside of the uid range %s and the uid pools", newUID, withDefaultMin(m.UIDRange, MinUID)), Field: "uid"}
 		}
-		newUID = highestUID + 1
+	} else if newUID, err = m.AllocateUID(account.GetIdPool()); err != nil {
+		return err
 	}
 
 	var group string
@@ -352,7 +360,7 @@ func (m *LDAPManager) NewAccount(req *pb.NewAccountRequest, algorithm pb.Hashing
 		algorithm = m.HashingAlgorithm
 	}
 
-	hashedPassword, err := ldaphash.Password(account.GetPassword(), algorithm)
+	hashedPassword, err := ldaphash.PasswordWithOptions(account.GetPassword(), algorithm, m.HashingOptions)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %v", err)
 	}
@@ -371,6 +379,14 @@ func (m *LDAPManager) NewAccount(req *pb.NewAccountRequest, algorithm pb.Hashing
 		{Type: "userPassword", Vals: []string{hashedPassword}},
 		{Type: "mail", Vals: []string{account.GetEmail()}},
 	}
+	if expires := account.GetExpires(); expires != "" && expires != NeverExpires {
+		shadowExpire, err := parseExpiryDate(expires)
+		if err != nil {
+			return err
+		}
+		userAttributes[0].Vals = append(userAttributes[0].Vals, "shadowAccount")
+		userAttributes = append(userAttributes, ldap.Attribute{Type: "shadowExpire", Vals: []string{strconv.FormatInt(shadowExpire, 10)}})
+	}
 
 	userDN := m.AccountNamed(account.GetUsername())
 	addUserRequest := &ldap.AddRequest{
@@ -379,7 +395,7 @@ func (m *LDAPManager) NewAccount(req *pb.NewAccountRequest, algorithm pb.Hashing
 		Controls:   []ldap.Control{},
 	}
 	log.Debugf("addUserRequest=%v", addUserRequest)
-	if err := m.ldap.Add(addUserRequest); err != nil {
+	if err := m.add(addUserRequest); err != nil {
 		if ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) {
 			return &AccountAlreadyExistsError{Username: account.GetUsername()}
 		}
@@ -391,8 +407,13 @@ func (m *LDAPManager) NewAccount(req *pb.NewAccountRequest, algorithm pb.Hashing
 			return fmt.Errorf("failed to add user %q to group %q: %v", account.GetUsername(), group, err)
 		}
 	}
-	if err := m.updateLastID("lastUID", newUID); err != nil {
-		return err
+	if explicitUID {
+		if err := m.reserveUID(newUID); err != nil {
+			return err
+		}
+	}
+	if err := m.recordPasswordHistory(account.GetUsername(), hashedPassword); err != nil {
+		log.Warn(err)
 	}
 	log.Infof("added new account %q (member of group %q)", account.GetUsername(), group)
 	return nil
@@ -406,7 +427,7 @@ func (m *LDAPManager) UpdateAccount(req *pb.UpdateAccountRequest, algorithm pb.H
 		m.UserGroupDN,
 		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 		fmt.Sprintf("(%s=%s)", m.AccountAttribute, req.GetUsername()),
-		m.defaultUserFields(),
+		append(m.defaultUserFields(), "userPassword", "objectClass"),
 		[]ldap.Control{},
 	))
 	if err != nil {
@@ -422,6 +443,34 @@ func (m *LDAPManager) UpdateAccount(req *pb.UpdateAccountRequest, algorithm pb.H
 	userDN := user.DN
 	update := req.GetUpdate()
 
+	// Check the new password before making any changes
+	if password := update.GetPassword(); password != "" {
+		account := &pb.Account{
+			Username:  username,
+			FirstName: user.GetAttributeValue("givenName"),
+			LastName:  user.GetAttributeValue("sn"),
+		}
+		if update.GetUsername() != "" {
+			account.Username = update.GetUsername()
+		}
+		if update.GetFirstName() != "" {
+			account.FirstName = update.GetFirstName()
+		}
+		if update.GetLastName() != "" {
+			account.LastName = update.GetLastName()
+		}
+		if err := m.validPassword(password, account); err != nil {
+			return "", 0, err
+		}
+		if err := m.checkPasswordHistory(username, user.GetAttributeValue("userPassword"), password); err != nil {
+			return "", 0, err
+		}
+	}
+
+	if expires := update.GetExpires(); isAdmin && !validExpiryDate(expires) {
+		return "", 0, &AccountValidationError{Invalid: []string{"expires"}}
+	}
+
 	// Check if the username was changed which requires a DN change
 	if update.GetUsername() != "" && update.GetUsername() != username {
 		username = update.GetUsername()
@@ -448,31 +497,27 @@ func (m *LDAPManager) UpdateAccount(req *pb.UpdateAccountRequest, algorithm pb.H
 			NewSuperior:  "",
 		}
 		log.Debugf("RenameAccount modifyRequest=%v", modifyRequest)
-		if err := m.ldap.ModifyDN(modifyRequest); err != nil {
+		if err := m.modifyDN(modifyRequest); err != nil {
 			return "", 0, err
 		}
 		log.Infof("renamed user from %q to %q", req.GetUsername(), username)
 		userDN = m.AccountNamed(username)
+		if err := m.renamePasswordHistory(req.GetUsername(), username); err != nil {
+			log.Warn(err)
+		}
+		if err := m.moveSessions(m.AccountNamed(req.GetUsername()), userDN); err != nil {
+			log.Warn(err)
+		}
+		if err := m.replaceGroupOwner(m.AccountNamed(req.GetUsername()), userDN); err != nil {
+			log.Warn(err)
+		}
+		if err := m.moveJoinRequests(fmt.Sprintf("(owner=%s)", escapeFilter(m.AccountNamed(req.GetUsername()))), "owner", userDN); err != nil {
+			log.Warn(err)
+		}
 
 		// migrate user from all his groups
-		groups, err := m.GetUserGroups(&pb.GetUserGroupsRequest{Username: username})
-		if err != nil {
-			return "", 0, fmt.Errorf("failed to get list of groups: %v", err)
-		}
-		for _, group := range groups.GetGroups() {
-			allowDeleteOfDefaultGroups := true
-			if err := m.DeleteGroupMember(&pb.GroupMember{Group: group, Username: req.GetUsername()}, allowDeleteOfDefaultGroups); err != nil {
-				if _, ok := err.(*NoSuchMemberError); !ok {
-					return "", 0, fmt.Errorf("failed to remove renamed user (%q -> %q) from group %q: %v", req.GetUsername(), username, group, err)
-				}
-			}
-			allowNonExistent := true
-			if err := m.AddGroupMember(&pb.GroupMember{Group: group, Username: username}, allowNonExistent); err != nil {
-				if _, ok := err.(*MemberAlreadyExistsError); !ok {
-					return "", 0, fmt.Errorf("failed to add renamed user (%q -> %q) to group %q: %v", req.GetUsername(), username, group, err)
-				}
-			}
-			log.Infof("Switched member %q to %q in user group %q ", req.GetUsername(), username, group)
+		if err := m.renameGroupMember(req.GetUsername(), username); err != nil {
+			return "", 0, err
 		}
 	}
 
@@ -504,8 +549,9 @@ func (m *LDAPManager) UpdateAccount(req *pb.UpdateAccountRequest, algorithm pb.H
 	if mail := update.GetEmail(); mail != "" {
 		modifyAccountRequest.Replace("mail", []string{mail})
 	}
+	var hashedPassword string
 	if password := update.GetPassword(); password != "" {
-		hashedPassword, err := ldaphash.Password(password, algorithm)
+		hashedPassword, err = ldaphash.PasswordWithOptions(password, algorithm, m.HashingOptions)
 		if err != nil {
 			return "", 0, fmt.Errorf("failed to hash password: %v", err)
 		}
@@ -521,12 +567,22 @@ func (m *LDAPManager) UpdateAccount(req *pb.UpdateAccountRequest, algorithm pb.H
 		if gid := update.GetGid(); gid >= MinGID {
 			modifyAccountRequest.Replace("gidNumber", []string{strconv.Itoa(int(gid))})
 		}
+		if expires := update.GetExpires(); expires != "" {
+			if err := setAccountExpiry(modifyAccountRequest, user, expires); err != nil {
+				return "", 0, err
+			}
+		}
 	}
 
 	log.Debugf("modifyAccountRequest=%v", modifyAccountRequest)
-	if err := m.ldap.Modify(modifyAccountRequest); err != nil {
+	if err := m.modify(modifyAccountRequest); err != nil {
 		return "", 0, fmt.Errorf("failed to modify existing user: %v", err)
 	}
+	if hashedPassword != "" {
+		if err := m.recordPasswordHistory(username, hashedPassword); err != nil {
+			log.Warn(err)
+		}
+	}
 	log.Infof("updated %d attributes of user %q", len(modifyAccountRequest.Changes), username)
 	return username, uidNumber, nil
 }
@@ -554,12 +610,24 @@ func (m *LDAPManager) DeleteAccount(req *pb.DeleteAccountRequest, keepGroups boo
 			}
 		}
 	}
-	if err := m.ldap.Del(ldap.NewDelRequest(
+	if err := m.del(ldap.NewDelRequest(
 		fmt.Sprintf("%s=%s,%s", m.AccountAttribute, escapeDN(req.GetUsername()), m.UserGroupDN),
 		[]ldap.Control{},
 	)); err != nil {
 		return err
 	}
+	if err := m.deletePasswordHistory(req.GetUsername()); err != nil {
+		log.Warn(err)
+	}
+	if err := m.RevokeSessions(&pb.RevokeSessionsRequest{Username: req.GetUsername()}); err != nil {
+		log.Warn(err)
+	}
+	if err := m.replaceGroupOwner(m.AccountNamed(req.GetUsername()), ""); err != nil {
+		log.Warn(err)
+	}
+	if err := m.deleteJoinRequests(fmt.Sprintf("(owner=%s)", escapeFilter(m.AccountNamed(req.GetUsername())))); err != nil {
+		log.Warn(err)
+	}
 	log.Infof("removed account %q", req.GetUsername())
 	return nil
 }
@@ -2,8 +2,10 @@ package ldapmanager
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -80,6 +82,34 @@ func TestAddNewUserAndGetUserList(t *testing.T) {
 	if err := containsUsers(userList, expected, test.Manager.AccountAttribute); err != nil {
 		t.Error(err)
 	}
+
+	// the users are returned in the requested order and the total only counts users matching the filter
+	for _, order := range []pb.SortOrder{pb.SortOrder_ASCENDING, pb.SortOrder_DESCENDING} {
+		userList, err = test.Manager.GetUserList(&pb.GetUserListRequest{SortOrder: order, Filter: []string{"uid=" + expected[0]}})
+		if err != nil {
+			t.Fatalf("failed to get users list: %v", err)
+		}
+		if userList.GetTotal() != 1 {
+			t.Errorf("expected a total of 1 user matching %q but got %d", expected[0], userList.GetTotal())
+		}
+		userList, err = test.Manager.GetUserList(&pb.GetUserListRequest{SortOrder: order})
+		if err != nil {
+			t.Fatalf("failed to get users list: %v", err)
+		}
+		var usernames []string
+		for _, user := range userList.GetUsers() {
+			usernames = append(usernames, user.GetData()[test.Manager.AccountAttribute])
+		}
+		sorted := sort.SliceIsSorted(usernames, func(i, j int) bool {
+			if order == pb.SortOrder_DESCENDING {
+				return usernames[i] > usernames[j]
+			}
+			return usernames[i] < usernames[j]
+		})
+		if !sorted {
+			t.Errorf("expected users in %s order but got %v", order, usernames)
+		}
+	}
 }
 
 // TestAuthenticateUser ...
@@ -341,3 +371,50 @@ func TestDeleteAccount(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+// TestAuthenticateUserKeepsAdminIdentity ...
+func TestAuthenticateUserKeepsAdminIdentity(t *testing.T) {
+	if skipAccountTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	username, password := "regular", "Hallo Welt"
+	if err := test.Manager.NewAccount(&pb.NewAccountRequest{
+		Account: &pb.Account{
+			Username:  username,
+			Password:  password,
+			Email:     "a@b.de",
+			FirstName: "roman",
+			LastName:  "d",
+		},
+	}, pb.HashingAlgorithm_DEFAULT); err != nil {
+		t.Fatalf("failed to add user %q: %v", username, err)
+	}
+
+	// Authenticate the regular user while concurrently running operations that require admin privileges
+	var wg sync.WaitGroup
+	errs := make(chan error, 40)
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := test.Manager.AuthenticateUser(&pb.LoginRequest{Username: username, Password: password}); err != nil {
+				errs <- fmt.Errorf("failed to authenticate user %q: %v", username, err)
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			group := fmt.Sprintf("group%d", i)
+			if err := test.Manager.NewGroup(&pb.NewGroupRequest{Name: group, Members: []string{username}}, false); err != nil {
+				errs <- fmt.Errorf("failed to add group %q while authenticating: %v", group, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
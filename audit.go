@@ -0,0 +1,383 @@
+package ldapmanager
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// AuditActorSystem is the actor of mutations that are not made on behalf of a user, such as the initial setup
+	AuditActorSystem = "system"
+
+	// AuditResultSuccess ...
+	AuditResultSuccess = "success"
+	// AuditResultFailure ...
+	AuditResultFailure = "failure"
+
+	// DefaultAuditLogLimit is the number of records returned by GetAuditLog if no limit is given
+	DefaultAuditLogLimit = 100
+	// MaxAuditLogLimit limits the number of records returned by GetAuditLog
+	MaxAuditLogLimit = 1000
+
+	auditLogMemorySize = 1000
+)
+
+// AuditRecord describes a single directory mutation. It never contains attribute values.
+type AuditRecord struct {
+	Time       time.Time `json:"time"`
+	Actor      string    `json:"actor"`
+	Operation  string    `json:"operation"`
+	Target     string    `json:"target"`
+	Attributes []string  `json:"attributes,omitempty"`
+	Result     string    `json:"result"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Proto converts the record to its protobuf representation
+func (r *AuditRecord) Proto() *pb.AuditRecord {
+	return &pb.AuditRecord{
+		Timestamp:  r.Time.Unix(),
+		Actor:      r.Actor,
+		Operation:  r.Operation,
+		Target:     r.Target,
+		Attributes: r.Attributes,
+		Result:     r.Result,
+		Error:      r.Error,
+	}
+}
+
+func (r *AuditRecord) matches(filter *pb.GetAuditLogRequest) bool {
+	if filter.GetActor() != "" && !strings.EqualFold(filter.GetActor(), r.Actor) {
+		return false
+	}
+	if filter.GetOperation() != "" && !strings.EqualFold(filter.GetOperation(), r.Operation) {
+		return false
+	}
+	if filter.GetTarget() != "" && !strings.Contains(strings.ToLower(r.Target), strings.ToLower(filter.GetTarget())) {
+		return false
+	}
+	if filter.GetResult() != "" && !strings.EqualFold(filter.GetResult(), r.Result) {
+		return false
+	}
+	if filter.GetSince() > 0 && r.Time.Unix() < filter.GetSince() {
+		return false
+	}
+	if filter.GetUntil() > 0 && r.Time.Unix() > filter.GetUntil() {
+		return false
+	}
+	return true
+}
+
+// AuditSink receives audit records
+type AuditSink interface {
+	Write(record *AuditRecord) error
+	Close() error
+}
+
+// AuditReader is implemented by sinks that can be queried for past records
+type AuditReader interface {
+	// Read returns up to limit of the most recent records matching the filter, newest first
+	Read(filter *pb.GetAuditLogRequest, limit int) ([]*AuditRecord, error)
+}
+
+// WriterAuditSink writes audit records as JSON lines
+type WriterAuditSink struct {
+	mux sync.Mutex
+	w   io.Writer
+}
+
+// NewWriterAuditSink ...
+func NewWriterAuditSink(w io.Writer) *WriterAuditSink {
+	return &WriterAuditSink{w: w}
+}
+
+// NewStdoutAuditSink writes audit records as JSON lines to stdout
+func NewStdoutAuditSink() *WriterAuditSink {
+	return NewWriterAuditSink(os.Stdout)
+}
+
+// Write ...
+func (s *WriterAuditSink) Write(record *AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// Close ...
+func (s *WriterAuditSink) Close() error {
+	return nil
+}
+
+// FileAuditSink appends audit records as JSON lines to a file, which can be queried
+type FileAuditSink struct {
+	WriterAuditSink
+	path string
+	file *os.File
+}
+
+// NewFileAuditSink ...
+func NewFileAuditSink(path string) (*FileAuditSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %q: %v", path, err)
+	}
+	return &FileAuditSink{WriterAuditSink: WriterAuditSink{w: file}, path: path, file: file}, nil
+}
+
+// Close ...
+func (s *FileAuditSink) Close() error {
+	return s.file.Close()
+}
+
+// Read ...
+func (s *FileAuditSink) Read(filter *pb.GetAuditLogRequest, limit int) ([]*AuditRecord, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []*AuditRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		if record.matches(filter) {
+			records = append(records, &record)
+			if len(records) > limit {
+				records = records[1:]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return newestFirst(records), nil
+}
+
+func newestFirst(records []*AuditRecord) []*AuditRecord {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.After(records[j].Time)
+	})
+	return records
+}
+
+// ParseAuditSink creates a sink from a specification such as
+// "stdout", "file:/var/log/ldap-manager/audit.jsonl", "syslog" or "syslog:udp://localhost:514"
+func ParseAuditSink(spec string) (AuditSink, error) {
+	kind := spec
+	var target string
+	if i := strings.Index(spec, ":"); i >= 0 {
+		kind, target = spec[:i], spec[i+1:]
+	}
+	switch strings.ToLower(kind) {
+	case "stdout":
+		return NewStdoutAuditSink(), nil
+	case "file":
+		if target == "" {
+			return nil, fmt.Errorf("invalid audit sink %q: missing file path", spec)
+		}
+		return NewFileAuditSink(target)
+	case "syslog":
+		var network, raddr string
+		if target != "" {
+			parts := strings.SplitN(target, "://", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid audit sink %q: expected syslog:NETWORK://ADDRESS", spec)
+			}
+			network, raddr = parts[0], parts[1]
+		}
+		return NewSyslogAuditSink(network, raddr)
+	}
+	return nil, fmt.Errorf("unknown audit sink %q", spec)
+}
+
+// AuditLog records directory mutations to all of its sinks and keeps the most recent records in memory
+type AuditLog struct {
+	mux    sync.Mutex
+	sinks  []AuditSink
+	recent []*AuditRecord
+}
+
+// NewAuditLog ...
+func NewAuditLog(sinks ...AuditSink) *AuditLog {
+	return &AuditLog{sinks: sinks}
+}
+
+// Record ...
+func (a *AuditLog) Record(record *AuditRecord) {
+	a.mux.Lock()
+	a.recent = append(a.recent, record)
+	if len(a.recent) > auditLogMemorySize {
+		a.recent = a.recent[1:]
+	}
+	sinks := a.sinks
+	a.mux.Unlock()
+	for _, sink := range sinks {
+		if err := sink.Write(record); err != nil {
+			log.Errorf("failed to write audit record: %v", err)
+		}
+	}
+}
+
+// Query returns the most recent records matching the filter, newest first.
+// The first sink that can be read is used, otherwise only the records kept in memory are available.
+func (a *AuditLog) Query(filter *pb.GetAuditLogRequest) ([]*AuditRecord, error) {
+	limit := int(filter.GetLimit())
+	if limit < 1 {
+		limit = DefaultAuditLogLimit
+	}
+	if limit > MaxAuditLogLimit {
+		limit = MaxAuditLogLimit
+	}
+	a.mux.Lock()
+	sinks := a.sinks
+	a.mux.Unlock()
+	for _, sink := range sinks {
+		if reader, ok := sink.(AuditReader); ok {
+			return reader.Read(filter, limit)
+		}
+	}
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	var records []*AuditRecord
+	for i := len(a.recent) - 1; i >= 0 && len(records) < limit; i-- {
+		if a.recent[i].matches(filter) {
+			records = append(records, a.recent[i])
+		}
+	}
+	return records, nil
+}
+
+// Close closes all sinks
+func (a *AuditLog) Close() error {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	var err error
+	for _, sink := range a.sinks {
+		if closeErr := sink.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// As returns a view of the manager that attributes all directory mutations to the actor in the audit log.
+// The view shares the connection pool and all other runtime state with the manager, only the configuration
+// is copied, which must not be changed after Setup.
+func (m *LDAPManager) As(actor string) *LDAPManager {
+	manager := *m
+	manager.actor = actor
+	return &manager
+}
+
+// GetAuditLog ...
+func (m *LDAPManager) GetAuditLog(req *pb.GetAuditLogRequest) (*pb.AuditLog, error) {
+	auditLog := &pb.AuditLog{}
+	if m.Audit == nil {
+		return auditLog, nil
+	}
+	records, err := m.Audit.Query(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the audit log: %v", err)
+	}
+	for _, record := range records {
+		auditLog.Records = append(auditLog.Records, record.Proto())
+	}
+	return auditLog, nil
+}
+
+func (m *LDAPManager) audit(operation, target string, attributes []string, err error) {
+	if m.Audit == nil {
+		return
+	}
+	actor := m.actor
+	if actor == "" {
+		actor = AuditActorSystem
+	}
+	record := &AuditRecord{
+		Time:       time.Now().UTC(),
+		Actor:      actor,
+		Operation:  operation,
+		Target:     target,
+		Attributes: attributes,
+		Result:     AuditResultSuccess,
+	}
+	if err != nil {
+		record.Result = AuditResultFailure
+		record.Error = err.Error()
+	}
+	m.Audit.Record(record)
+}
+
+func uniqueAttributes(attributes []string) []string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, attr := range attributes {
+		if key := strings.ToLower(attr); !seen[key] {
+			seen[key] = true
+			unique = append(unique, attr)
+		}
+	}
+	return unique
+}
+
+func (m *LDAPManager) add(addRequest *ldap.AddRequest) error {
+	err := m.ldap.Add(addRequest)
+	var attributes []string
+	for _, attr := range addRequest.Attributes {
+		attributes = append(attributes, attr.Type)
+	}
+	m.audit("add", addRequest.DN, uniqueAttributes(attributes), err)
+	return err
+}
+
+func (m *LDAPManager) modify(modifyRequest *ldap.ModifyRequest) error {
+	return m.modifyWithOperation("modify", modifyRequest)
+}
+
+// modifyWithOperation records the modification with a more specific audit operation
+func (m *LDAPManager) modifyWithOperation(operation string, modifyRequest *ldap.ModifyRequest) error {
+	err := m.ldap.Modify(modifyRequest)
+	var attributes []string
+	for _, change := range modifyRequest.Changes {
+		attributes = append(attributes, change.Modification.Type)
+	}
+	m.audit(operation, modifyRequest.DN, uniqueAttributes(attributes), err)
+	return err
+}
+
+func (m *LDAPManager) del(delRequest *ldap.DelRequest) error {
+	err := m.ldap.Del(delRequest)
+	m.audit("delete", delRequest.DN, nil, err)
+	return err
+}
+
+func (m *LDAPManager) modifyDN(modifyDNRequest *ldap.ModifyDNRequest) error {
+	err := m.ldap.ModifyDN(modifyDNRequest)
+	var attributes []string
+	if rdn := strings.SplitN(modifyDNRequest.NewRDN, "=", 2); len(rdn) == 2 {
+		attributes = append(attributes, rdn[0])
+	}
+	m.audit("rename", modifyDNRequest.DN, attributes, err)
+	return err
+}
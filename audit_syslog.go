@@ -0,0 +1,37 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package ldapmanager
+
+import (
+	"encoding/json"
+	"log/syslog"
+)
+
+// SyslogAuditSink sends audit records as JSON to syslog
+type SyslogAuditSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogAuditSink connects to the syslog daemon at raddr or the local syslog socket if network is empty
+func NewSyslogAuditSink(network, raddr string) (*SyslogAuditSink, error) {
+	writer, err := syslog.Dial(network, raddr, syslog.LOG_INFO|syslog.LOG_AUTH, "ldap-manager")
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogAuditSink{writer: writer}, nil
+}
+
+// Write ...
+func (s *SyslogAuditSink) Write(record *AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.writer.Info(string(line))
+}
+
+// Close ...
+func (s *SyslogAuditSink) Close() error {
+	return s.writer.Close()
+}
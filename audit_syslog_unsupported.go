@@ -0,0 +1,11 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package ldapmanager
+
+import "errors"
+
+// NewSyslogAuditSink is not supported on this platform
+func NewSyslogAuditSink(network, raddr string) (AuditSink, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
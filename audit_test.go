@@ -0,0 +1,134 @@
+package ldapmanager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+func testAuditRecords() []*AuditRecord {
+	now := time.Now().UTC()
+	return []*AuditRecord{
+		{Time: now.Add(-2 * time.Hour), Actor: "admin", Operation: "add", Target: "uid=a,ou=users,dc=example,dc=org", Result: AuditResultSuccess},
+		{Time: now.Add(-1 * time.Hour), Actor: "admin", Operation: "modify", Target: "uid=a,ou=users,dc=example,dc=org", Attributes: []string{"userPassword"}, Result: AuditResultSuccess},
+		{Time: now, Actor: "bob", Operation: "delete", Target: "cn=devs,ou=groups,dc=example,dc=org", Result: AuditResultFailure, Error: "no such object"},
+	}
+}
+
+// TestAuditLogQuery ...
+func TestAuditLogQuery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileSink, err := NewFileAuditSink(filepath.Join(dir, "audit.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, audit := range []*AuditLog{NewAuditLog(), NewAuditLog(fileSink)} {
+		for _, record := range testAuditRecords() {
+			audit.Record(record)
+		}
+		cases := []struct {
+			filter   *pb.GetAuditLogRequest
+			expected []string
+		}{
+			{&pb.GetAuditLogRequest{}, []string{"delete", "modify", "add"}},
+			{&pb.GetAuditLogRequest{Actor: "admin"}, []string{"modify", "add"}},
+			{&pb.GetAuditLogRequest{Target: "ou=groups"}, []string{"delete"}},
+			{&pb.GetAuditLogRequest{Result: AuditResultFailure}, []string{"delete"}},
+			{&pb.GetAuditLogRequest{Since: time.Now().Add(-90 * time.Minute).Unix()}, []string{"delete", "modify"}},
+			{&pb.GetAuditLogRequest{Limit: 1}, []string{"delete"}},
+		}
+		for _, c := range cases {
+			records, err := audit.Query(c.filter)
+			if err != nil {
+				t.Fatalf("failed to query audit log: %v", err)
+			}
+			var operations []string
+			for _, record := range records {
+				operations = append(operations, record.Operation)
+			}
+			if strings.Join(operations, ",") != strings.Join(c.expected, ",") {
+				t.Errorf("expected %v for filter %v but got %v", c.expected, c.filter, operations)
+			}
+		}
+		if err := audit.Close(); err != nil {
+			t.Errorf("failed to close audit log: %v", err)
+		}
+	}
+}
+
+// TestParseAuditSink ...
+func TestParseAuditSink(t *testing.T) {
+	for _, invalid := range []string{"", "file", "file:", "syslog:localhost", "kafka:topic"} {
+		if _, err := ParseAuditSink(invalid); err == nil {
+			t.Errorf("expected error when parsing invalid audit sink %q", invalid)
+		}
+	}
+	if _, err := ParseAuditSink("stdout"); err != nil {
+		t.Errorf("failed to parse stdout audit sink: %v", err)
+	}
+}
+
+// TestAuditMutations ...
+func TestAuditMutations(t *testing.T) {
+	if skipAuditTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	password := "Hallo Welt"
+	manager := test.Manager.As("someadmin")
+	if err := manager.NewAccount(&pb.NewAccountRequest{
+		Account: &pb.Account{
+			Username:  "romnn",
+			Password:  password,
+			Email:     "a@b.de",
+			FirstName: "roman",
+			LastName:  "d",
+		},
+	}, pb.HashingAlgorithm_CLEAR); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+	if err := manager.ChangePassword(&pb.ChangePasswordRequest{Username: "romnn", Password: "changed", HashingAlgorithm: pb.HashingAlgorithm_CLEAR}, false); err != nil {
+		t.Fatalf("failed to change password: %v", err)
+	}
+
+	auditLog, err := test.Manager.GetAuditLog(&pb.GetAuditLogRequest{Actor: "someadmin"})
+	if err != nil {
+		t.Fatalf("failed to get audit log: %v", err)
+	}
+	if len(auditLog.GetRecords()) < 2 {
+		t.Fatalf("expected at least two audit records but got %v", auditLog.GetRecords())
+	}
+	latest := auditLog.GetRecords()[0]
+	if latest.GetOperation() != "modify" || latest.GetTarget() != test.Manager.AccountNamed("romnn") {
+		t.Errorf("expected latest record to be the password change but got %v", latest)
+	}
+	if !contains(latest.GetAttributes(), "userPassword") {
+		t.Errorf("expected userPassword in changed attributes but got %v", latest.GetAttributes())
+	}
+	for _, record := range auditLog.GetRecords() {
+		if strings.Contains(record.String(), password) || strings.Contains(record.String(), "changed") {
+			t.Errorf("audit record must not contain the password: %v", record)
+		}
+	}
+
+	// mutations during the setup are attributed to the system
+	setupLog, err := test.Manager.GetAuditLog(&pb.GetAuditLogRequest{Actor: AuditActorSystem, Operation: "add"})
+	if err != nil {
+		t.Fatalf("failed to get audit log: %v", err)
+	}
+	if len(setupLog.GetRecords()) < 1 {
+		t.Error("expected setup mutations in the audit log")
+	}
+}
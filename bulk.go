@@ -0,0 +1,161 @@
+package ldapmanager
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// GeneratePassword can be used instead of a password to generate a random password for a new account
+	GeneratePassword = "generate"
+
+	// GeneratedPasswordLength ...
+	GeneratedPasswordLength = 16
+
+	// MaxBulkAccounts limits the number of accounts that can be created in a single bulk request
+	MaxBulkAccounts = 10000
+
+	passwordAlphabet = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789!#%+-=?@_"
+)
+
+// csvColumns are the supported columns of an accounts CSV file in their default order
+var csvColumns = []string{"username", "first_name", "last_name", "email", "groups", "password"}
+
+// GenerateRandomPassword generates a password that contains all character classes of the password policy
+func GenerateRandomPassword(length int) (string, error) {
+	password := make([]byte, length)
+	max := big.NewInt(int64(len(passwordAlphabet)))
+	policy := &PasswordPolicy{RequireLowercase: true, RequireUppercase: true, RequireDigit: true, RequireSpecial: true}
+	for {
+		for i := range password {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			password[i] = passwordAlphabet[n.Int64()]
+		}
+		if length < 4 || len(policy.Violations(string(password), nil)) == 0 {
+			return string(password), nil
+		}
+	}
+}
+
+// ParseAccountsCSV parses rows of username, first name, last name, email, groups (separated by ";") and password.
+// An optional header row may list the columns in a different order.
+func ParseAccountsCSV(r io.Reader) ([]*pb.BulkAccount, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %v", err)
+	}
+	columns := make(map[string]int)
+	for i, column := range csvColumns {
+		columns[column] = i
+	}
+	if len(rows) > 0 && strings.EqualFold(strings.TrimSpace(rows[0][0]), "username") {
+		columns = make(map[string]int)
+		for i, column := range rows[0] {
+			column = strings.ToLower(strings.TrimSpace(column))
+			column = strings.Replace(column, " ", "_", -1)
+			columns[column] = i
+		}
+		for _, required := range []string{"username", "email"} {
+			if _, ok := columns[required]; !ok {
+				return nil, fmt.Errorf("missing column %q", required)
+			}
+		}
+		rows = rows[1:]
+	}
+
+	var accounts []*pb.BulkAccount
+	for _, row := range rows {
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		var groups []string
+		for _, group := range strings.Split(value("groups"), ";") {
+			if group = strings.TrimSpace(group); group != "" {
+				groups = append(groups, group)
+			}
+		}
+		accounts = append(accounts, &pb.BulkAccount{
+			Account: &pb.Account{
+				Username:  value("username"),
+				FirstName: value("first_name"),
+				LastName:  value("last_name"),
+				Email:     value("email"),
+				Password:  value("password"),
+			},
+			Groups: groups,
+		})
+	}
+	return accounts, nil
+}
+
+// BulkNewAccounts creates all accounts and adds them to their groups.
+// Failures do not abort the request but are reported for each account.
+func (m *LDAPManager) BulkNewAccounts(req *pb.BulkNewAccountsRequest, algorithm pb.HashingAlgorithm) (*pb.BulkNewAccountsResponse, error) {
+	if len(req.GetAccounts()) > MaxBulkAccounts {
+		return nil, &ValidationError{Message: fmt.Sprintf("at most %d accounts can be created at once", MaxBulkAccounts)}
+	}
+	response := &pb.BulkNewAccountsResponse{}
+	for i, bulkAccount := range req.GetAccounts() {
+		account := bulkAccount.GetAccount()
+		result := &pb.BulkAccountResult{Row: int32(i + 1), Username: account.GetUsername()}
+		response.Results = append(response.Results, result)
+		if account == nil {
+			result.Error = "missing account"
+			continue
+		}
+
+		if account.GetPassword() == GeneratePassword {
+			password, err := GenerateRandomPassword(GeneratedPasswordLength)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate password: %v", err)
+			}
+			account.Password = password
+			result.GeneratedPassword = password
+		}
+		if err := m.NewAccount(&pb.NewAccountRequest{Account: account}, algorithm); err != nil {
+			result.GeneratedPassword = ""
+			if _, safe := err.(Error); safe {
+				result.Error = err.Error()
+			} else {
+				log.Error(err)
+				result.Error = "error while creating new account"
+			}
+			continue
+		}
+		result.Success = true
+		response.Created++
+
+		var failed []string
+		for _, group := range bulkAccount.GetGroups() {
+			allowNonExistent := false
+			if err := m.AddGroupMember(&pb.GroupMember{Group: group, Username: account.GetUsername()}, allowNonExistent); err != nil {
+				if _, ok := err.(*MemberAlreadyExistsError); !ok {
+					log.Warnf("failed to add %q to group %q: %v", account.GetUsername(), group, err)
+					failed = append(failed, group)
+				}
+			}
+		}
+		if len(failed) > 0 {
+			result.Error = fmt.Sprintf("account was created but could not be added to groups %s", strings.Join(failed, ", "))
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,123 @@
+package ldapmanager
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// TestParseAccountsCSV ...
+func TestParseAccountsCSV(t *testing.T) {
+	cases := []struct {
+		csv      string
+		expected []*pb.BulkAccount
+	}{
+		{
+			csv: "romnn,roman,d,a@b.de,admins;users,generate\n",
+			expected: []*pb.BulkAccount{
+				{
+					Account: &pb.Account{Username: "romnn", FirstName: "roman", LastName: "d", Email: "a@b.de", Password: "generate"},
+					Groups:  []string{"admins", "users"},
+				},
+			},
+		},
+		{
+			csv: "# comment\nUsername, Email, Password, First Name\nromnn, a@b.de, secret, roman\nother,c@d.de,,\n",
+			expected: []*pb.BulkAccount{
+				{Account: &pb.Account{Username: "romnn", FirstName: "roman", Email: "a@b.de", Password: "secret"}},
+				{Account: &pb.Account{Username: "other", Email: "c@d.de"}},
+			},
+		},
+	}
+	for _, c := range cases {
+		accounts, err := ParseAccountsCSV(strings.NewReader(c.csv))
+		if err != nil {
+			t.Fatalf("failed to parse %q: %v", c.csv, err)
+		}
+		if diff := cmp.Diff(c.expected, accounts, protocmp.Transform()); diff != "" {
+			t.Errorf("unexpected accounts for %q: %s", c.csv, diff)
+		}
+	}
+
+	if _, err := ParseAccountsCSV(strings.NewReader("username,first_name\nromnn,roman\n")); err == nil {
+		t.Errorf("expected an error for a header without an email column")
+	}
+}
+
+// TestGenerateRandomPassword ...
+func TestGenerateRandomPassword(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		password, err := GenerateRandomPassword(GeneratedPasswordLength)
+		if err != nil {
+			t.Fatalf("failed to generate password: %v", err)
+		}
+		if len(password) != GeneratedPasswordLength {
+			t.Errorf("expected password of length %d but got %q", GeneratedPasswordLength, password)
+		}
+		if seen[password] {
+			t.Errorf("generated the same password %q twice", password)
+		}
+		seen[password] = true
+	}
+}
+
+// TestBulkNewAccounts ...
+func TestBulkNewAccounts(t *testing.T) {
+	if skipBulkTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	if err := test.Manager.NewGroup(&pb.NewGroupRequest{Name: "developers"}, false); err != nil {
+		t.Fatalf("failed to add group: %v", err)
+	}
+	response, err := test.Manager.BulkNewAccounts(&pb.BulkNewAccountsRequest{
+		Accounts: []*pb.BulkAccount{
+			{
+				Account: &pb.Account{Username: "romnn", FirstName: "roman", LastName: "d", Email: "a@b.de", Password: GeneratePassword},
+				Groups:  []string{"developers"},
+			},
+			{
+				Account: &pb.Account{Username: "other", FirstName: "other", LastName: "d", Email: "c@d.de", Password: "Hallo Welt"},
+				Groups:  []string{"missing"},
+			},
+			{
+				Account: &pb.Account{Username: "romnn", FirstName: "roman", LastName: "d", Email: "a@b.de", Password: "Hallo Welt"},
+			},
+		},
+	}, pb.HashingAlgorithm_DEFAULT)
+	if err != nil {
+		t.Fatalf("failed to create accounts: %v", err)
+	}
+	results := response.GetResults()
+	if len(results) != 3 || response.GetCreated() != 2 {
+		t.Fatalf("expected 2 of 3 accounts to be created but got %v", results)
+	}
+	if !results[0].GetSuccess() || results[0].GetGeneratedPassword() == "" || results[0].GetError() != "" {
+		t.Errorf("expected first account to be created with a generated password but got %v", results[0])
+	}
+	if !results[1].GetSuccess() || results[1].GetError() == "" {
+		t.Errorf("expected second account to be created with a group error but got %v", results[1])
+	}
+	if results[2].GetSuccess() || results[2].GetError() == "" {
+		t.Errorf("expected duplicate account to fail but got %v", results[2])
+	}
+
+	if _, err := test.Manager.AuthenticateUser(&pb.LoginRequest{
+		Username: "romnn", Password: results[0].GetGeneratedPassword(),
+	}); err != nil {
+		t.Errorf("failed to authenticate with the generated password: %v", err)
+	}
+	status, err := test.Manager.IsGroupMember(&pb.IsGroupMemberRequest{Group: "developers", Username: "romnn"})
+	if err != nil {
+		t.Fatalf("failed to check group membership: %v", err)
+	}
+	if !status.GetIsMember() {
+		t.Errorf("expected romnn to be a member of developers")
+	}
+}
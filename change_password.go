@@ -11,7 +11,10 @@ import (
 )
 
 // ChangePassword ...
-func (m *LDAPManager) ChangePassword(req *pb.ChangePasswordRequest) error {
+//
+// selfService must only be set when the owner of the account changes the password,
+// which clears the reset flag of the password policy overlay
+func (m *LDAPManager) ChangePassword(req *pb.ChangePasswordRequest, selfService bool) error {
 	// Validate
 	if req.GetUsername() == "" || req.GetPassword() == "" {
 		return errors.New("username and password must not be empty")
@@ -24,7 +27,7 @@ func (m *LDAPManager) ChangePassword(req *pb.ChangePasswordRequest) error {
 		m.BaseDN,
 		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 		fmt.Sprintf("(%s=%s)", m.AccountAttribute, escapeFilter(req.GetUsername())),
-		[]string{"dn"},
+		[]string{"dn", "givenName", "sn", "userPassword"},
 		[]ldap.Control{},
 	))
 	if err != nil {
@@ -34,7 +37,17 @@ func (m *LDAPManager) ChangePassword(req *pb.ChangePasswordRequest) error {
 		return &ZeroOrMultipleAccountsError{Username: req.GetUsername(), Count: len(result.Entries)}
 	}
 	userDN := result.Entries[0].DN
-	hashedPassword, err := ldaphash.Password(req.GetPassword(), req.GetHashingAlgorithm())
+	if err := m.validPassword(req.GetPassword(), &pb.Account{
+		Username:  req.GetUsername(),
+		FirstName: result.Entries[0].GetAttributeValue("givenName"),
+		LastName:  result.Entries[0].GetAttributeValue("sn"),
+	}); err != nil {
+		return err
+	}
+	if err := m.checkPasswordHistory(req.GetUsername(), result.Entries[0].GetAttributeValue("userPassword"), req.GetPassword()); err != nil {
+		return err
+	}
+	hashedPassword, err := ldaphash.PasswordWithOptions(req.GetPassword(), req.GetHashingAlgorithm(), m.HashingOptions)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %v", err)
 	}
@@ -44,9 +57,17 @@ func (m *LDAPManager) ChangePassword(req *pb.ChangePasswordRequest) error {
 	)
 	modifyPasswordRequest.Replace("userPassword", []string{hashedPassword})
 	log.Debugf("modifyPasswordRequest=%v", modifyPasswordRequest)
-	if err := m.ldap.Modify(modifyPasswordRequest); err != nil {
+	if err := m.modify(modifyPasswordRequest); err != nil {
 		return fmt.Errorf("failed to modify existing user: %v", err)
 	}
+	if err := m.recordPasswordHistory(req.GetUsername(), hashedPassword); err != nil {
+		log.Warn(err)
+	}
+	if m.PPolicyOverlay && selfService {
+		if err := m.clearPasswordReset(userDN); err != nil {
+			log.Warn(err)
+		}
+	}
 	log.Infof("changed password for user %q", req.GetUsername())
 	return nil
 }
@@ -40,7 +40,7 @@ func TestChangePassword(t *testing.T) {
 	if err := test.Manager.ChangePassword(&pb.ChangePasswordRequest{
 		Username: username,
 		Password: "", // invalid
-	}); err == nil {
+	}, false); err == nil {
 		t.Fatalf("expected error changing the password for user %q to be empty", username)
 	}
 
@@ -49,7 +49,7 @@ func TestChangePassword(t *testing.T) {
 	if err := test.Manager.ChangePassword(&pb.ChangePasswordRequest{
 		Username: username,
 		Password: newPassword, // valid
-	}); err != nil {
+	}, false); err != nil {
 		t.Fatalf("failed to change password of user %q to %q: %v", username, newPassword, err)
 	}
 
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	ldapmanager "github.com/romnn/ldap-manager"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	"github.com/urfave/cli/v2"
+)
+
+func accountFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{Name: "first-name", Usage: "first name"},
+		&cli.StringFlag{Name: "last-name", Usage: "last name"},
+		&cli.StringFlag{Name: "email", Usage: "email address"},
+		&cli.IntFlag{Name: "uid", Usage: "uidNumber (default is to allocate the next free uid)"},
+		&cli.IntFlag{Name: "gid", Usage: "gidNumber"},
+		&cli.StringFlag{Name: "login-shell", Usage: "login shell"},
+		&cli.StringFlag{Name: "home-directory", Usage: "home directory"},
+		&cli.StringFlag{Name: "expires", Usage: "date (YYYY-MM-DD) from which on the account can no longer be used, or \"never\""},
+	}
+}
+
+func accountFromFlags(ctx *cli.Context) *pb.Account {
+	return &pb.Account{
+		FirstName:     ctx.String("first-name"),
+		LastName:      ctx.String("last-name"),
+		Email:         ctx.String("email"),
+		Uid:           int32(ctx.Int("uid")),
+		Gid:           int32(ctx.Int("gid")),
+		LoginShell:    ctx.String("login-shell"),
+		HomeDirectory: ctx.String("home-directory"),
+		Expires:       ctx.String("expires"),
+	}
+}
+
+// readPassword returns the password flag, a generated password or reads the password from stdin
+func readPassword(ctx *cli.Context) (password string, generated bool, err error) {
+	password = ctx.String("password")
+	if password == ldapmanager.GeneratePassword {
+		password, err = ldapmanager.GenerateRandomPassword(ldapmanager.GeneratedPasswordLength)
+		return password, true, err
+	}
+	if password != "" {
+		return password, false, nil
+	}
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", false, fmt.Errorf("failed to read password: %v", err)
+	}
+	return strings.TrimRight(line, "\r\n"), false, nil
+}
+
+func passwordFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "password",
+		Usage: "password, or \"generate\" to generate a random password (default is to read it from stdin)",
+	}
+}
+
+func accountCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "account",
+		Usage: "manage accounts",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "create",
+				Usage:     "create a new account",
+				ArgsUsage: "USERNAME",
+				Flags: clientFlags(append(accountFlags(),
+					passwordFlag(),
+					&cli.StringFlag{Name: "id-pool", Usage: "allocate the uid from this named pool"},
+				)...),
+				Action: withClient([]string{"USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					password, generated, err := readPassword(ctx)
+					if err != nil {
+						return err
+					}
+					account := accountFromFlags(ctx)
+					account.Username = ctx.Args().First()
+					account.Password = password
+					account.IdPool = ctx.String("id-pool")
+					if err := client.NewAccount(&pb.NewAccountRequest{Account: account}); err != nil {
+						return err
+					}
+					result := map[string]interface{}{"username": account.GetUsername()}
+					message := fmt.Sprintf("created account %q", account.GetUsername())
+					if generated {
+						result["password"] = password
+						message += fmt.Sprintf(" with password %s", password)
+					}
+					return printResult(ctx, message, result)
+				}),
+			},
+			{
+				Name:      "get",
+				Usage:     "show an account",
+				ArgsUsage: "USERNAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					user, err := client.GetAccount(&pb.GetAccountRequest{Username: ctx.Args().First()})
+					if err != nil {
+						return err
+					}
+					return printMessage(ctx, user, []string{"attribute", "value"}, sortedRows(user.GetData()))
+				}),
+			},
+			{
+				Name:      "update",
+				Usage:     "update an account",
+				ArgsUsage: "USERNAME",
+				Flags: clientFlags(append(accountFlags(),
+					&cli.StringFlag{Name: "rename", Usage: "new username"},
+				)...),
+				Action: withClient([]string{"USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					username := ctx.Args().First()
+					update := accountFromFlags(ctx)
+					update.Username = ctx.String("rename")
+					if err := client.UpdateAccount(&pb.UpdateAccountRequest{Username: username, Update: update}); err != nil {
+						return err
+					}
+					if update.GetUsername() != "" {
+						username = update.GetUsername()
+					}
+					return printResult(ctx, fmt.Sprintf("updated account %q", username), map[string]interface{}{"username": username})
+				}),
+			},
+			{
+				Name:      "delete",
+				Usage:     "delete an account",
+				ArgsUsage: "USERNAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					username := ctx.Args().First()
+					if err := client.DeleteAccount(&pb.DeleteAccountRequest{Username: username}); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("deleted account %q", username), map[string]interface{}{"username": username})
+				}),
+			},
+			{
+				Name:      "lock",
+				Usage:     "lock an account until it is unlocked again",
+				ArgsUsage: "USERNAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					username := ctx.Args().First()
+					if err := client.LockAccount(&pb.LockAccountRequest{Username: username}); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("locked account %q", username), map[string]interface{}{"username": username})
+				}),
+			},
+			{
+				Name:      "unlock",
+				Usage:     "unlock a locked account",
+				ArgsUsage: "USERNAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					username := ctx.Args().First()
+					if err := client.UnlockAccount(&pb.UnlockAccountRequest{Username: username}); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("unlocked account %q", username), map[string]interface{}{"username": username})
+				}),
+			},
+			{
+				Name:      "revoke-sessions",
+				Usage:     "log out an account everywhere by revoking all of its sessions",
+				ArgsUsage: "USERNAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					username := ctx.Args().First()
+					if err := client.RevokeSessions(&pb.RevokeSessionsRequest{Username: username}); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("revoked sessions of account %q", username), map[string]interface{}{"username": username})
+				}),
+			},
+			{
+				Name:      "passwd",
+				Usage:     "change the password of an account",
+				ArgsUsage: "USERNAME",
+				Flags:     clientFlags(passwordFlag()),
+				Action: withClient([]string{"USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					username := ctx.Args().First()
+					password, generated, err := readPassword(ctx)
+					if err != nil {
+						return err
+					}
+					if err := client.ChangePassword(&pb.ChangePasswordRequest{Username: username, Password: password}); err != nil {
+						return err
+					}
+					result := map[string]interface{}{"username": username}
+					message := fmt.Sprintf("changed password of %q", username)
+					if generated {
+						result["password"] = password
+						message += fmt.Sprintf(" to %s", password)
+					}
+					return printResult(ctx, message, result)
+				}),
+			},
+		},
+	}
+}
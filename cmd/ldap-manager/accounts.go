@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+
+	ldapmanager "github.com/romnn/ldap-manager"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	"github.com/urfave/cli/v2"
+)
+
+func accountsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "accounts",
+		Usage: "manage accounts in bulk",
+		Subcommands: []*cli.Command{
+			accountsImportCommand(),
+		},
+	}
+}
+
+func accountsImportCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "create accounts from a CSV file with columns username, first_name, last_name, email, groups and password",
+		ArgsUsage: "FILE",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "passwords-output",
+				Usage: "file to write generated passwords to (required if any password is \"generate\")",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return cli.Exit("expected exactly one CSV file", 2)
+			}
+			file, err := os.Open(ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			accounts, err := ldapmanager.ParseAccountsCSV(file)
+			if err != nil {
+				return cli.Exit(err.Error(), 2)
+			}
+			passwordsOutput := ctx.String("passwords-output")
+			generates := false
+			for _, account := range accounts {
+				if account.GetAccount().GetPassword() == ldapmanager.GeneratePassword {
+					generates = true
+				}
+			}
+			var passwords *csv.Writer
+			if generates {
+				if passwordsOutput == "" {
+					return cli.Exit("--passwords-output is required when passwords are generated", 2)
+				}
+				// the file is created before any account, so generated passwords can not get lost
+				passwordsFile, err := createPasswordsFile(passwordsOutput)
+				if err != nil {
+					return fmt.Errorf("failed to create passwords file: %v", err)
+				}
+				defer passwordsFile.Close()
+				passwords = csv.NewWriter(passwordsFile)
+			}
+
+			manager, err := connect(ctx)
+			if err != nil {
+				return err
+			}
+			defer manager.Close()
+
+			response, err := manager.BulkNewAccounts(&pb.BulkNewAccountsRequest{Accounts: accounts}, pb.HashingAlgorithm_DEFAULT)
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+			var writeErr error
+			for _, result := range response.GetResults() {
+				status := "created"
+				if !result.GetSuccess() {
+					status = "failed"
+					failed++
+				}
+				if result.GetError() != "" {
+					fmt.Printf("%-4d %-8s %s (%s)\n", result.GetRow(), status, result.GetUsername(), result.GetError())
+				} else {
+					fmt.Printf("%-4d %-8s %s\n", result.GetRow(), status, result.GetUsername())
+				}
+				if result.GetGeneratedPassword() != "" && writeErr == nil {
+					if err := writePassword(passwords, result.GetUsername(), result.GetGeneratedPassword()); err != nil {
+						writeErr = fmt.Errorf("failed to write generated password of %q: %v", result.GetUsername(), err)
+					}
+				}
+			}
+			fmt.Printf("%d created, %d failed\n", response.GetCreated(), failed)
+			if writeErr != nil {
+				return writeErr
+			}
+			if failed > 0 {
+				return cli.Exit("some accounts could not be created", 1)
+			}
+			return nil
+		},
+	}
+}
+
+// createPasswordsFile creates a new CSV file for generated passwords that is only readable by the owner.
+// Existing files are never overwritten.
+func createPasswordsFile(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	writer := csv.NewWriter(file)
+	if err := writer.Write([]string{"username", "password"}); err != nil {
+		file.Close()
+		return nil, err
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+// writePassword writes a username and password pair to the passwords file right away
+func writePassword(writer *csv.Writer, username, password string) error {
+	if writer == nil {
+		return fmt.Errorf("no passwords file")
+	}
+	if err := writer.Write([]string{username, password}); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
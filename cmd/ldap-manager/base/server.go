@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 
 	gogrpcservice "github.com/romnn/go-grpc-service"
 	"github.com/romnn/go-grpc-service/auth"
 	ldapmanager "github.com/romnn/ldap-manager"
 	ldapconfig "github.com/romnn/ldap-manager/config"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	ldaphash "github.com/romnn/ldap-manager/hash"
 	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
@@ -34,8 +37,8 @@ func (s *LDAPManagerServer) Shutdown() {
 	}
 }
 
-// NewLDAPManagerServer ...
-func NewLDAPManagerServer(ctx *cli.Context) *LDAPManagerServer {
+// NewLDAPManager creates a manager for the OpenLDAP server configured by the command line flags
+func NewLDAPManager(ctx *cli.Context) (*ldapmanager.LDAPManager, error) {
 	hasReadonlyUser := ctx.String("openldap-readonly-user") != ""
 	baseDN := ctx.String("openldap-base-dn")
 	groupsOU := ctx.String("groups-ou")
@@ -50,6 +53,118 @@ func NewLDAPManagerServer(ctx *cli.Context) *LDAPManagerServer {
 		userGroupDN = fmt.Sprintf("ou=%s,%s", usersOU, baseDN)
 	}
 
+	passwordHistoryDN := ctx.String("password-history-dn")
+	if passwordHistoryDN == "" {
+		passwordHistoryDN = fmt.Sprintf("ou=password-history,%s", baseDN)
+	}
+
+	passwordResetDN := ctx.String("password-reset-dn")
+	if passwordResetDN == "" {
+		passwordResetDN = fmt.Sprintf("ou=password-resets,%s", baseDN)
+	}
+	ppolicyDN := ctx.String("ppolicy-dn")
+	if ppolicyDN == "" {
+		ppolicyDN = fmt.Sprintf("ou=policies,%s", baseDN)
+	}
+
+	sessionDN := ctx.String("sessions-dn")
+	if sessionDN == "" {
+		sessionDN = fmt.Sprintf("ou=sessions,%s", baseDN)
+	}
+
+	joinRequestDN := ctx.String("join-requests-dn")
+	if joinRequestDN == "" {
+		joinRequestDN = fmt.Sprintf("ou=requests,%s", baseDN)
+	}
+
+	var mailSender ldapmanager.MailSender
+	if spec := ctx.String("mail-sender"); spec != "" {
+		sender, err := ldapmanager.ParseMailSender(spec)
+		if err != nil {
+			return nil, err
+		}
+		mailSender = sender
+	}
+
+	if ctx.Bool("require-admin-totp") && ctx.String("totp-key") == "" {
+		return nil, fmt.Errorf("requiring two-factor authentication for admins requires a TOTP key")
+	}
+	twoFactorAttribute := ctx.String("totp-attribute")
+	if twoFactorAttribute == "" {
+		twoFactorAttribute = ldapmanager.DefaultTwoFactorAttribute
+	}
+
+	uidPools, err := ldapmanager.ParseIDPools(ctx.StringSlice("uid-pool"))
+	if err != nil {
+		return nil, err
+	}
+	gidPools, err := ldapmanager.ParseIDPools(ctx.StringSlice("gid-pool"))
+	if err != nil {
+		return nil, err
+	}
+
+	groupMembershipSyncAttributes, err := ldapmanager.ParseGroupMembershipSyncAttributes(
+		ctx.StringSlice("group-membership-sync-attribute"),
+		ctx.String("group-membership-attribute"),
+		ctx.Bool("openldap-use-rfc2307bis"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := ldapmanager.ParseRoles(ctx.StringSlice("role"))
+	if err != nil {
+		return nil, err
+	}
+	roleGroups, err := ldapmanager.ParseRoleGroups(ctx.StringSlice("role-groups"), roles)
+	if err != nil {
+		return nil, err
+	}
+
+	var auditSinks []ldapmanager.AuditSink
+	for _, spec := range ctx.StringSlice("audit-sink") {
+		sink, err := ldapmanager.ParseAuditSink(spec)
+		if err != nil {
+			return nil, err
+		}
+		auditSinks = append(auditSinks, sink)
+	}
+
+	passwordPolicy := ldapmanager.PasswordPolicy{
+		MinLength:          ctx.Int("password-min-length"),
+		RequireLowercase:   ctx.Bool("password-require-lowercase"),
+		RequireUppercase:   ctx.Bool("password-require-uppercase"),
+		RequireDigit:       ctx.Bool("password-require-digit"),
+		RequireSpecial:     ctx.Bool("password-require-special"),
+		RejectPersonalInfo: ctx.Bool("password-reject-personal-info"),
+	}
+	if path := ctx.String("password-breached-list"); path != "" {
+		if passwordPolicy.Breached, err = ldapmanager.LoadBreachedPasswords(path); err != nil {
+			return nil, err
+		}
+	}
+
+	hashingOptions := ldaphash.Options{
+		BcryptCost:       ctx.Int("bcrypt-cost"),
+		EXTDESRounds:     ctx.Int("extdes-rounds"),
+		Argon2Time:       ctx.Int("argon2-time"),
+		Argon2Memory:     ctx.Int("argon2-memory"),
+		Argon2Threads:    ctx.Int("argon2-threads"),
+		PBKDF2Iterations: ctx.Int("pbkdf2-iterations"),
+	}
+	if err := hashingOptions.Validate(); err != nil {
+		return nil, err
+	}
+
+	hashSchemeStrength, err := ldapmanager.ParseHashSchemeStrength(ctx.StringSlice("hash-scheme-strength"))
+	if err != nil {
+		return nil, err
+	}
+	hashingAlgorithm, ok := pb.HashingAlgorithm_value[strings.ToUpper(ctx.String("hashing-algorithm"))]
+	if !ok {
+		return nil, fmt.Errorf("unknown hashing algorithm %q", ctx.String("hashing-algorithm"))
+	}
+
 	manager := &ldapmanager.LDAPManager{
 		OpenLDAPConfig: ldapconfig.OpenLDAPConfig{
 			Host:                 ctx.String("openldap-host"),
@@ -66,20 +181,68 @@ func NewLDAPManagerServer(ctx *cli.Context) *LDAPManagerServer {
 			TLS:                  ctx.Bool("openldap-tls"),
 			UseRFC2307BISSchema:  ctx.Bool("openldap-use-rfc2307bis"),
 		},
-		GroupsOU:                 groupsOU,
-		UsersOU:                  usersOU,
-		GroupsDN:                 groupsDN,
-		UserGroupDN:              userGroupDN,
-		GroupMembershipAttribute: ctx.String("group-membership-attribute"),
-		GroupMembershipUsesUID:   ctx.Bool("group-membership-uses-uid"),
-		AccountAttribute:         ctx.String("account-attribute"),
-		GroupAttribute:           ctx.String("group-attribute"),
-		DefaultUserGroup:         ctx.String("default-user-group"),
-		DefaultAdminGroup:        ctx.String("default-admin-group"),
-		DefaultUserShell:         ctx.String("default-login-shell"),
-		DefaultAdminUsername:     ctx.String("default-admin-username"),
-		DefaultAdminPassword:     ctx.String("default-admin-password"),
-		ForceCreateAdmin:         ctx.Bool("force-create-admin"),
+		Pool: ldapmanager.ConnPoolConfig{
+			Size:                ctx.Int("openldap-pool-size"),
+			MaxRetries:          ctx.Int("openldap-pool-max-retries"),
+			MaxBackoff:          ctx.Duration("openldap-pool-max-backoff"),
+			HealthCheckInterval: ctx.Duration("openldap-pool-health-check-interval"),
+			AcquireTimeout:      ctx.Duration("openldap-pool-acquire-timeout"),
+		},
+		GroupsOU:                      groupsOU,
+		UsersOU:                       usersOU,
+		GroupsDN:                      groupsDN,
+		UserGroupDN:                   userGroupDN,
+		GroupMembershipAttribute:      ctx.String("group-membership-attribute"),
+		GroupMembershipUsesUID:        ctx.Bool("group-membership-uses-uid"),
+		GroupMembershipSyncAttributes: groupMembershipSyncAttributes,
+		AccountAttribute:              ctx.String("account-attribute"),
+		GroupAttribute:                ctx.String("group-attribute"),
+		DefaultUserGroup:              ctx.String("default-user-group"),
+		DefaultAdminGroup:             ctx.String("default-admin-group"),
+		DefaultUserShell:              ctx.String("default-login-shell"),
+		DefaultAdminUsername:          ctx.String("default-admin-username"),
+		DefaultAdminPassword:          ctx.String("default-admin-password"),
+		ForceCreateAdmin:              ctx.Bool("force-create-admin"),
+		UIDRange:                      ldapmanager.IDRange{Min: ctx.Int("uid-min"), Max: ctx.Int("uid-max")},
+		GIDRange:                      ldapmanager.IDRange{Min: ctx.Int("gid-min"), Max: ctx.Int("gid-max")},
+		UIDPools:                      uidPools,
+		GIDPools:                      gidPools,
+		PasswordPolicy:                passwordPolicy,
+		HashingAlgorithm:              pb.HashingAlgorithm(hashingAlgorithm),
+		HashingOptions:                hashingOptions,
+		UpgradeHashesOnLogin:          ctx.Bool("upgrade-hashes-on-login"),
+		HashSchemeStrength:            hashSchemeStrength,
+		PasswordHistorySize:           ctx.Int("password-history"),
+		PasswordHistoryDN:             passwordHistoryDN,
+		MailSender:                    mailSender,
+		PasswordResetURL:              ctx.String("password-reset-url"),
+		PasswordResetDN:               passwordResetDN,
+		PasswordResetTTL:              ctx.Duration("password-reset-ttl"),
+		PasswordResetRateLimit:        ctx.Int("password-reset-rate-limit"),
+		PasswordResetRateWindow:       ctx.Duration("password-reset-rate-window"),
+		TwoFactorKey:                  ctx.String("totp-key"),
+		TwoFactorAttribute:            twoFactorAttribute,
+		TwoFactorIssuer:               ctx.String("totp-issuer"),
+		RequireTwoFactorForAdmins:     ctx.Bool("require-admin-totp"),
+		Roles:                         roles,
+		RoleGroups:                    roleGroups,
+		PPolicyOverlay:                ctx.Bool("ppolicy"),
+		PPolicyDN:                     ppolicyDN,
+		SessionDN:                     sessionDN,
+		SessionTTL:                    ctx.Duration("session-ttl"),
+		JoinRequestDN:                 joinRequestDN,
+		JoinRequestTTL:                ctx.Duration("join-request-ttl"),
+		Audit:                         ldapmanager.NewAuditLog(auditSinks...),
+	}
+
+	return manager, nil
+}
+
+// NewLDAPManagerServer ...
+func NewLDAPManagerServer(ctx *cli.Context) (*LDAPManagerServer, error) {
+	manager, err := NewLDAPManager(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	return &LDAPManagerServer{
@@ -98,7 +261,7 @@ func NewLDAPManagerServer(ctx *cli.Context) *LDAPManagerServer {
 		Static:        !ctx.Bool("no-static"),
 		StaticRoot:    ctx.String("static-root"),
 		Manager:       manager,
-	}
+	}, nil
 }
 
 // Setup prepares the service
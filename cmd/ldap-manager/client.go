@@ -0,0 +1,516 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/romnn/flags4urfavecli/values"
+	ldapmanager "github.com/romnn/ldap-manager"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// managerClient is the part of the API used by the administrative commands.
+// It is implemented by the manager itself and by a client of a running server.
+type managerClient interface {
+	GetAccount(req *pb.GetAccountRequest) (*pb.User, error)
+	NewAccount(req *pb.NewAccountRequest) error
+	UpdateAccount(req *pb.UpdateAccountRequest) error
+	DeleteAccount(req *pb.DeleteAccountRequest) error
+	LockAccount(req *pb.LockAccountRequest) error
+	UnlockAccount(req *pb.UnlockAccountRequest) error
+	RevokeSessions(req *pb.RevokeSessionsRequest) error
+	ChangePassword(req *pb.ChangePasswordRequest) error
+	NewGroup(req *pb.NewGroupRequest) error
+	DeleteGroup(req *pb.DeleteGroupRequest) error
+	UpdateGroup(req *pb.UpdateGroupRequest) error
+	GetGroupList(req *pb.GetGroupListRequest) (*pb.GroupList, error)
+	GetGroup(req *pb.GetGroupRequest) (*pb.Group, error)
+	GetOwnedGroups(req *pb.GetOwnedGroupsRequest) (*pb.GroupList, error)
+	RepairGroupMembership(req *pb.RepairGroupMembershipRequest) (*pb.GroupMembershipRepairs, error)
+	AddGroupMember(req *pb.GroupMember) error
+	DeleteGroupMember(req *pb.GroupMember) error
+	IsGroupMember(req *pb.IsGroupMemberRequest) (*pb.GroupMemberStatus, error)
+	RequestGroupMembership(req *pb.GroupMember) (*pb.JoinRequest, error)
+	ListPendingRequests(req *pb.ListPendingRequestsRequest) (*pb.JoinRequestList, error)
+	ApproveRequest(req *pb.JoinRequestDecision) error
+	DenyRequest(req *pb.JoinRequestDecision) error
+	Close()
+}
+
+// directClient uses the manager directly against the directory
+type directClient struct {
+	manager *ldapmanager.LDAPManager
+}
+
+func (c *directClient) GetAccount(req *pb.GetAccountRequest) (*pb.User, error) {
+	return c.manager.GetAccount(req)
+}
+
+func (c *directClient) NewAccount(req *pb.NewAccountRequest) error {
+	return c.manager.NewAccount(req, pb.HashingAlgorithm_DEFAULT)
+}
+
+func (c *directClient) UpdateAccount(req *pb.UpdateAccountRequest) error {
+	isAdmin := true
+	_, _, err := c.manager.UpdateAccount(req, pb.HashingAlgorithm_DEFAULT, isAdmin)
+	return err
+}
+
+func (c *directClient) DeleteAccount(req *pb.DeleteAccountRequest) error {
+	allowDeleteOfDefaultGroups := false
+	return c.manager.DeleteAccount(req, allowDeleteOfDefaultGroups)
+}
+
+func (c *directClient) LockAccount(req *pb.LockAccountRequest) error {
+	return c.manager.LockAccount(req)
+}
+
+func (c *directClient) UnlockAccount(req *pb.UnlockAccountRequest) error {
+	return c.manager.UnlockAccount(req)
+}
+
+func (c *directClient) RevokeSessions(req *pb.RevokeSessionsRequest) error {
+	return c.manager.RevokeSessions(req)
+}
+
+func (c *directClient) ChangePassword(req *pb.ChangePasswordRequest) error {
+	return c.manager.ChangePassword(req, false)
+}
+
+func (c *directClient) NewGroup(req *pb.NewGroupRequest) error {
+	strict := false
+	return c.manager.NewGroup(req, strict)
+}
+
+func (c *directClient) DeleteGroup(req *pb.DeleteGroupRequest) error {
+	return c.manager.DeleteGroup(req)
+}
+
+func (c *directClient) UpdateGroup(req *pb.UpdateGroupRequest) error {
+	return c.manager.UpdateGroup(req)
+}
+
+func (c *directClient) GetGroupList(req *pb.GetGroupListRequest) (*pb.GroupList, error) {
+	return c.manager.GetGroupList(req)
+}
+
+func (c *directClient) GetGroup(req *pb.GetGroupRequest) (*pb.Group, error) {
+	return c.manager.GetGroup(req)
+}
+
+func (c *directClient) GetOwnedGroups(req *pb.GetOwnedGroupsRequest) (*pb.GroupList, error) {
+	return c.manager.GetOwnedGroups(req)
+}
+
+func (c *directClient) RepairGroupMembership(req *pb.RepairGroupMembershipRequest) (*pb.GroupMembershipRepairs, error) {
+	return c.manager.RepairGroupMembership(req)
+}
+
+func (c *directClient) AddGroupMember(req *pb.GroupMember) error {
+	allowNonExistent := false
+	return c.manager.AddGroupMember(req, allowNonExistent)
+}
+
+func (c *directClient) DeleteGroupMember(req *pb.GroupMember) error {
+	allowDeleteOfDefaultGroups := false
+	return c.manager.DeleteGroupMember(req, allowDeleteOfDefaultGroups)
+}
+
+func (c *directClient) IsGroupMember(req *pb.IsGroupMemberRequest) (*pb.GroupMemberStatus, error) {
+	return c.manager.IsGroupMember(req)
+}
+
+func (c *directClient) RequestGroupMembership(req *pb.GroupMember) (*pb.JoinRequest, error) {
+	return c.manager.RequestGroupMembership(req)
+}
+
+func (c *directClient) ListPendingRequests(req *pb.ListPendingRequestsRequest) (*pb.JoinRequestList, error) {
+	return c.manager.ListPendingRequests(req)
+}
+
+func (c *directClient) ApproveRequest(req *pb.JoinRequestDecision) error {
+	return c.manager.ApproveRequest(req)
+}
+
+func (c *directClient) DenyRequest(req *pb.JoinRequestDecision) error {
+	return c.manager.DenyRequest(req)
+}
+
+func (c *directClient) Close() {
+	c.manager.Close()
+}
+
+// grpcClient calls a running ldap manager server
+type grpcClient struct {
+	conn    *grpc.ClientConn
+	client  pb.LDAPManagerClient
+	token   string
+	timeout time.Duration
+	// loggedIn is set if the client started its own session, which ends when the client is closed
+	loggedIn bool
+}
+
+func (c *grpcClient) context() (context.Context, context.CancelFunc) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-user-token", c.token)
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+func (c *grpcClient) GetAccount(req *pb.GetAccountRequest) (*pb.User, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetAccount(ctx, req)
+}
+
+func (c *grpcClient) NewAccount(req *pb.NewAccountRequest) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.NewAccount(ctx, req)
+	return err
+}
+
+func (c *grpcClient) UpdateAccount(req *pb.UpdateAccountRequest) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.UpdateAccount(ctx, req)
+	return err
+}
+
+func (c *grpcClient) DeleteAccount(req *pb.DeleteAccountRequest) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.DeleteAccount(ctx, req)
+	return err
+}
+
+func (c *grpcClient) LockAccount(req *pb.LockAccountRequest) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.LockAccount(ctx, req)
+	return err
+}
+
+func (c *grpcClient) UnlockAccount(req *pb.UnlockAccountRequest) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.UnlockAccount(ctx, req)
+	return err
+}
+
+func (c *grpcClient) RevokeSessions(req *pb.RevokeSessionsRequest) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.RevokeSessions(ctx, req)
+	return err
+}
+
+func (c *grpcClient) ChangePassword(req *pb.ChangePasswordRequest) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.ChangePassword(ctx, req)
+	return err
+}
+
+func (c *grpcClient) NewGroup(req *pb.NewGroupRequest) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.NewGroup(ctx, req)
+	return err
+}
+
+func (c *grpcClient) DeleteGroup(req *pb.DeleteGroupRequest) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.DeleteGroup(ctx, req)
+	return err
+}
+
+func (c *grpcClient) UpdateGroup(req *pb.UpdateGroupRequest) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.UpdateGroup(ctx, req)
+	return err
+}
+
+func (c *grpcClient) GetGroupList(req *pb.GetGroupListRequest) (*pb.GroupList, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetGroupList(ctx, req)
+}
+
+func (c *grpcClient) GetGroup(req *pb.GetGroupRequest) (*pb.Group, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetGroup(ctx, req)
+}
+
+func (c *grpcClient) GetOwnedGroups(req *pb.GetOwnedGroupsRequest) (*pb.GroupList, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.GetOwnedGroups(ctx, req)
+}
+
+func (c *grpcClient) RepairGroupMembership(req *pb.RepairGroupMembershipRequest) (*pb.GroupMembershipRepairs, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.RepairGroupMembership(ctx, req)
+}
+
+func (c *grpcClient) AddGroupMember(req *pb.GroupMember) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.AddGroupMember(ctx, req)
+	return err
+}
+
+func (c *grpcClient) DeleteGroupMember(req *pb.GroupMember) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.DeleteGroupMember(ctx, req)
+	return err
+}
+
+func (c *grpcClient) IsGroupMember(req *pb.IsGroupMemberRequest) (*pb.GroupMemberStatus, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.IsGroupMember(ctx, req)
+}
+
+func (c *grpcClient) RequestGroupMembership(req *pb.GroupMember) (*pb.JoinRequest, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.RequestGroupMembership(ctx, req)
+}
+
+func (c *grpcClient) ListPendingRequests(req *pb.ListPendingRequestsRequest) (*pb.JoinRequestList, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.ListPendingRequests(ctx, req)
+}
+
+func (c *grpcClient) ApproveRequest(req *pb.JoinRequestDecision) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.ApproveRequest(ctx, req)
+	return err
+}
+
+func (c *grpcClient) DenyRequest(req *pb.JoinRequestDecision) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.DenyRequest(ctx, req)
+	return err
+}
+
+func (c *grpcClient) Close() {
+	if c.loggedIn {
+		ctx, cancel := c.context()
+		defer cancel()
+		if _, err := c.client.Logout(ctx, &pb.Empty{}); err != nil {
+			log.Warnf("failed to log out: %v", err)
+		}
+	}
+	c.conn.Close()
+}
+
+// clientFlags are shared by all administrative commands
+func clientFlags(flags ...cli.Flag) []cli.Flag {
+	return append(flags,
+		&cli.GenericFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Value: &values.EnumValue{
+				Enum:    []string{outputTable, outputJSON},
+				Default: outputTable,
+			},
+			EnvVars: []string{"OUTPUT"},
+			Usage:   "output format",
+		},
+		&cli.StringFlag{
+			Name:    "server",
+			EnvVars: []string{"LDAP_MANAGER_SERVER"},
+			Usage:   "address of a running ldap manager grpc server (default is to connect to OpenLDAP directly)",
+		},
+		&cli.StringFlag{
+			Name:    "token",
+			EnvVars: []string{"LDAP_MANAGER_TOKEN"},
+			Usage:   "authentication token for the grpc server",
+		},
+		&cli.StringFlag{
+			Name:    "login-username",
+			EnvVars: []string{"LDAP_MANAGER_USERNAME"},
+			Usage:   "login to the grpc server as this user if no token is given",
+		},
+		&cli.StringFlag{
+			Name:    "login-password",
+			EnvVars: []string{"LDAP_MANAGER_PASSWORD"},
+			Usage:   "password used to login to the grpc server",
+		},
+		&cli.StringFlag{
+			Name:    "login-otp",
+			EnvVars: []string{"LDAP_MANAGER_OTP"},
+			Usage:   "one-time password or recovery code used to login to the grpc server with two-factor authentication",
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Value: 30 * time.Second,
+			Usage: "timeout of requests to the grpc server",
+		},
+	)
+}
+
+// newClient connects to the grpc server if one is given, otherwise directly to OpenLDAP
+func newClient(ctx *cli.Context) (managerClient, error) {
+	address := ctx.String("server")
+	if address == "" {
+		manager, err := connect(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return &directClient{manager: manager.As(cliActor())}, nil
+	}
+
+	dialCtx, cancel := context.WithTimeout(context.Background(), ctx.Duration("timeout"))
+	defer cancel()
+	conn, err := grpc.DialContext(dialCtx, address, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to connect to %q: %v", address, err)
+	}
+	client := &grpcClient{
+		conn:    conn,
+		client:  pb.NewLDAPManagerClient(conn),
+		token:   ctx.String("token"),
+		timeout: ctx.Duration("timeout"),
+	}
+	if client.token == "" && ctx.String("login-username") != "" {
+		loginCtx, cancel := client.context()
+		defer cancel()
+		token, err := client.client.Login(loginCtx, &pb.LoginRequest{
+			Username: ctx.String("login-username"),
+			Password: ctx.String("login-password"),
+		})
+		if err == nil && token.GetOtpRequired() {
+			if ctx.String("login-otp") == "" {
+				conn.Close()
+				return nil, status.Error(codes.Unauthenticated, "a one-time password is required (--login-otp)")
+			}
+			token, err = client.client.LoginOTP(loginCtx, &pb.LoginOTPRequest{
+				Challenge: token.GetOtpChallenge(),
+				Code:      ctx.String("login-otp"),
+			})
+		}
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		client.token = token.GetToken()
+		client.loggedIn = true
+	}
+	return client, nil
+}
+
+// cliActor is the name that mutations made directly by the CLI are attributed to in the audit log
+func cliActor() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return "cli:" + current.Username
+	}
+	return "cli"
+}
+
+// exitError converts an error into an exit error whose exit code is the gRPC status code
+func exitError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if appErr, ok := err.(ldapmanager.Error); ok {
+		return cli.Exit(appErr.Error(), int(appErr.Code()))
+	}
+	if s, ok := status.FromError(err); ok {
+		return cli.Exit(s.Message(), int(s.Code()))
+	}
+	return cli.Exit(err.Error(), int(codes.Internal))
+}
+
+// withClient checks the arguments, runs the action with a connected client and converts errors into exit codes
+func withClient(args []string, action func(ctx *cli.Context, client managerClient) error) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		if ctx.NArg() != len(args) {
+			return cli.Exit(fmt.Sprintf("expected arguments: %s", strings.Join(args, " ")), int(codes.InvalidArgument))
+		}
+		client, err := newClient(ctx)
+		if err != nil {
+			return exitError(err)
+		}
+		defer client.Close()
+		return exitError(action(ctx, client))
+	}
+}
+
+// printMessage prints a protobuf message as JSON or a table with the given columns of each row
+func printMessage(ctx *cli.Context, message proto.Message, header []string, rows [][]string) error {
+	if ctx.String("output") == outputJSON {
+		out, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(message)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+	return printTable(header, rows)
+}
+
+// printResult prints the result of a mutation
+func printResult(ctx *cli.Context, message string, fields map[string]interface{}) error {
+	if ctx.String("output") == outputJSON {
+		out, err := json.MarshalIndent(fields, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+	fmt.Println(message)
+	return nil
+}
+
+func printTable(header []string, rows [][]string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if len(header) > 0 {
+		fmt.Fprintln(w, strings.ToUpper(strings.Join(header, "\t")))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// sortedRows returns the key value pairs of the map as rows sorted by key
+func sortedRows(data map[string]string) [][]string {
+	var rows [][]string
+	for key, value := range data {
+		rows = append(rows, []string{key, value})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i][0] < rows[j][0]
+	})
+	return rows
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	ldapmanager "github.com/romnn/ldap-manager"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestExitError ...
+func TestExitError(t *testing.T) {
+	if err := exitError(nil); err != nil {
+		t.Errorf("expected no error but got %v", err)
+	}
+	cases := []struct {
+		err      error
+		expected codes.Code
+	}{
+		{&ldapmanager.ValidationError{Message: "invalid"}, codes.InvalidArgument},
+		{&ldapmanager.ZeroOrMultipleAccountsError{Username: "romnn"}, (&ldapmanager.ZeroOrMultipleAccountsError{}).Code()},
+		{status.Error(codes.PermissionDenied, "requires admin privileges"), codes.PermissionDenied},
+		{errors.New("failed"), codes.Internal},
+	}
+	for _, c := range cases {
+		exitErr, ok := exitError(c.err).(cli.ExitCoder)
+		if !ok {
+			t.Fatalf("expected an exit error for %v", c.err)
+		}
+		if exitErr.ExitCode() != int(c.expected) {
+			t.Errorf("expected exit code %d for %v but got %d", c.expected, c.err, exitErr.ExitCode())
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	"github.com/urfave/cli/v2"
+)
+
+const groupListPageSize = 100
+
+func groupCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "group",
+		Usage: "manage groups",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "create",
+				Usage:     "create a new group",
+				ArgsUsage: "NAME",
+				Flags: clientFlags(
+					&cli.StringSliceFlag{Name: "member", Usage: "initial member of the group"},
+					&cli.StringSliceFlag{Name: "owner", Usage: "owner who can manage the members of the group"},
+					&cli.StringFlag{Name: "id-pool", Usage: "allocate the gid from this named pool"},
+				),
+				Action: withClient([]string{"NAME"}, func(ctx *cli.Context, client managerClient) error {
+					name := ctx.Args().First()
+					if err := client.NewGroup(&pb.NewGroupRequest{
+						Name:    name,
+						Members: ctx.StringSlice("member"),
+						Owners:  ctx.StringSlice("owner"),
+						IdPool:  ctx.String("id-pool"),
+					}); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("created group %q", name), map[string]interface{}{"name": name})
+				}),
+			},
+			{
+				Name:      "delete",
+				Usage:     "delete a group",
+				ArgsUsage: "NAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"NAME"}, func(ctx *cli.Context, client managerClient) error {
+					name := ctx.Args().First()
+					if err := client.DeleteGroup(&pb.DeleteGroupRequest{Name: name}); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("deleted group %q", name), map[string]interface{}{"name": name})
+				}),
+			},
+			{
+				Name:      "rename",
+				Usage:     "rename a group",
+				ArgsUsage: "NAME NEW_NAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"NAME", "NEW_NAME"}, func(ctx *cli.Context, client managerClient) error {
+					name, newName := ctx.Args().Get(0), ctx.Args().Get(1)
+					if err := client.UpdateGroup(&pb.UpdateGroupRequest{Name: name, NewName: newName}); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("renamed group %q to %q", name, newName), map[string]interface{}{"name": newName})
+				}),
+			},
+			{
+				Name:      "owned",
+				Usage:     "list the groups owned by an account",
+				ArgsUsage: "USERNAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					groups, err := client.GetOwnedGroups(&pb.GetOwnedGroupsRequest{Username: ctx.Args().First()})
+					if err != nil {
+						return err
+					}
+					var rows [][]string
+					for _, group := range groups.GetGroups() {
+						rows = append(rows, []string{group})
+					}
+					return printMessage(ctx, groups, []string{"group"}, rows)
+				}),
+			},
+			ownerCommand(),
+			{
+				Name:  "repair",
+				Usage: "reconcile group membership attributes that drifted apart",
+				Flags: clientFlags(
+					&cli.StringFlag{Name: "group", Usage: "only repair this group"},
+					&cli.StringFlag{Name: "authoritative", Usage: "copy the members of this attribute to the others instead of merging all attributes"},
+					&cli.BoolFlag{Name: "dry-run", Usage: "only show the changes"},
+				),
+				Action: withClient(nil, func(ctx *cli.Context, client managerClient) error {
+					repairs, err := client.RepairGroupMembership(&pb.RepairGroupMembershipRequest{
+						Group:         ctx.String("group"),
+						Authoritative: ctx.String("authoritative"),
+						DryRun:        ctx.Bool("dry-run"),
+					})
+					if err != nil {
+						return err
+					}
+					var rows [][]string
+					for _, repair := range repairs.GetRepairs() {
+						rows = append(rows, []string{
+							repair.GetGroup(), repair.GetAttribute(),
+							strings.Join(repair.GetAdded(), ","), strings.Join(repair.GetRemoved(), ","),
+						})
+					}
+					return printMessage(ctx, repairs, []string{"group", "attribute", "added", "removed"}, rows)
+				}),
+			},
+			{
+				Name:  "list",
+				Usage: "list all groups",
+				Flags: clientFlags(
+					&cli.StringSliceFlag{Name: "filter", Usage: "only list groups matching the filter"},
+				),
+				Action: withClient(nil, func(ctx *cli.Context, client managerClient) error {
+					groups := &pb.GroupList{}
+					req := &pb.GetGroupListRequest{PageSize: groupListPageSize, Filter: ctx.StringSlice("filter")}
+					for {
+						page, err := client.GetGroupList(req)
+						if err != nil {
+							return err
+						}
+						groups.Groups = append(groups.Groups, page.GetGroups()...)
+						groups.Total = page.GetTotal()
+						if page.GetNextPageToken() == "" {
+							break
+						}
+						req.PageToken = page.GetNextPageToken()
+					}
+					var rows [][]string
+					for _, group := range groups.GetGroups() {
+						rows = append(rows, []string{group})
+					}
+					return printMessage(ctx, groups, []string{"group"}, rows)
+				}),
+			},
+		},
+	}
+}
+
+func ownerCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "owner",
+		Usage: "manage group owners",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "let an account manage the members of a group",
+				ArgsUsage: "GROUP USERNAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"GROUP", "USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					group, username := ctx.Args().Get(0), ctx.Args().Get(1)
+					if err := client.UpdateGroup(&pb.UpdateGroupRequest{Name: group, AddOwners: []string{username}}); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("added %q as owner of group %q", username, group),
+						map[string]interface{}{"group": group, "username": username})
+				}),
+			},
+			{
+				Name:      "remove",
+				Usage:     "remove an owner of a group",
+				ArgsUsage: "GROUP USERNAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"GROUP", "USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					group, username := ctx.Args().Get(0), ctx.Args().Get(1)
+					if err := client.UpdateGroup(&pb.UpdateGroupRequest{Name: group, RemoveOwners: []string{username}}); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("removed %q as owner of group %q", username, group),
+						map[string]interface{}{"group": group, "username": username})
+				}),
+			},
+		},
+	}
+}
+
+func memberCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "member",
+		Usage: "manage group members",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "add",
+				Usage:     "add an account to a group",
+				ArgsUsage: "GROUP USERNAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"GROUP", "USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					member := &pb.GroupMember{Group: ctx.Args().Get(0), Username: ctx.Args().Get(1)}
+					if err := client.AddGroupMember(member); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("added %q to group %q", member.GetUsername(), member.GetGroup()),
+						map[string]interface{}{"group": member.GetGroup(), "username": member.GetUsername()})
+				}),
+			},
+			{
+				Name:      "remove",
+				Usage:     "remove an account from a group",
+				ArgsUsage: "GROUP USERNAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"GROUP", "USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					member := &pb.GroupMember{Group: ctx.Args().Get(0), Username: ctx.Args().Get(1)}
+					if err := client.DeleteGroupMember(member); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("removed %q from group %q", member.GetUsername(), member.GetGroup()),
+						map[string]interface{}{"group": member.GetGroup(), "username": member.GetUsername()})
+				}),
+			},
+			{
+				Name:      "add-group",
+				Usage:     "add a group as a member of another group",
+				ArgsUsage: "GROUP MEMBER_GROUP",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"GROUP", "MEMBER_GROUP"}, func(ctx *cli.Context, client managerClient) error {
+					member := &pb.GroupMember{Group: ctx.Args().Get(0), MemberGroup: ctx.Args().Get(1)}
+					if err := client.AddGroupMember(member); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("added group %q to group %q", member.GetMemberGroup(), member.GetGroup()),
+						map[string]interface{}{"group": member.GetGroup(), "member_group": member.GetMemberGroup()})
+				}),
+			},
+			{
+				Name:      "remove-group",
+				Usage:     "remove a nested group from a group",
+				ArgsUsage: "GROUP MEMBER_GROUP",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"GROUP", "MEMBER_GROUP"}, func(ctx *cli.Context, client managerClient) error {
+					member := &pb.GroupMember{Group: ctx.Args().Get(0), MemberGroup: ctx.Args().Get(1)}
+					if err := client.DeleteGroupMember(member); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("removed group %q from group %q", member.GetMemberGroup(), member.GetGroup()),
+						map[string]interface{}{"group": member.GetGroup(), "member_group": member.GetMemberGroup()})
+				}),
+			},
+			{
+				Name:      "check",
+				Usage:     "check if an account is a member of a group",
+				ArgsUsage: "GROUP USERNAME",
+				Flags: clientFlags(
+					&cli.BoolFlag{Name: "effective", Usage: "also check the membership through nested groups"},
+				),
+				Action: withClient([]string{"GROUP", "USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					group, username := ctx.Args().Get(0), ctx.Args().Get(1)
+					memberStatus, err := client.IsGroupMember(&pb.IsGroupMemberRequest{Group: group, Username: username, Effective: ctx.Bool("effective")})
+					if err != nil {
+						return err
+					}
+					return printMessage(ctx, memberStatus,
+						[]string{"group", "username", "member"},
+						[][]string{{group, username, fmt.Sprintf("%t", memberStatus.GetIsMember())}})
+				}),
+			},
+			{
+				Name:      "request",
+				Usage:     "request to join a group",
+				ArgsUsage: "GROUP USERNAME",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"GROUP", "USERNAME"}, func(ctx *cli.Context, client managerClient) error {
+					request, err := client.RequestGroupMembership(&pb.GroupMember{Group: ctx.Args().Get(0), Username: ctx.Args().Get(1)})
+					if err != nil {
+						return err
+					}
+					return printMessage(ctx, request,
+						[]string{"id", "group", "username", "expires"},
+						[][]string{{request.GetId(), request.GetGroup(), request.GetUsername(), time.Unix(request.GetExpires(), 0).Format(time.RFC3339)}})
+				}),
+			},
+			{
+				Name:  "pending",
+				Usage: "list pending requests to join groups",
+				Flags: clientFlags(
+					&cli.StringFlag{Name: "group", Usage: "only list requests to join this group"},
+					&cli.StringFlag{Name: "username", Usage: "only list requests of this user"},
+				),
+				Action: withClient(nil, func(ctx *cli.Context, client managerClient) error {
+					requests, err := client.ListPendingRequests(&pb.ListPendingRequestsRequest{
+						Group:    ctx.String("group"),
+						Username: ctx.String("username"),
+					})
+					if err != nil {
+						return err
+					}
+					var rows [][]string
+					for _, request := range requests.GetRequests() {
+						rows = append(rows, []string{request.GetId(), request.GetGroup(), request.GetUsername(), time.Unix(request.GetExpires(), 0).Format(time.RFC3339)})
+					}
+					return printMessage(ctx, requests, []string{"id", "group", "username", "expires"}, rows)
+				}),
+			},
+			{
+				Name:      "approve",
+				Usage:     "approve a request to join a group",
+				ArgsUsage: "GROUP ID",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"GROUP", "ID"}, func(ctx *cli.Context, client managerClient) error {
+					decision := &pb.JoinRequestDecision{Group: ctx.Args().Get(0), Id: ctx.Args().Get(1)}
+					if err := client.ApproveRequest(decision); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("approved request %q to join group %q", decision.GetId(), decision.GetGroup()),
+						map[string]interface{}{"group": decision.GetGroup(), "id": decision.GetId()})
+				}),
+			},
+			{
+				Name:      "deny",
+				Usage:     "deny a request to join a group",
+				ArgsUsage: "GROUP ID",
+				Flags:     clientFlags(),
+				Action: withClient([]string{"GROUP", "ID"}, func(ctx *cli.Context, client managerClient) error {
+					decision := &pb.JoinRequestDecision{Group: ctx.Args().Get(0), Id: ctx.Args().Get(1)}
+					if err := client.DenyRequest(decision); err != nil {
+						return err
+					}
+					return printResult(ctx, fmt.Sprintf("denied request %q to join group %q", decision.GetId(), decision.GetGroup()),
+						map[string]interface{}{"group": decision.GetGroup(), "id": decision.GetId()})
+				}),
+			},
+		},
+	}
+}
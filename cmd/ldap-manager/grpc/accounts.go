@@ -14,7 +14,7 @@ import (
 
 // GetUserList ...
 func (s *LDAPManagerServer) GetUserList(ctx context.Context, in *pb.GetUserListRequest) (*pb.UserList, error) {
-	_, err := s.authenticate(ctx)
+	_, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.UserList{}, err
 	}
@@ -31,13 +31,10 @@ func (s *LDAPManagerServer) GetUserList(ctx context.Context, in *pb.GetUserListR
 
 // GetAccount ...
 func (s *LDAPManagerServer) GetAccount(ctx context.Context, in *pb.GetAccountRequest) (*pb.User, error) {
-	claims, err := s.authenticate(ctx)
+	_, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.User{}, err
 	}
-	if !claims.IsAdmin && claims.UID != in.GetUsername() {
-		return &pb.User{}, status.Error(codes.PermissionDenied, "requires admin privileges")
-	}
 	account, err := s.Manager.GetAccount(in)
 	if err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
@@ -51,11 +48,11 @@ func (s *LDAPManagerServer) GetAccount(ctx context.Context, in *pb.GetAccountReq
 
 // NewAccount ...
 func (s *LDAPManagerServer) NewAccount(ctx context.Context, in *pb.NewAccountRequest) (*pb.Empty, error) {
-	_, err := s.authenticate(ctx)
+	claims, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.Empty{}, err
 	}
-	if err := s.Manager.NewAccount(in, pb.HashingAlgorithm_DEFAULT); err != nil {
+	if err := s.Manager.As(claims.UID).NewAccount(in, pb.HashingAlgorithm_DEFAULT); err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
 			return &pb.Empty{}, toStatus(appErr)
 		}
@@ -65,16 +62,33 @@ func (s *LDAPManagerServer) NewAccount(ctx context.Context, in *pb.NewAccountReq
 	return &pb.Empty{}, nil
 }
 
+// BulkNewAccounts ...
+func (s *LDAPManagerServer) BulkNewAccounts(ctx context.Context, in *pb.BulkNewAccountsRequest) (*pb.BulkNewAccountsResponse, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.BulkNewAccountsResponse{}, err
+	}
+	response, err := s.Manager.As(claims.UID).BulkNewAccounts(in, pb.HashingAlgorithm_DEFAULT)
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.BulkNewAccountsResponse{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.BulkNewAccountsResponse{}, status.Error(codes.Internal, "error while creating new accounts")
+	}
+	return response, nil
+}
+
 // UpdateAccount ...
 func (s *LDAPManagerServer) UpdateAccount(ctx context.Context, in *pb.UpdateAccountRequest) (*pb.Token, error) {
-	claims, err := s.authenticate(ctx)
+	claims, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.Token{}, err
 	}
-	if !claims.IsAdmin && claims.UID != in.GetUsername() {
-		return &pb.Token{}, status.Error(codes.PermissionDenied, "requires admin privileges")
+	if err := s.authorizeTarget(claims, in.GetUsername()); err != nil {
+		return &pb.Token{}, err
 	}
-	username, uidNumber, err := s.Manager.UpdateAccount(in, pb.HashingAlgorithm_DEFAULT, claims.IsAdmin)
+	username, uidNumber, err := s.Manager.As(claims.UID).UpdateAccount(in, pb.HashingAlgorithm_DEFAULT, s.can(claims, pb.Permission_MANAGE_ACCOUNTS))
 	if err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
 			return &pb.Token{}, toStatus(appErr)
@@ -82,37 +96,56 @@ func (s *LDAPManagerServer) UpdateAccount(ctx context.Context, in *pb.UpdateAcco
 		log.Error(err)
 		return &pb.Token{}, status.Error(codes.Internal, "error while updating account")
 	}
+	if claims.UID != in.GetUsername() {
+		// the token of the user editing another account stays the same
+		return s.currentToken(ctx, claims), nil
+	}
+	// a renamed user continues the session with the new username and the roles are evaluated again
+	otpEnabled, err := s.Manager.TOTPEnabled(username)
+	if err != nil {
+		log.Error(err)
+		return nil, status.Error(codes.Internal, "error while checking two-factor authentication")
+	}
+	roles, isAdmin, otpEnrollmentRequired, err := s.tokenRoles(username, otpEnabled)
+	if err != nil {
+		return nil, err
+	}
 	token, expireSeconds, err := s.Authenticator.Login(&AuthClaims{
 		UID:         username,
 		UIDNumber:   strconv.Itoa(uidNumber),
-		IsAdmin:     claims.IsAdmin,
+		IsAdmin:     isAdmin,
+		Roles:       roles,
 		DisplayName: claims.DisplayName,
+		SessionID:   claims.SessionID,
 	})
 	if err != nil {
 		log.Error(err)
 		return nil, status.Error(codes.Internal, "error while signing token")
 	}
 	return &pb.Token{
-		Token:       token,
-		Username:    username,
-		IsAdmin:     claims.IsAdmin,
-		DisplayName: claims.DisplayName,
-		Expiration:  expireSeconds,
+		Token:                 token,
+		Username:              username,
+		IsAdmin:               isAdmin,
+		DisplayName:           claims.DisplayName,
+		OtpEnrollmentRequired: otpEnrollmentRequired,
+		Expiration:            expireSeconds,
+		Roles:                 roles,
+		Permissions:           s.Manager.Permissions(roles),
 	}, nil
 }
 
 // DeleteAccount ...
 func (s *LDAPManagerServer) DeleteAccount(ctx context.Context, in *pb.DeleteAccountRequest) (*pb.Empty, error) {
-	claims, err := s.authenticate(ctx)
+	claims, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.Empty{}, err
 	}
 	log.Info(claims.UID, in.GetUsername())
-	if !claims.IsAdmin && claims.UID != in.GetUsername() {
-		return &pb.Empty{}, status.Error(codes.PermissionDenied, "requires admin privileges")
+	if err := s.authorizeTarget(claims, in.GetUsername()); err != nil {
+		return &pb.Empty{}, err
 	}
 	allowDeleteOfDefaultGroups := false
-	if err := s.Manager.DeleteAccount(in, allowDeleteOfDefaultGroups); err != nil {
+	if err := s.Manager.As(claims.UID).DeleteAccount(in, allowDeleteOfDefaultGroups); err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
 			return &pb.Empty{}, toStatus(appErr)
 		}
@@ -122,16 +155,58 @@ func (s *LDAPManagerServer) DeleteAccount(ctx context.Context, in *pb.DeleteAcco
 	return &pb.Empty{}, nil
 }
 
+// LockAccount ...
+func (s *LDAPManagerServer) LockAccount(ctx context.Context, in *pb.LockAccountRequest) (*pb.Empty, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if claims.UID == in.GetUsername() {
+		return &pb.Empty{}, status.Error(codes.FailedPrecondition, "cannot lock your own account")
+	}
+	if err := s.authorizeTarget(claims, in.GetUsername()); err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := s.Manager.As(claims.UID).LockAccount(in); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while locking account")
+	}
+	return &pb.Empty{}, nil
+}
+
+// UnlockAccount ...
+func (s *LDAPManagerServer) UnlockAccount(ctx context.Context, in *pb.UnlockAccountRequest) (*pb.Empty, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := s.authorizeTarget(claims, in.GetUsername()); err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := s.Manager.As(claims.UID).UnlockAccount(in); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while unlocking account")
+	}
+	return &pb.Empty{}, nil
+}
+
 // ChangePassword ...
 func (s *LDAPManagerServer) ChangePassword(ctx context.Context, in *pb.ChangePasswordRequest) (*pb.Empty, error) {
-	claims, err := s.authenticate(ctx)
+	claims, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.Empty{}, err
 	}
-	if !claims.IsAdmin && claims.UID != in.GetUsername() {
-		return &pb.Empty{}, status.Error(codes.PermissionDenied, "requires admin privileges")
+	if err := s.authorizeTarget(claims, in.GetUsername()); err != nil {
+		return &pb.Empty{}, err
 	}
-	if err := s.Manager.ChangePassword(in); err != nil {
+	selfService := claims.UID == in.GetUsername()
+	if err := s.Manager.As(claims.UID).ChangePassword(in, selfService); err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
 			return &pb.Empty{}, toStatus(appErr)
 		}
@@ -0,0 +1,28 @@
+package grpc
+
+import (
+	"context"
+
+	ldapmanager "github.com/romnn/ldap-manager"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetAuditLog ...
+func (s *LDAPManagerServer) GetAuditLog(ctx context.Context, in *pb.GetAuditLogRequest) (*pb.AuditLog, error) {
+	_, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.AuditLog{}, err
+	}
+	auditLog, err := s.Manager.GetAuditLog(in)
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.AuditLog{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.AuditLog{}, status.Error(codes.Internal, "error while getting the audit log")
+	}
+	return auditLog, nil
+}
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 	gogrpcservice "github.com/romnn/go-grpc-service"
@@ -18,10 +19,12 @@ import (
 
 // AuthClaims ...
 type AuthClaims struct {
-	UID         string `json:"uid"`
-	UIDNumber   string `json:"uid_num"`
-	IsAdmin     bool   `json:"is_admin"`
-	DisplayName string `json:"display_name"`
+	UID         string   `json:"uid"`
+	UIDNumber   string   `json:"uid_num"`
+	IsAdmin     bool     `json:"is_admin"`
+	Roles       []string `json:"roles,omitempty"`
+	DisplayName string   `json:"display_name"`
+	SessionID   string   `json:"sid,omitempty"`
 	jwt.StandardClaims
 }
 
@@ -32,18 +35,39 @@ func (claims *AuthClaims) GetStandardClaims() *jwt.StandardClaims {
 	return &claims.StandardClaims
 }
 
-func routeRequiresAdmin(ctx context.Context) (bool, error) {
+// routePolicy returns the permission and self service option of the called method
+func routePolicy(ctx context.Context) (pb.Permission, bool, error) {
 	if methodDesc, ok := ctx.Value(gogrpcservice.GrpcMethodDescriptor).(pref.MethodDescriptor); ok {
-		if requireAdmin, ok := proto.GetExtension(methodDesc.Options(), pb.E_RequireAdmin).(bool); ok {
-			return requireAdmin, nil
+		permission, ok := proto.GetExtension(methodDesc.Options(), pb.E_Permission).(pb.Permission)
+		if !ok {
+			return permission, false, errors.New("route has an invalid authorization policy")
 		}
+		selfService, _ := proto.GetExtension(methodDesc.Options(), pb.E_SelfService).(bool)
+		return permission, selfService, nil
 	}
-	return true, errors.New("route has no or insufficient authentication policy")
+	return pb.Permission_AUTHENTICATED, false, errors.New("route has no or insufficient authentication policy")
 }
 
-// Login logs in a user
-func (s *LDAPManagerServer) authenticate(ctx context.Context) (*AuthClaims, error) {
-	requireAdmin, err := routeRequiresAdmin(ctx)
+// usernameRequest is implemented by requests that refer to an account
+type usernameRequest interface {
+	GetUsername() string
+}
+
+// groupRequest is implemented by requests that refer to a group
+type groupRequest interface {
+	GetGroup() string
+}
+
+// can reports if the roles of the user grant a permission
+func (s *LDAPManagerServer) can(claims *AuthClaims, permission pb.Permission) bool {
+	return s.Manager.HasPermission(claims.Roles, permission)
+}
+
+// authenticate validates the token of a request and checks that the roles of the user grant the permission of the called method.
+// Users can call self service methods for their own account and members of the groups they own can be managed
+// with the MANAGE_OWNED_GROUP_MEMBERS permission.
+func (s *LDAPManagerServer) authenticate(ctx context.Context, req interface{}) (*AuthClaims, error) {
+	permission, selfService, err := routePolicy(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -59,19 +83,84 @@ func (s *LDAPManagerServer) authenticate(ctx context.Context) (*AuthClaims, erro
 	if err != nil {
 		return nil, status.Error(codes.Unauthenticated, "token validation failed")
 	}
-	if claims, ok := token.Claims.(*AuthClaims); ok && valid {
-		if requireAdmin && !claims.IsAdmin {
-			return nil, status.Error(codes.PermissionDenied, "requires admin priviledges")
+	claims, ok := token.Claims.(*AuthClaims)
+	if !ok || !valid {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	if s.Manager.SessionsEnabled() {
+		active, err := s.Manager.SessionActive(claims.SessionID)
+		if err != nil {
+			log.Error(err)
+			return nil, status.Error(codes.Internal, "error while checking session")
+		}
+		if !active {
+			return nil, status.Error(codes.Unauthenticated, "session was revoked")
 		}
-		// authenticated
+	}
+	if s.can(claims, permission) {
+		return claims, nil
+	}
+	if request, ok := req.(usernameRequest); ok && selfService && request.GetUsername() == claims.UID {
 		return claims, nil
 	}
-	return nil, status.Error(codes.Unauthenticated, "invalid token")
+	if request, ok := req.(groupRequest); ok && permission == pb.Permission_MANAGE_GROUP_MEMBERS {
+		owner, err := s.ownsGroup(claims, request.GetGroup())
+		if err != nil {
+			return nil, err
+		}
+		if owner {
+			return claims, nil
+		}
+	}
+	return nil, status.Errorf(codes.PermissionDenied, "requires %s permission", permission)
+}
+
+// ownsGroup reports if the user can manage the members of a group with the MANAGE_OWNED_GROUP_MEMBERS permission
+func (s *LDAPManagerServer) ownsGroup(claims *AuthClaims, group string) (bool, error) {
+	if !s.can(claims, pb.Permission_MANAGE_OWNED_GROUP_MEMBERS) {
+		return false, nil
+	}
+	owner, err := s.Manager.IsGroupOwner(group, claims.UID)
+	if err != nil {
+		log.Error(err)
+		return false, status.Error(codes.Internal, "error while checking group owners")
+	}
+	if !owner {
+		return false, nil
+	}
+	// the default user and admin groups, the groups mapped to roles and the groups nested in them stay admin-only
+	// even if someone owns them
+	protected, err := s.Manager.IsNestedInProtectedGroup(group)
+	if err != nil {
+		log.Error(err)
+		return false, status.Error(codes.Internal, "error while checking group owners")
+	}
+	return !protected, nil
+}
+
+// authorizeTarget checks that the user holds all roles and permissions of another account before acting on its behalf,
+// e.g. setting its password, so that the helpdesk can not take over admin accounts
+func (s *LDAPManagerServer) authorizeTarget(claims *AuthClaims, username string) error {
+	if claims.UID == username {
+		return nil
+	}
+	roles, err := s.Manager.GetRoles(username)
+	if err != nil {
+		log.Error(err)
+		return status.Error(codes.Internal, "error while getting roles")
+	}
+	if hasRole(roles, ldapmanager.RoleAdmin) && !hasRole(claims.Roles, ldapmanager.RoleAdmin) {
+		return status.Errorf(codes.PermissionDenied, "%q is an admin", username)
+	}
+	if !s.Manager.HasAllPermissions(claims.Roles, roles) {
+		return status.Errorf(codes.PermissionDenied, "%q holds permissions you do not have", username)
+	}
+	return nil
 }
 
 // Login logs in a user
 func (s *LDAPManagerServer) Login(ctx context.Context, in *pb.LoginRequest) (*pb.Token, error) {
-	user, err := s.Manager.AuthenticateUser(in)
+	user, policy, err := s.Manager.AuthenticateUserWithPolicy(in)
 	if err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
 			return &pb.Token{}, toStatus(appErr)
@@ -85,31 +174,242 @@ func (s *LDAPManagerServer) Login(ctx context.Context, in *pb.LoginRequest) (*pb
 		return &pb.Token{}, status.Error(codes.NotFound, "user is invalid")
 	}
 
-	adminMemberStatus, err := s.Manager.IsGroupMember(&pb.IsGroupMemberRequest{
-		Username: uid,
-		Group:    s.Manager.DefaultAdminGroup,
-	})
+	otpEnabled, err := s.Manager.TOTPEnabled(uid)
 	if err != nil {
 		log.Error(err)
-		return nil, status.Error(codes.Internal, "error while checking user member status")
+		return nil, status.Error(codes.Internal, "error while checking two-factor authentication")
+	}
+	if otpEnabled {
+		// the password was accepted, the token is only issued for a valid one-time password
+		challenge, expires := s.Manager.NewLoginChallenge(uid)
+		return withPasswordPolicyStatus(&pb.Token{
+			Username:     uid,
+			OtpRequired:  true,
+			OtpChallenge: challenge,
+			Expiration:   expires.Unix(),
+		}, policy), nil
 	}
-	isAdmin := adminMemberStatus.GetIsMember()
-	displayName := user.GetAttributeValue("displayName")
-	token, expireSeconds, err := s.Authenticator.Login(&AuthClaims{
+	token, err := s.issueToken(uid, uidNumber, user.GetAttributeValue("displayName"), otpEnabled, nil)
+	if err != nil {
+		return nil, err
+	}
+	return withPasswordPolicyStatus(token, policy), nil
+}
+
+// withPasswordPolicyStatus informs the user about the state of the password reported by the password policy overlay
+func withPasswordPolicyStatus(token *pb.Token, policy *ldapmanager.PasswordPolicyStatus) *pb.Token {
+	if policy == nil {
+		return token
+	}
+	token.PasswordExpiresIn = int64(policy.ExpiresIn.Seconds())
+	token.PasswordExpired = policy.Expired
+	token.GraceLoginsRemaining = int32(policy.GraceLoginsRemaining)
+	token.PasswordMustChange = policy.MustChange
+	return token
+}
+
+// LoginOTP completes a login of a user with two-factor authentication
+func (s *LDAPManagerServer) LoginOTP(ctx context.Context, in *pb.LoginOTPRequest) (*pb.Token, error) {
+	uid, err := s.Manager.VerifyLoginOTP(in.GetChallenge(), in.GetCode())
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Token{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Token{}, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	user, err := s.Manager.GetAccount(&pb.GetAccountRequest{Username: uid})
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Token{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Token{}, status.Error(codes.Internal, "error while getting account")
+	}
+	uidNumber := user.GetData()["uidNumber"]
+	if uidNumber == "" {
+		return &pb.Token{}, status.Error(codes.NotFound, "user is invalid")
+	}
+	otpEnabled := true
+	return s.issueToken(uid, uidNumber, user.GetData()["displayName"], otpEnabled, nil)
+}
+
+// RefreshToken issues a new token for a session. Admin privileges are evaluated again.
+func (s *LDAPManagerServer) RefreshToken(ctx context.Context, in *pb.RefreshTokenRequest) (*pb.Token, error) {
+	user, session, err := s.Manager.RefreshSession(in.GetRefreshToken())
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Token{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Token{}, status.Error(codes.Internal, "error while refreshing token")
+	}
+	uid := user.GetAttributeValue(s.Manager.AccountAttribute)
+	uidNumber := user.GetAttributeValue("uidNumber")
+	if uid == "" || uidNumber == "" {
+		return &pb.Token{}, status.Error(codes.NotFound, "user is invalid")
+	}
+	otpEnabled, err := s.Manager.TOTPEnabled(uid)
+	if err != nil {
+		log.Error(err)
+		return nil, status.Error(codes.Internal, "error while checking two-factor authentication")
+	}
+	return s.issueToken(uid, uidNumber, user.GetAttributeValue("displayName"), otpEnabled, session)
+}
+
+// Logout revokes the session of the token
+func (s *LDAPManagerServer) Logout(ctx context.Context, in *pb.Empty) (*pb.Empty, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if claims.SessionID == "" {
+		return &pb.Empty{}, nil
+	}
+	if err := s.Manager.As(claims.UID).RevokeSession(claims.SessionID); err != nil {
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while revoking session")
+	}
+	return &pb.Empty{}, nil
+}
+
+// RevokeSessions revokes all sessions of an account
+func (s *LDAPManagerServer) RevokeSessions(ctx context.Context, in *pb.RevokeSessionsRequest) (*pb.Empty, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := s.authorizeTarget(claims, in.GetUsername()); err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := s.Manager.As(claims.UID).RevokeSessions(in); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while revoking sessions")
+	}
+	return &pb.Empty{}, nil
+}
+
+// issueToken signs a token for an authenticated user and starts a new session unless an existing session is refreshed.
+// The roles are evaluated for every token, admin privileges are withheld from admins without two-factor authentication if it is required.
+func (s *LDAPManagerServer) issueToken(uid, uidNumber, displayName string, otpEnabled bool, session *ldapmanager.Session) (*pb.Token, error) {
+	roles, isAdmin, otpEnrollmentRequired, err := s.tokenRoles(uid, otpEnabled)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil && s.Manager.SessionsEnabled() {
+		if session, err = s.Manager.As(uid).NewSession(uid); err != nil {
+			log.Error(err)
+			return nil, status.Error(codes.Internal, "error while starting session")
+		}
+	}
+	claims := &AuthClaims{
 		UID:         uid,
 		UIDNumber:   uidNumber,
 		IsAdmin:     isAdmin,
+		Roles:       roles,
 		DisplayName: displayName,
-	})
+	}
+	if session != nil {
+		claims.SessionID = session.ID
+	}
+	token, expireSeconds, err := s.Authenticator.Login(claims)
 	if err != nil {
 		log.Error(err)
 		return nil, status.Error(codes.Internal, "error while signing token")
 	}
-	return &pb.Token{
-		Token:       token,
-		Username:    uid,
-		IsAdmin:     isAdmin,
-		DisplayName: displayName,
-		Expiration:  expireSeconds,
-	}, nil
+	issued := &pb.Token{
+		Token:                 token,
+		Username:              uid,
+		IsAdmin:               isAdmin,
+		DisplayName:           displayName,
+		OtpEnrollmentRequired: otpEnrollmentRequired,
+		Expiration:            expireSeconds,
+		Roles:                 roles,
+		Permissions:           s.Manager.Permissions(roles),
+	}
+	if session != nil {
+		issued.RefreshToken = session.RefreshToken
+		issued.RefreshExpiration = session.Expires.Unix()
+	}
+	return issued, nil
+}
+
+// tokenRoles returns the roles of a user for a new token
+func (s *LDAPManagerServer) tokenRoles(uid string, otpEnabled bool) ([]string, bool, bool, error) {
+	roles, err := s.Manager.GetRoles(uid)
+	if err != nil {
+		log.Error(err)
+		return nil, false, false, status.Error(codes.Internal, "error while getting roles")
+	}
+	isAdmin := hasRole(roles, ldapmanager.RoleAdmin)
+	otpEnrollmentRequired := isAdmin && s.Manager.RequireTwoFactorForAdmins && !otpEnabled
+	if otpEnrollmentRequired {
+		isAdmin = false
+		roles = withoutRole(roles, ldapmanager.RoleAdmin)
+	}
+	return roles, isAdmin, otpEnrollmentRequired, nil
+}
+
+// currentToken returns the token the user authenticated with
+func (s *LDAPManagerServer) currentToken(ctx context.Context, claims *AuthClaims) *pb.Token {
+	token := &pb.Token{
+		Username:    claims.UID,
+		IsAdmin:     claims.IsAdmin,
+		DisplayName: claims.DisplayName,
+		Expiration:  int64(time.Until(time.Unix(claims.ExpiresAt, 0)).Seconds()),
+		Roles:       claims.Roles,
+		Permissions: s.Manager.Permissions(claims.Roles),
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if tokens := md.Get("x-user-token"); len(tokens) > 0 {
+			token.Token = tokens[0]
+		}
+	}
+	return token
+}
+
+// RequestPasswordReset mails a password reset token. The response does not reveal if the account exists.
+func (s *LDAPManagerServer) RequestPasswordReset(ctx context.Context, in *pb.PasswordResetRequest) (*pb.Empty, error) {
+	if err := s.Manager.RequestPasswordReset(in); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while requesting password reset")
+	}
+	return &pb.Empty{}, nil
+}
+
+// ResetPassword sets a new password using a password reset token
+func (s *LDAPManagerServer) ResetPassword(ctx context.Context, in *pb.ResetPasswordRequest) (*pb.Empty, error) {
+	if err := s.Manager.ResetPassword(in); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while resetting password")
+	}
+	return &pb.Empty{}, nil
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutRole(roles []string, role string) []string {
+	var remaining []string
+	for _, r := range roles {
+		if r != role {
+			remaining = append(remaining, r)
+		}
+	}
+	return remaining
 }
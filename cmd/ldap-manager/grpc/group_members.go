@@ -12,13 +12,10 @@ import (
 
 // IsGroupMember ...
 func (s *LDAPManagerServer) IsGroupMember(ctx context.Context, in *pb.IsGroupMemberRequest) (*pb.GroupMemberStatus, error) {
-	claims, err := s.authenticate(ctx)
+	_, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.GroupMemberStatus{}, err
 	}
-	if !claims.IsAdmin && claims.UID != in.GetUsername() {
-		return &pb.GroupMemberStatus{}, status.Error(codes.PermissionDenied, "requires admin privileges")
-	}
 	memberStatus, err := s.Manager.IsGroupMember(in)
 	if err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
@@ -32,7 +29,7 @@ func (s *LDAPManagerServer) IsGroupMember(ctx context.Context, in *pb.IsGroupMem
 
 // GetGroup ...
 func (s *LDAPManagerServer) GetGroup(ctx context.Context, in *pb.GetGroupRequest) (*pb.Group, error) {
-	_, err := s.authenticate(ctx)
+	_, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.Group{}, err
 	}
@@ -49,13 +46,10 @@ func (s *LDAPManagerServer) GetGroup(ctx context.Context, in *pb.GetGroupRequest
 
 // GetUserGroups ...
 func (s *LDAPManagerServer) GetUserGroups(ctx context.Context, in *pb.GetUserGroupsRequest) (*pb.GroupList, error) {
-	claims, err := s.authenticate(ctx)
+	_, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.GroupList{}, err
 	}
-	if !claims.IsAdmin && claims.UID != in.GetUsername() {
-		return &pb.GroupList{}, status.Error(codes.PermissionDenied, "requires admin privileges")
-	}
 	groups, err := s.Manager.GetUserGroups(in)
 	if err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
@@ -69,11 +63,11 @@ func (s *LDAPManagerServer) GetUserGroups(ctx context.Context, in *pb.GetUserGro
 
 // AddGroupMember ...
 func (s *LDAPManagerServer) AddGroupMember(ctx context.Context, in *pb.GroupMember) (*pb.Empty, error) {
-	_, err := s.authenticate(ctx)
+	claims, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.Empty{}, err
 	}
-	if err := s.Manager.AddGroupMember(in, false); err != nil {
+	if err := s.Manager.As(claims.UID).AddGroupMember(in, false); err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
 			return &pb.Empty{}, toStatus(appErr)
 		}
@@ -85,15 +79,22 @@ func (s *LDAPManagerServer) AddGroupMember(ctx context.Context, in *pb.GroupMemb
 
 // DeleteGroupMember ...
 func (s *LDAPManagerServer) DeleteGroupMember(ctx context.Context, in *pb.GroupMember) (*pb.Empty, error) {
-	claims, err := s.authenticate(ctx)
+	claims, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.Empty{}, err
 	}
-	if !claims.IsAdmin && claims.UID != in.GetUsername() {
-		return &pb.Empty{}, status.Error(codes.PermissionDenied, "requires admin privileges")
+	if in.GetMemberGroup() != "" && !s.can(claims, pb.Permission_MANAGE_GROUP_MEMBERS) {
+		// users can only leave groups themselves, nested groups are managed by admins and group owners
+		owner, err := s.ownsGroup(claims, in.GetGroup())
+		if err != nil {
+			return &pb.Empty{}, err
+		}
+		if !owner {
+			return &pb.Empty{}, status.Errorf(codes.PermissionDenied, "requires %s permission", pb.Permission_MANAGE_GROUP_MEMBERS)
+		}
 	}
-	allowDeleteOfDefaultGroups := claims.IsAdmin
-	if err := s.Manager.DeleteGroupMember(in, allowDeleteOfDefaultGroups); err != nil {
+	allowDeleteOfDefaultGroups := s.can(claims, pb.Permission_MANAGE_GROUP_MEMBERS)
+	if err := s.Manager.As(claims.UID).DeleteGroupMember(in, allowDeleteOfDefaultGroups); err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
 			return &pb.Empty{}, toStatus(appErr)
 		}
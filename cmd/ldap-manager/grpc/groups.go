@@ -12,11 +12,11 @@ import (
 
 // NewGroup ...
 func (s *LDAPManagerServer) NewGroup(ctx context.Context, in *pb.NewGroupRequest) (*pb.Empty, error) {
-	_, err := s.authenticate(ctx)
+	claims, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.Empty{}, err
 	}
-	if err := s.Manager.NewGroup(in, false); err != nil {
+	if err := s.Manager.As(claims.UID).NewGroup(in, false); err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
 			return &pb.Empty{}, toStatus(appErr)
 		}
@@ -28,11 +28,11 @@ func (s *LDAPManagerServer) NewGroup(ctx context.Context, in *pb.NewGroupRequest
 
 // DeleteGroup ...
 func (s *LDAPManagerServer) DeleteGroup(ctx context.Context, in *pb.DeleteGroupRequest) (*pb.Empty, error) {
-	_, err := s.authenticate(ctx)
+	claims, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.Empty{}, err
 	}
-	if err := s.Manager.DeleteGroup(in); err != nil {
+	if err := s.Manager.As(claims.UID).DeleteGroup(in); err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
 			return &pb.Empty{}, toStatus(appErr)
 		}
@@ -44,11 +44,11 @@ func (s *LDAPManagerServer) DeleteGroup(ctx context.Context, in *pb.DeleteGroupR
 
 // UpdateGroup ...
 func (s *LDAPManagerServer) UpdateGroup(ctx context.Context, in *pb.UpdateGroupRequest) (*pb.Empty, error) {
-	_, err := s.authenticate(ctx)
+	claims, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.Empty{}, err
 	}
-	if err := s.Manager.UpdateGroup(in); err != nil {
+	if err := s.Manager.As(claims.UID).UpdateGroup(in); err != nil {
 		if appErr, safe := err.(ldapmanager.Error); safe {
 			return &pb.Empty{}, toStatus(appErr)
 		}
@@ -60,7 +60,7 @@ func (s *LDAPManagerServer) UpdateGroup(ctx context.Context, in *pb.UpdateGroupR
 
 // GetGroupList ...
 func (s *LDAPManagerServer) GetGroupList(ctx context.Context, in *pb.GetGroupListRequest) (*pb.GroupList, error) {
-	_, err := s.authenticate(ctx)
+	_, err := s.authenticate(ctx, in)
 	if err != nil {
 		return &pb.GroupList{}, err
 	}
@@ -74,3 +74,37 @@ func (s *LDAPManagerServer) GetGroupList(ctx context.Context, in *pb.GetGroupLis
 	}
 	return groups, nil
 }
+
+// GetOwnedGroups ...
+func (s *LDAPManagerServer) GetOwnedGroups(ctx context.Context, in *pb.GetOwnedGroupsRequest) (*pb.GroupList, error) {
+	_, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.GroupList{}, err
+	}
+	groups, err := s.Manager.GetOwnedGroups(in)
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.GroupList{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.GroupList{}, status.Error(codes.Internal, "error while getting owned groups")
+	}
+	return groups, nil
+}
+
+// RepairGroupMembership ...
+func (s *LDAPManagerServer) RepairGroupMembership(ctx context.Context, in *pb.RepairGroupMembershipRequest) (*pb.GroupMembershipRepairs, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.GroupMembershipRepairs{}, err
+	}
+	repairs, err := s.Manager.As(claims.UID).RepairGroupMembership(in)
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.GroupMembershipRepairs{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.GroupMembershipRepairs{}, status.Error(codes.Internal, "error while repairing group members")
+	}
+	return repairs, nil
+}
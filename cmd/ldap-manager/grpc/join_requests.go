@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+
+	ldapmanager "github.com/romnn/ldap-manager"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequestGroupMembership ...
+func (s *LDAPManagerServer) RequestGroupMembership(ctx context.Context, in *pb.GroupMember) (*pb.JoinRequest, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.JoinRequest{}, err
+	}
+	request, err := s.Manager.As(claims.UID).RequestGroupMembership(in)
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.JoinRequest{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.JoinRequest{}, status.Error(codes.Internal, "error while requesting group membership")
+	}
+	return request, nil
+}
+
+// ListPendingRequests ...
+func (s *LDAPManagerServer) ListPendingRequests(ctx context.Context, in *pb.ListPendingRequestsRequest) (*pb.JoinRequestList, error) {
+	_, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.JoinRequestList{}, err
+	}
+	requests, err := s.Manager.ListPendingRequests(in)
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.JoinRequestList{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.JoinRequestList{}, status.Error(codes.Internal, "error while listing join requests")
+	}
+	return requests, nil
+}
+
+// ApproveRequest ...
+func (s *LDAPManagerServer) ApproveRequest(ctx context.Context, in *pb.JoinRequestDecision) (*pb.Empty, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := s.Manager.As(claims.UID).ApproveRequest(in); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while approving join request")
+	}
+	return &pb.Empty{}, nil
+}
+
+// DenyRequest ...
+func (s *LDAPManagerServer) DenyRequest(ctx context.Context, in *pb.JoinRequestDecision) (*pb.Empty, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := s.Manager.As(claims.UID).DenyRequest(in); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while denying join request")
+	}
+	return &pb.Empty{}, nil
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+
+	ldapmanager "github.com/romnn/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// GetPwdPolicyList ...
+func (s *LDAPManagerServer) GetPwdPolicyList(ctx context.Context, in *pb.GetPwdPolicyListRequest) (*pb.PwdPolicyList, error) {
+	_, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.PwdPolicyList{}, err
+	}
+	policies, err := s.Manager.GetPwdPolicyList(in)
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.PwdPolicyList{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.PwdPolicyList{}, status.Error(codes.Internal, "error while getting list of password policies")
+	}
+	return policies, nil
+}
+
+// GetPwdPolicy ...
+func (s *LDAPManagerServer) GetPwdPolicy(ctx context.Context, in *pb.GetPwdPolicyRequest) (*pb.PwdPolicy, error) {
+	_, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.PwdPolicy{}, err
+	}
+	policy, err := s.Manager.GetPwdPolicy(in)
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.PwdPolicy{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.PwdPolicy{}, status.Error(codes.Internal, "error while getting password policy")
+	}
+	return policy, nil
+}
+
+// NewPwdPolicy ...
+func (s *LDAPManagerServer) NewPwdPolicy(ctx context.Context, in *pb.PwdPolicy) (*pb.Empty, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := s.Manager.As(claims.UID).NewPwdPolicy(in); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while creating new password policy")
+	}
+	return &pb.Empty{}, nil
+}
+
+// UpdatePwdPolicy ...
+func (s *LDAPManagerServer) UpdatePwdPolicy(ctx context.Context, in *pb.PwdPolicy) (*pb.Empty, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := s.Manager.As(claims.UID).UpdatePwdPolicy(in); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while updating password policy")
+	}
+	return &pb.Empty{}, nil
+}
+
+// DeletePwdPolicy ...
+func (s *LDAPManagerServer) DeletePwdPolicy(ctx context.Context, in *pb.DeletePwdPolicyRequest) (*pb.Empty, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := s.Manager.As(claims.UID).DeletePwdPolicy(in); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while deleting password policy")
+	}
+	return &pb.Empty{}, nil
+}
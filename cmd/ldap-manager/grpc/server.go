@@ -11,6 +11,7 @@ import (
 	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
 	log "github.com/sirupsen/logrus"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/status"
 )
 
@@ -58,5 +59,18 @@ func (s *LDAPManagerServer) Serve(ctx context.Context, wg *sync.WaitGroup) error
 }
 
 func toStatus(e ldapmanager.Error) error {
-	return status.Error(e.Code(), e.Error())
+	st := status.New(e.Code(), e.Error())
+	if validationErr, ok := e.(*ldapmanager.ValidationError); ok && len(validationErr.Violations) > 0 {
+		details := &errdetails.BadRequest{}
+		for _, violation := range validationErr.Violations {
+			details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       validationErr.Field,
+				Description: violation,
+			})
+		}
+		if withDetails, err := st.WithDetails(details); err == nil {
+			st = withDetails
+		}
+	}
+	return st.Err()
 }
@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"context"
+
+	ldapmanager "github.com/romnn/ldap-manager"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EnrollTOTP ...
+func (s *LDAPManagerServer) EnrollTOTP(ctx context.Context, in *pb.EnrollTOTPRequest) (*pb.TOTPEnrollment, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.TOTPEnrollment{}, err
+	}
+	if claims.UID != in.GetUsername() {
+		return &pb.TOTPEnrollment{}, status.Error(codes.PermissionDenied, "users can only enroll themselves")
+	}
+	enrollment, err := s.Manager.As(claims.UID).EnrollTOTP(in)
+	if err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.TOTPEnrollment{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.TOTPEnrollment{}, status.Error(codes.Internal, "error while enrolling two-factor authentication")
+	}
+	return enrollment, nil
+}
+
+// ConfirmTOTP ...
+func (s *LDAPManagerServer) ConfirmTOTP(ctx context.Context, in *pb.ConfirmTOTPRequest) (*pb.Empty, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if claims.UID != in.GetUsername() {
+		return &pb.Empty{}, status.Error(codes.PermissionDenied, "users can only enroll themselves")
+	}
+	if err := s.Manager.As(claims.UID).ConfirmTOTP(in); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while confirming two-factor authentication")
+	}
+	return &pb.Empty{}, nil
+}
+
+// DisableTOTP ...
+func (s *LDAPManagerServer) DisableTOTP(ctx context.Context, in *pb.DisableTOTPRequest) (*pb.Empty, error) {
+	claims, err := s.authenticate(ctx, in)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := s.authorizeTarget(claims, in.GetUsername()); err != nil {
+		return &pb.Empty{}, err
+	}
+	// admins can disable two-factor authentication of other users (e.g. after losing the device)
+	requireCode := claims.UID == in.GetUsername()
+	if err := s.Manager.As(claims.UID).DisableTOTP(in, requireCode); err != nil {
+		if appErr, safe := err.(ldapmanager.Error); safe {
+			return &pb.Empty{}, toStatus(appErr)
+		}
+		log.Error(err)
+		return &pb.Empty{}, status.Error(codes.Internal, "error while disabling two-factor authentication")
+	}
+	return &pb.Empty{}, nil
+}
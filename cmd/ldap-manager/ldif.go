@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/romnn/flags4urfavecli/values"
+	ldapmanager "github.com/romnn/ldap-manager"
+	ldapbase "github.com/romnn/ldap-manager/cmd/ldap-manager/base"
+	"github.com/urfave/cli/v2"
+)
+
+// connect creates a manager and connects to the directory without running the setup
+func connect(ctx *cli.Context) (*ldapmanager.LDAPManager, error) {
+	manager, err := ldapbase.NewLDAPManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skipSetupLDAP := true
+	if err := manager.Setup(skipSetupLDAP); err != nil {
+		return nil, fmt.Errorf("failed to connect to OpenLDAP: %v", err)
+	}
+	return manager, nil
+}
+
+func exportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "export all users and groups",
+		Flags: []cli.Flag{
+			&cli.GenericFlag{
+				Name: "format",
+				Value: &values.EnumValue{
+					Enum:    []string{"ldif"},
+					Default: "ldif",
+				},
+				Usage: "export format",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "output file (default is stdout)",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			manager, err := connect(ctx)
+			if err != nil {
+				return err
+			}
+			defer manager.Close()
+
+			var out io.Writer = os.Stdout
+			if path := ctx.String("output"); path != "" {
+				file, err := os.Create(path)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				out = file
+			}
+			return manager.ExportLDIF(out)
+		},
+	}
+}
+
+func importCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "import users and groups from an LDIF file",
+		ArgsUsage: "FILE",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "only check the entries without writing anything",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			if ctx.NArg() != 1 {
+				return cli.Exit("expected exactly one LDIF file", 2)
+			}
+			file, err := os.Open(ctx.Args().First())
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			manager, err := connect(ctx)
+			if err != nil {
+				return err
+			}
+			defer manager.Close()
+
+			results, err := manager.ImportLDIF(file, ctx.Bool("dry-run"))
+			counts := make(map[string]int)
+			for _, result := range results {
+				counts[result.Action]++
+				if result.Message != "" {
+					fmt.Printf("%-8s %s (%s)\n", result.Action, result.DN, result.Message)
+				} else {
+					fmt.Printf("%-8s %s\n", result.Action, result.DN)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d added, %d updated, %d skipped, %d invalid\n",
+				counts[ldapmanager.ImportActionAdd], counts[ldapmanager.ImportActionUpdate],
+				counts[ldapmanager.ImportActionSkip], counts[ldapmanager.ImportActionInvalid])
+			if counts[ldapmanager.ImportActionInvalid] > 0 {
+				return cli.Exit("some entries could not be imported", 1)
+			}
+			return nil
+		},
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/romnn/go-grpc-service/auth"
 	ldapbase "github.com/romnn/ldap-manager/cmd/ldap-manager/base"
@@ -18,6 +19,8 @@ import (
 	"github.com/romnn/flags4urfavecli/values"
 	"github.com/romnn/go-grpc-service/versioning"
 	ldapmanager "github.com/romnn/ldap-manager"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	ldaphash "github.com/romnn/ldap-manager/hash"
 	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc"
@@ -34,8 +37,20 @@ type LDAPManagerServer interface {
 	Shutdown()
 }
 
+// hashingAlgorithmNames lists the hashing algorithms in the order of the enum
+func hashingAlgorithmNames() []string {
+	var names []string
+	for value := int32(0); ; value++ {
+		name, ok := pb.HashingAlgorithm_name[value]
+		if !ok {
+			return names
+		}
+		names = append(names, name)
+	}
+}
+
 func main() {
-	shutdown := make(chan os.Signal)
+	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-shutdown
@@ -74,9 +89,10 @@ func main() {
 	}
 
 	jwtAuthFlags := auth.DefaultCLIFlags(&auth.DefaultCLIFlagsOptions{
-		Issuer:    "issuer@example.org",
-		Audience:  "example.org",
-		ExpireSec: 1 * 24 * 60 * 60,
+		Issuer:   "issuer@example.org",
+		Audience: "example.org",
+		// access tokens are short-lived and renewed with refresh tokens
+		ExpireSec: 15 * 60,
 	})
 
 	ldapConfigFlags := []cli.Flag{
@@ -153,6 +169,37 @@ func main() {
 			EnvVars: []string{"OPENLDAP_USE_RFC2307BIS"},
 			Usage:   "openldap use RFC2307BIS schema",
 		},
+		// Connection pool
+		&cli.IntFlag{
+			Name:    "openldap-pool-size",
+			Value:   10,
+			EnvVars: []string{"OPENLDAP_POOL_SIZE"},
+			Usage:   "maximum number of concurrent openldap connections",
+		},
+		&cli.IntFlag{
+			Name:    "openldap-pool-max-retries",
+			Value:   5,
+			EnvVars: []string{"OPENLDAP_POOL_MAX_RETRIES"},
+			Usage:   "number of reconnect attempts before an openldap operation fails",
+		},
+		&cli.DurationFlag{
+			Name:    "openldap-pool-max-backoff",
+			Value:   5 * time.Second,
+			EnvVars: []string{"OPENLDAP_POOL_MAX_BACKOFF"},
+			Usage:   "maximum delay between openldap reconnect attempts",
+		},
+		&cli.DurationFlag{
+			Name:    "openldap-pool-health-check-interval",
+			Value:   30 * time.Second,
+			EnvVars: []string{"OPENLDAP_POOL_HEALTH_CHECK_INTERVAL"},
+			Usage:   "idle time after which a pooled openldap connection is checked before reuse",
+		},
+		&cli.DurationFlag{
+			Name:    "openldap-pool-acquire-timeout",
+			Value:   30 * time.Second,
+			EnvVars: []string{"OPENLDAP_POOL_ACQUIRE_TIMEOUT"},
+			Usage:   "maximum time an openldap operation waits for a connection when all connections are in use",
+		},
 	}
 
 	ldapManagerFlags := []cli.Flag{
@@ -195,6 +242,11 @@ func main() {
 			EnvVars: []string{"GROUP_MEMBERSHIP_USES_UID"},
 			Usage:   "group membership uses UID only instead of full DN",
 		},
+		&cli.StringSliceFlag{
+			Name:    "group-membership-sync-attribute",
+			EnvVars: []string{"GROUP_MEMBERSHIP_SYNC_ATTRIBUTES"},
+			Usage:   "additional group membership attribute (uniqueMember or memberUID) that is kept in sync with the group membership attribute",
+		},
 		&cli.StringFlag{
 			Name:    "account-attribute",
 			Value:   "uid",
@@ -243,6 +295,253 @@ func main() {
 			EnvVars: []string{"FORCE_CREATE_ADMIN"},
 			Usage:   "force creation of the admin user even if there is a different user in the admin group",
 		},
+		// ID allocation
+		&cli.IntFlag{
+			Name:    "uid-min",
+			Value:   ldapmanager.MinUID + 1,
+			EnvVars: []string{"UID_MIN"},
+			Usage:   "lowest uidNumber that is allocated automatically",
+		},
+		&cli.IntFlag{
+			Name:    "uid-max",
+			Value:   0,
+			EnvVars: []string{"UID_MAX"},
+			Usage:   "highest uidNumber that is allocated automatically (0 means no limit)",
+		},
+		&cli.IntFlag{
+			Name:    "gid-min",
+			Value:   ldapmanager.MinGID + 1,
+			EnvVars: []string{"GID_MIN"},
+			Usage:   "lowest gidNumber that is allocated automatically",
+		},
+		&cli.IntFlag{
+			Name:    "gid-max",
+			Value:   0,
+			EnvVars: []string{"GID_MAX"},
+			Usage:   "highest gidNumber that is allocated automatically (0 means no limit)",
+		},
+		&cli.StringSliceFlag{
+			Name:    "uid-pool",
+			EnvVars: []string{"UID_POOLS"},
+			Usage:   "named uidNumber pool that accounts can be allocated from (e.g. service=10000-19999)",
+		},
+		&cli.StringSliceFlag{
+			Name:    "gid-pool",
+			EnvVars: []string{"GID_POOLS"},
+			Usage:   "named gidNumber pool that groups can be allocated from (e.g. service=10000-19999)",
+		},
+		// Password policy
+		&cli.IntFlag{
+			Name:    "password-min-length",
+			Value:   0,
+			EnvVars: []string{"PASSWORD_MIN_LENGTH"},
+			Usage:   "minimum password length",
+		},
+		&cli.BoolFlag{
+			Name:    "password-require-lowercase",
+			EnvVars: []string{"PASSWORD_REQUIRE_LOWERCASE"},
+			Usage:   "require passwords to contain a lowercase letter",
+		},
+		&cli.BoolFlag{
+			Name:    "password-require-uppercase",
+			EnvVars: []string{"PASSWORD_REQUIRE_UPPERCASE"},
+			Usage:   "require passwords to contain an uppercase letter",
+		},
+		&cli.BoolFlag{
+			Name:    "password-require-digit",
+			EnvVars: []string{"PASSWORD_REQUIRE_DIGIT"},
+			Usage:   "require passwords to contain a digit",
+		},
+		&cli.BoolFlag{
+			Name:    "password-require-special",
+			EnvVars: []string{"PASSWORD_REQUIRE_SPECIAL"},
+			Usage:   "require passwords to contain a special character",
+		},
+		&cli.BoolFlag{
+			Name:    "password-reject-personal-info",
+			EnvVars: []string{"PASSWORD_REJECT_PERSONAL_INFO"},
+			Usage:   "reject passwords that contain the username, first or last name",
+		},
+		&cli.StringFlag{
+			Name:    "password-breached-list",
+			EnvVars: []string{"PASSWORD_BREACHED_LIST"},
+			Usage:   "file of breached passwords or upper case SHA-1 hashes (one per line) that are rejected",
+		},
+		&cli.GenericFlag{
+			Name: "hashing-algorithm",
+			Value: &values.EnumValue{
+				Enum:    hashingAlgorithmNames(),
+				Default: pb.HashingAlgorithm_DEFAULT.String(),
+			},
+			EnvVars: []string{"HASHING_ALGORITHM"},
+			Usage:   "default password hashing algorithm (DEFAULT is SSHA)",
+		},
+		&cli.BoolFlag{
+			Name:    "upgrade-hashes-on-login",
+			EnvVars: []string{"UPGRADE_HASHES_ON_LOGIN"},
+			Usage:   "rehash passwords with the hashing algorithm on login if the stored hash uses a weaker scheme",
+		},
+		&cli.StringSliceFlag{
+			Name:    "hash-scheme-strength",
+			EnvVars: []string{"HASH_SCHEME_STRENGTH"},
+			Usage:   "override the rank of a hash scheme used to detect weaker hashes (e.g. SSHA=4)",
+		},
+		&cli.IntFlag{
+			Name:    "bcrypt-cost",
+			Value:   ldaphash.DefaultBcryptCost,
+			EnvVars: []string{"BCRYPT_COST"},
+			Usage:   "cost of BLOWFISH (bcrypt) password hashes (4-31)",
+		},
+		&cli.IntFlag{
+			Name:    "extdes-rounds",
+			Value:   ldaphash.DefaultEXTDESRounds,
+			EnvVars: []string{"EXTDES_ROUNDS"},
+			Usage:   "number of rounds of EXTDES (BSDi extended DES) password hashes",
+		},
+		&cli.IntFlag{
+			Name:    "argon2-time",
+			Value:   ldaphash.DefaultArgon2Time,
+			EnvVars: []string{"ARGON2_TIME"},
+			Usage:   "number of passes over the memory of ARGON2ID password hashes",
+		},
+		&cli.IntFlag{
+			Name:    "argon2-memory",
+			Value:   ldaphash.DefaultArgon2Memory,
+			EnvVars: []string{"ARGON2_MEMORY"},
+			Usage:   "memory in KiB used by ARGON2ID password hashes",
+		},
+		&cli.IntFlag{
+			Name:    "argon2-threads",
+			Value:   ldaphash.DefaultArgon2Threads,
+			EnvVars: []string{"ARGON2_THREADS"},
+			Usage:   "parallelism of ARGON2ID password hashes",
+		},
+		&cli.IntFlag{
+			Name:    "pbkdf2-iterations",
+			Value:   ldaphash.DefaultPBKDF2Iterations,
+			EnvVars: []string{"PBKDF2_ITERATIONS"},
+			Usage:   "number of iterations of PBKDF2_SHA256 and PBKDF2_SHA512 password hashes",
+		},
+		&cli.IntFlag{
+			Name:    "password-history",
+			Value:   0,
+			EnvVars: []string{"PASSWORD_HISTORY"},
+			Usage:   "number of previous passwords that can not be reused (0 disables the history)",
+		},
+		&cli.StringFlag{
+			Name:    "password-history-dn",
+			EnvVars: []string{"PASSWORD_HISTORY_DN"},
+			Usage:   "password history DN (default is ou=password-history,<base-dn>)",
+		},
+		// Password reset
+		&cli.StringFlag{
+			Name:    "mail-sender",
+			EnvVars: []string{"MAIL_SENDER"},
+			Usage:   "mail sender for password reset tokens (stdout, file:PATH or smtp://[USER:PASSWORD@]HOST:PORT?from=ADDRESS), password reset is disabled without a sender",
+		},
+		&cli.StringFlag{
+			Name:    "password-reset-url",
+			EnvVars: []string{"PASSWORD_RESET_URL"},
+			Usage:   "link included in password reset mails with {token} replaced by the token (e.g. https://ldap.example.org/reset?token={token})",
+		},
+		&cli.StringFlag{
+			Name:    "password-reset-dn",
+			EnvVars: []string{"PASSWORD_RESET_DN"},
+			Usage:   "password reset token DN (default is ou=password-resets,<base-dn>)",
+		},
+		&cli.DurationFlag{
+			Name:    "password-reset-ttl",
+			Value:   ldapmanager.DefaultPasswordResetTTL,
+			EnvVars: []string{"PASSWORD_RESET_TTL"},
+			Usage:   "validity of password reset tokens",
+		},
+		&cli.IntFlag{
+			Name:    "password-reset-rate-limit",
+			Value:   ldapmanager.DefaultPasswordResetRateLimit,
+			EnvVars: []string{"PASSWORD_RESET_RATE_LIMIT"},
+			Usage:   "maximum number of password resets per account within the rate window",
+		},
+		&cli.DurationFlag{
+			Name:    "password-reset-rate-window",
+			Value:   ldapmanager.DefaultPasswordResetRateWindow,
+			EnvVars: []string{"PASSWORD_RESET_RATE_WINDOW"},
+			Usage:   "window of the password reset rate limit",
+		},
+		// Two-factor authentication
+		&cli.StringFlag{
+			Name:    "totp-key",
+			EnvVars: []string{"TOTP_KEY"},
+			Usage:   "secret key used to encrypt TOTP secrets and sign login challenges, two-factor authentication is disabled without a key",
+		},
+		&cli.StringFlag{
+			Name:    "totp-attribute",
+			Value:   ldapmanager.DefaultTwoFactorAttribute,
+			EnvVars: []string{"TOTP_ATTRIBUTE"},
+			Usage:   "account attribute that stores the encrypted TOTP secret",
+		},
+		&cli.StringFlag{
+			Name:    "totp-issuer",
+			Value:   ldapmanager.DefaultTwoFactorIssuer,
+			EnvVars: []string{"TOTP_ISSUER"},
+			Usage:   "issuer shown in authenticator apps",
+		},
+		&cli.BoolFlag{
+			Name:    "require-admin-totp",
+			EnvVars: []string{"REQUIRE_ADMIN_TOTP"},
+			Usage:   "only grant admin privileges to members of the admin group that enabled two-factor authentication",
+		},
+		// Roles
+		&cli.StringSliceFlag{
+			Name:    "role",
+			EnvVars: []string{"ROLES"},
+			Usage:   "define or override a role (NAME=PERMISSION[,PERMISSION...]), built-in roles are admin, helpdesk, group-owner and auditor",
+		},
+		&cli.StringSliceFlag{
+			Name:    "role-groups",
+			EnvVars: []string{"ROLE_GROUPS"},
+			Usage:   "grant a role to the members of LDAP groups (NAME=GROUP[,GROUP...]), members of the default admin group are always admins",
+		},
+		// Sessions
+		&cli.StringFlag{
+			Name:    "sessions-dn",
+			EnvVars: []string{"SESSIONS_DN"},
+			Usage:   "sessions DN (default is ou=sessions,<base-dn>)",
+		},
+		&cli.DurationFlag{
+			Name:    "session-ttl",
+			Value:   ldapmanager.DefaultSessionTTL,
+			EnvVars: []string{"SESSION_TTL"},
+			Usage:   "validity of refresh tokens, each refresh extends the session",
+		},
+		// Group join requests
+		&cli.StringFlag{
+			Name:    "join-requests-dn",
+			EnvVars: []string{"JOIN_REQUESTS_DN"},
+			Usage:   "group join requests DN (default is ou=requests,<base-dn>)",
+		},
+		&cli.DurationFlag{
+			Name:    "join-request-ttl",
+			Value:   ldapmanager.DefaultJoinRequestTTL,
+			EnvVars: []string{"JOIN_REQUEST_TTL"},
+			Usage:   "time after which pending group join requests are discarded",
+		},
+		// Password policy overlay
+		&cli.BoolFlag{
+			Name:    "ppolicy",
+			EnvVars: []string{"PPOLICY"},
+			Usage:   "send the password policy control on login and manage password policies (requires the ppolicy overlay)",
+		},
+		&cli.StringFlag{
+			Name:    "ppolicy-dn",
+			EnvVars: []string{"PPOLICY_DN"},
+			Usage:   "password policy DN (default is ou=policies,<base-dn>)",
+		},
+		// Audit log
+		&cli.StringSliceFlag{
+			Name:    "audit-sink",
+			EnvVars: []string{"AUDIT_SINKS"},
+			Usage:   "audit log sink (stdout, file:PATH, syslog or syslog:NETWORK://ADDRESS)",
+		},
 	}
 
 	name := "ldap manager service"
@@ -268,7 +567,10 @@ func main() {
 						return fmt.Errorf("failed to listen: %v", err)
 					}
 
-					base := ldapbase.NewLDAPManagerServer(cliCtx)
+					base, err := ldapbase.NewLDAPManagerServer(cliCtx)
+					if err != nil {
+						return err
+					}
 					var wg sync.WaitGroup
 					wg.Add(2)
 					ctx := context.Background()
@@ -288,7 +590,12 @@ func main() {
 					return nil
 				},
 			},
-			// TODO: Implement CLI interface with more commands
+			exportCommand(),
+			importCommand(),
+			accountsCommand(),
+			accountCommand(),
+			groupCommand(),
+			memberCommand(),
 		},
 	}
 	err := app.Run(os.Args)
@@ -21,7 +21,9 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/testcontainers/testcontainers-go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
@@ -256,3 +258,186 @@ func TestSetup(t *testing.T) {
 		// TODO: also check the gatway via REST
 	}
 }
+
+// TestRoleBasedAccess ...
+func TestRoleBasedAccess(t *testing.T) {
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	manager := test.ManagerServer.Manager
+	roles, err := ldapmanager.ParseRoles([]string{"accounts=MANAGE_ACCOUNTS"})
+	if err != nil {
+		t.Fatalf("failed to parse roles: %v", err)
+	}
+	manager.Roles = roles
+	manager.RoleGroups = map[string][]string{ldapmanager.RoleHelpdesk: {"support"}, "accounts": {"accountants"}}
+	for _, username := range []string{"helper", "someone", "accountant"} {
+		if err := manager.NewAccount(&pb.NewAccountRequest{Account: &pb.Account{
+			Username:  username,
+			Password:  "Hallo Welt",
+			Email:     username + "@example.org",
+			FirstName: "roman",
+			LastName:  "d",
+		}}, pb.HashingAlgorithm_DEFAULT); err != nil {
+			t.Fatalf("failed to add user %q: %v", username, err)
+		}
+	}
+	if err := manager.NewGroup(&pb.NewGroupRequest{Name: "support", Members: []string{"helper"}}, false); err != nil {
+		t.Fatalf("failed to add group: %v", err)
+	}
+	if err := manager.NewGroup(&pb.NewGroupRequest{Name: "accountants", Members: []string{"accountant"}}, false); err != nil {
+		t.Fatalf("failed to add group: %v", err)
+	}
+
+	login := func(username string) context.Context {
+		token, err := test.ManagerClient.Login(context.Background(), &pb.LoginRequest{Username: username, Password: "Hallo Welt"})
+		if err != nil {
+			t.Fatalf("failed to login as %q: %v", username, err)
+		}
+		return metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{
+			"x-user-token": token.GetToken(),
+		}))
+	}
+	helper, someone, accountant := login("helper"), login("someone"), login("accountant")
+
+	// the helpdesk can reset passwords and unlock accounts but not manage accounts
+	if _, err := test.ManagerClient.UnlockAccount(helper, &pb.UnlockAccountRequest{Username: "someone"}); err != nil {
+		t.Errorf("expected helpdesk to unlock accounts but got %v", err)
+	}
+	if _, err := test.ManagerClient.ChangePassword(helper, &pb.ChangePasswordRequest{Username: "someone", Password: "Neues Passwort 1"}); err != nil {
+		t.Errorf("expected helpdesk to reset passwords but got %v", err)
+	}
+	if _, err := test.ManagerClient.DeleteAccount(helper, &pb.DeleteAccountRequest{Username: "someone"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected helpdesk to not delete accounts but got %v", err)
+	}
+
+	// the helpdesk can not take over accounts with more permissions
+	if _, err := test.ManagerClient.ChangePassword(helper, &pb.ChangePasswordRequest{Username: manager.DefaultAdminUsername, Password: "Neues Passwort 1"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected helpdesk to not reset the password of admins but got %v", err)
+	}
+	if _, err := manager.AuthenticateUser(&pb.LoginRequest{Username: manager.DefaultAdminUsername, Password: manager.DefaultAdminPassword}); err != nil {
+		t.Errorf("expected the admin password to be unchanged but got %v", err)
+	}
+
+	// managing accounts does not allow to take over accounts with more permissions
+	if _, err := test.ManagerClient.UpdateAccount(accountant, &pb.UpdateAccountRequest{Username: manager.DefaultAdminUsername, Update: &pb.Account{Password: "Neues Passwort 1"}}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected accountants to not update admins but got %v", err)
+	}
+	if _, err := test.ManagerClient.DeleteAccount(accountant, &pb.DeleteAccountRequest{Username: manager.DefaultAdminUsername}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected accountants to not delete admins but got %v", err)
+	}
+	if _, err := test.ManagerClient.LockAccount(accountant, &pb.LockAccountRequest{Username: manager.DefaultAdminUsername}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected accountants to not lock admins but got %v", err)
+	}
+	if _, err := test.ManagerClient.DisableTOTP(accountant, &pb.DisableTOTPRequest{Username: manager.DefaultAdminUsername}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected accountants to not disable two-factor authentication of admins but got %v", err)
+	}
+	if _, err := test.ManagerClient.UnlockAccount(helper, &pb.UnlockAccountRequest{Username: manager.DefaultAdminUsername}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected helpdesk to not unlock admins but got %v", err)
+	}
+	if _, err := test.ManagerClient.RevokeSessions(helper, &pb.RevokeSessionsRequest{Username: manager.DefaultAdminUsername}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected helpdesk to not revoke sessions of admins but got %v", err)
+	}
+	if _, err := test.ManagerClient.UpdateAccount(accountant, &pb.UpdateAccountRequest{Username: "someone", Update: &pb.Account{FirstName: "some"}}); err != nil {
+		t.Errorf("expected accountants to update users but got %v", err)
+	}
+
+	// editing another account does not hand out a token of that account
+	adminToken, err := test.ManagerClient.Login(context.Background(), &pb.LoginRequest{Username: manager.DefaultAdminUsername, Password: manager.DefaultAdminPassword})
+	if err != nil {
+		t.Fatalf("failed to login as admin: %v", err)
+	}
+	admin := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{
+		"x-user-token": adminToken.GetToken(),
+	}))
+	token, err := test.ManagerClient.UpdateAccount(admin, &pb.UpdateAccountRequest{Username: "someone", Update: &pb.Account{FirstName: "someone"}})
+	if err != nil {
+		t.Errorf("expected admin to update accounts but got %v", err)
+	} else if token.GetUsername() != manager.DefaultAdminUsername || token.GetToken() != adminToken.GetToken() {
+		t.Errorf("expected the token of the admin to stay the same but got one for %q", token.GetUsername())
+	}
+
+	// users without roles can only use self service
+	if _, err := test.ManagerClient.GetAccount(someone, &pb.GetAccountRequest{Username: "someone"}); err != nil {
+		t.Errorf("expected users to get their own account but got %v", err)
+	}
+	if _, err := test.ManagerClient.GetAccount(someone, &pb.GetAccountRequest{Username: "helper"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected users to not get other accounts but got %v", err)
+	}
+	if _, err := test.ManagerClient.GetUserList(someone, &pb.GetUserListRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected users to not list accounts but got %v", err)
+	}
+}
+
+// TestGroupOwnerAccess ...
+func TestGroupOwnerAccess(t *testing.T) {
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	manager := test.ManagerServer.Manager
+	for _, username := range []string{"lead", "someone"} {
+		if err := manager.NewAccount(&pb.NewAccountRequest{Account: &pb.Account{
+			Username:  username,
+			Password:  "Hallo Welt",
+			Email:     username + "@example.org",
+			FirstName: "roman",
+			LastName:  "d",
+		}}, pb.HashingAlgorithm_DEFAULT); err != nil {
+			t.Fatalf("failed to add user %q: %v", username, err)
+		}
+	}
+	for _, group := range []string{"team", "other"} {
+		if err := manager.NewGroup(&pb.NewGroupRequest{Name: group, Members: []string{"lead"}, Owners: []string{"lead"}}, false); err != nil {
+			t.Fatalf("failed to add group %q: %v", group, err)
+		}
+	}
+	if err := manager.UpdateGroup(&pb.UpdateGroupRequest{Name: "other", RemoveOwners: []string{"lead"}}); err != nil {
+		t.Fatalf("failed to remove owner: %v", err)
+	}
+
+	token, err := test.ManagerClient.Login(context.Background(), &pb.LoginRequest{Username: "lead", Password: "Hallo Welt"})
+	if err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	lead := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{
+		"x-user-token": token.GetToken(),
+	}))
+
+	owned, err := test.ManagerClient.GetOwnedGroups(lead, &pb.GetOwnedGroupsRequest{Username: "lead"})
+	if err != nil {
+		t.Fatalf("failed to get owned groups: %v", err)
+	}
+	if len(owned.GetGroups()) != 1 || owned.GetGroups()[0] != "team" {
+		t.Errorf("expected lead to own [team] but got %v", owned.GetGroups())
+	}
+	if _, err := test.ManagerClient.AddGroupMember(lead, &pb.GroupMember{Group: "team", Username: "someone"}); err != nil {
+		t.Errorf("expected owners to add members but got %v", err)
+	}
+	if _, err := test.ManagerClient.DeleteGroupMember(lead, &pb.GroupMember{Group: "team", Username: "someone"}); err != nil {
+		t.Errorf("expected owners to remove members but got %v", err)
+	}
+	if _, err := test.ManagerClient.AddGroupMember(lead, &pb.GroupMember{Group: "other", Username: "someone"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected owners to not manage other groups but got %v", err)
+	}
+	if _, err := test.ManagerClient.AddGroupMember(lead, &pb.GroupMember{Group: manager.DefaultAdminGroup, Username: "someone"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected owners to not manage the admin group but got %v", err)
+	}
+
+	// users can leave groups but not remove nested groups from them
+	token, err = test.ManagerClient.Login(context.Background(), &pb.LoginRequest{Username: "someone", Password: "Hallo Welt"})
+	if err != nil {
+		t.Fatalf("failed to login: %v", err)
+	}
+	someone := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{
+		"x-user-token": token.GetToken(),
+	}))
+	if _, err := test.ManagerClient.DeleteGroupMember(someone, &pb.GroupMember{Group: "team", Username: "someone", MemberGroup: "other"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected users to not remove nested groups but got %v", err)
+	}
+
+	// owners can not grant themselves the role of a group mapped to it
+	manager.RoleGroups = map[string][]string{ldapmanager.RoleHelpdesk: {"team"}}
+	if _, err := test.ManagerClient.AddGroupMember(lead, &pb.GroupMember{Group: "team", Username: "someone"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected owners to not manage groups mapped to roles but got %v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package ldapmanager
 
 import (
+	"fmt"
+	"strings"
+
 	"google.golang.org/grpc/codes"
 )
 
@@ -28,10 +31,17 @@ type Error interface {
 type ValidationError struct {
 	ApplicationError
 	Message string
+	// Field is the invalid field of the request, if known
+	Field string
+	// Violations lists every rule that failed, if there are multiple
+	Violations []string
 }
 
 // Error ...
 func (e *ValidationError) Error() string {
+	if len(e.Violations) > 0 {
+		return fmt.Sprintf("%s: %s", e.Message, strings.Join(e.Violations, ", "))
+	}
 	return e.Message
 }
 
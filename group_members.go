@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/go-ldap/ldap"
 	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
@@ -64,7 +65,7 @@ func (m *LDAPManager) getGroup(groupName string) (*pb.Group, error) {
 		m.GroupsDN,
 		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 		fmt.Sprintf("(cn=%s)", escapeFilter(groupName)),
-		[]string{m.GroupMembershipAttribute, "gidNumber"},
+		[]string{m.GroupMembershipAttribute, "gidNumber", "owner"},
 		[]ldap.Control{},
 	))
 	if err != nil {
@@ -83,19 +84,32 @@ func (m *LDAPManager) getGroup(groupName string) (*pb.Group, error) {
 		Members: members,
 		Name:    groupName,
 		Gid:     int32(gid),
+		Owners:  group.GetAttributeValues("owner"),
 	}, nil
 }
 
 // IsGroupMember ...
 func (m *LDAPManager) IsGroupMember(req *pb.IsGroupMemberRequest) (*pb.GroupMemberStatus, error) {
 	var status pb.GroupMemberStatus
-	result, err := m.findGroup(req.Group, []string{"dn", m.GroupMembershipAttribute})
+	result, err := m.findGroup(req.Group, []string{"dn", "cn", m.GroupMembershipAttribute})
 	if err != nil {
 		return &status, err
 	}
 	if len(result.Entries) != 1 {
 		return &status, &ZeroOrMultipleGroupsError{Group: req.GetGroup(), Count: len(result.Entries)}
 	}
+	if req.GetEffective() && m.nestedGroupsSupported() {
+		groups, err := m.effectiveGroups(m.AccountNamed(req.GetUsername()))
+		if err != nil {
+			return &status, err
+		}
+		for _, group := range groups {
+			if strings.EqualFold(group, result.Entries[0].GetAttributeValue("cn")) {
+				return &pb.GroupMemberStatus{IsMember: true}, nil
+			}
+		}
+		return &status, nil
+	}
 	if !m.GroupMembershipUsesUID {
 		req.Username = fmt.Sprintf("%s=%s,%s", m.AccountAttribute, req.GetUsername(), m.UserGroupDN)
 	}
@@ -109,6 +123,13 @@ func (m *LDAPManager) IsGroupMember(req *pb.IsGroupMemberRequest) (*pb.GroupMemb
 
 // GetUserGroups ...
 func (m *LDAPManager) GetUserGroups(req *pb.GetUserGroupsRequest) (*pb.GroupList, error) {
+	if req.GetEffective() && m.nestedGroupsSupported() {
+		groups, err := m.effectiveGroups(m.AccountNamed(req.GetUsername()))
+		if err != nil {
+			return nil, err
+		}
+		return sortedGroupList(groups), nil
+	}
 	username := escapeDN(req.GetUsername())
 	if !m.GroupMembershipUsesUID {
 		username = m.AccountNamed(req.GetUsername())
@@ -140,14 +161,31 @@ func (m *LDAPManager) GetGroup(req *pb.GetGroupRequest) (*pb.Group, error) {
 	if err != nil {
 		return nil, err
 	}
-	normGroup := &pb.Group{Name: group.GetName(), Gid: group.GetGid(), Total: int64(len(group.GetMembers()))}
+	normGroup := &pb.Group{
+		Name:   group.GetName(),
+		Gid:    group.GetGid(),
+		Owners: m.ownerUsernames(group.GetOwners()),
+	}
 
-	// Convert member DN's to usernames
+	// Convert member DN's to usernames and group names
 	for _, memberDN := range group.GetMembers() {
-		if memberUsername, err := extractAttribute(memberDN, m.AccountAttribute); err == nil && memberUsername != "" {
-			normGroup.Members = append(normGroup.GetMembers(), memberUsername)
+		name, isGroup := m.memberName(memberDN)
+		if name == "" {
+			continue
+		}
+		if isGroup {
+			normGroup.MemberGroups = append(normGroup.GetMemberGroups(), name)
+		} else {
+			normGroup.Members = append(normGroup.GetMembers(), name)
 		}
 	}
+	sort.Strings(normGroup.MemberGroups)
+	if req.GetEffective() && m.nestedGroupsSupported() {
+		if normGroup.Members, err = m.effectiveMembers(group.GetName()); err != nil {
+			return nil, err
+		}
+	}
+	normGroup.Total = int64(len(normGroup.GetMembers()))
 
 	// Sort
 	sort.Slice(normGroup.Members, func(i, j int) bool {
@@ -170,6 +208,12 @@ func (m *LDAPManager) AddGroupMember(req *pb.GroupMember, allowNonExistent bool)
 	if req.GetGroup() == "" {
 		return &ValidationError{Message: "group name must not be empty"}
 	}
+	if req.GetMemberGroup() != "" && req.GetUsername() != "" {
+		return &ValidationError{Message: "either a user or a group can be added at once", Field: "member_group"}
+	}
+	if req.GetMemberGroup() != "" {
+		return m.addNestedGroup(req)
+	}
 	if req.GetUsername() == "" {
 		return &ValidationError{Message: "username must not be empty"}
 	}
@@ -194,8 +238,11 @@ func (m *LDAPManager) AddGroupMember(req *pb.GroupMember, allowNonExistent bool)
 		[]ldap.Control{},
 	)
 	modifyRequest.Add(m.GroupMembershipAttribute, []string{username})
+	if err := m.syncMembership(modifyRequest, req.GetUsername(), false, true); err != nil {
+		return err
+	}
 	log.Debugf("AddGroupMember: modifyRequest=%v", modifyRequest)
-	if err := m.ldap.Modify(modifyRequest); err != nil {
+	if err := m.modify(modifyRequest); err != nil {
 		if ldap.IsErrorWithCode(err, ldap.LDAPResultAttributeOrValueExists) {
 			return &MemberAlreadyExistsError{Member: req.GetUsername(), Group: req.GetGroup()}
 		}
@@ -207,12 +254,18 @@ func (m *LDAPManager) AddGroupMember(req *pb.GroupMember, allowNonExistent bool)
 
 // DeleteGroupMember ...
 func (m *LDAPManager) DeleteGroupMember(req *pb.GroupMember, allowDeleteOfDefaultGroups bool) error {
-	if req.GetGroup() == "" || req.GetUsername() == "" {
+	if req.GetGroup() == "" || (req.GetUsername() == "" && req.GetMemberGroup() == "") {
 		return &ValidationError{Message: "group and user name can not be empty"}
 	}
+	if req.GetMemberGroup() != "" && req.GetUsername() != "" {
+		return &ValidationError{Message: "either a user or a group can be removed at once", Field: "member_group"}
+	}
 	if !allowDeleteOfDefaultGroups && m.IsProtectedGroup(req.GetGroup()) {
 		return &ValidationError{Message: "deleting members from the default user or admin group is not allowed"}
 	}
+	if req.GetMemberGroup() != "" {
+		return m.deleteNestedGroup(req)
+	}
 	username := escapeDN(req.GetUsername())
 	if !m.GroupMembershipUsesUID {
 		username = m.AccountNamed(req.GetUsername())
@@ -222,8 +275,11 @@ func (m *LDAPManager) DeleteGroupMember(req *pb.GroupMember, allowDeleteOfDefaul
 		[]ldap.Control{},
 	)
 	modifyRequest.Delete(m.GroupMembershipAttribute, []string{username})
+	if err := m.syncMembership(modifyRequest, req.GetUsername(), false, false); err != nil {
+		return err
+	}
 	log.Debugf("DeleteGroupMember: modifyRequest=%v", modifyRequest)
-	if err := m.ldap.Modify(modifyRequest); err != nil {
+	if err := m.modify(modifyRequest); err != nil {
 		if ldap.IsErrorWithCode(err, ldap.LDAPResultObjectClassViolation) {
 			return &RemoveLastGroupMemberError{Group: req.GetGroup()}
 		}
@@ -0,0 +1,144 @@
+package ldapmanager
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+)
+
+// Group owners are stored as account DNs in the owner attribute of the group, which requires the
+// groupOfUniqueNames object class of the RFC2307BIS schema. Owners can manage the members of their
+// groups without any other permission, the default user and admin groups, the groups mapped to roles
+// and the groups nested in them can not have owners.
+
+func (m *LDAPManager) checkGroupOwners(groupName string) error {
+	if !m.UseRFC2307BISSchema {
+		return &ValidationError{Message: "group owners require the RFC2307BIS schema", Field: "owners"}
+	}
+	protected, err := m.IsNestedInProtectedGroup(groupName)
+	if err != nil {
+		return err
+	}
+	if protected {
+		return &ValidationError{Message: "the default user or admin group and groups granting roles can not have owners", Field: "owners"}
+	}
+	return nil
+}
+
+// ownerDNs returns the DNs of the owner accounts, which must exist
+func (m *LDAPManager) ownerDNs(usernames []string) ([]string, error) {
+	var owners []string
+	for _, username := range usernames {
+		entry, err := m.findAccount(username, []string{"dn"})
+		if err != nil {
+			return nil, err
+		}
+		owners = append(owners, entry.DN)
+	}
+	return owners, nil
+}
+
+func (m *LDAPManager) ownerUsernames(ownerDNs []string) []string {
+	var owners []string
+	for _, ownerDN := range ownerDNs {
+		if owner, err := extractAttribute(ownerDN, m.AccountAttribute); err == nil && owner != "" {
+			owners = append(owners, owner)
+		}
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+func (m *LDAPManager) updateGroupOwners(groupName string, addOwners, removeOwners []string) error {
+	if len(addOwners) < 1 && len(removeOwners) < 1 {
+		return nil
+	}
+	if len(addOwners) > 0 {
+		// owners can always be removed, e.g. after the group was mapped to a role
+		if err := m.checkGroupOwners(groupName); err != nil {
+			return err
+		}
+	}
+	add, err := m.ownerDNs(addOwners)
+	if err != nil {
+		return err
+	}
+	modifyRequest := ldap.NewModifyRequest(m.GroupNamed(groupName), []ldap.Control{})
+	if len(add) > 0 {
+		modifyRequest.Add("owner", add)
+	}
+	var remove []string
+	for _, username := range removeOwners {
+		remove = append(remove, m.AccountNamed(username))
+	}
+	if len(remove) > 0 {
+		modifyRequest.Delete("owner", remove)
+	}
+	if err := m.modify(modifyRequest); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return &ZeroOrMultipleGroupsError{Group: groupName}
+		}
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultAttributeOrValueExists) {
+			return &ValidationError{Message: "user already owns the group", Field: "add_owners"}
+		}
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) {
+			return &ValidationError{Message: "user does not own the group", Field: "remove_owners"}
+		}
+		return fmt.Errorf("failed to update owners of group %q: %v", groupName, err)
+	}
+	log.Infof("added %d and removed %d owners of group %q", len(add), len(remove), groupName)
+	return nil
+}
+
+// GetOwnedGroups returns the groups owned by a user
+func (m *LDAPManager) GetOwnedGroups(req *pb.GetOwnedGroupsRequest) (*pb.GroupList, error) {
+	if req.GetUsername() == "" {
+		return nil, &ValidationError{Message: "username must not be empty"}
+	}
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.GroupsDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&(objectClass=posixGroup)(owner=%s))", escapeFilter(m.AccountNamed(req.GetUsername()))),
+		[]string{"cn"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find groups owned by %q: %v", req.GetUsername(), err)
+	}
+	groupList := &pb.GroupList{Total: int64(len(result.Entries))}
+	for _, group := range result.Entries {
+		if cn := group.GetAttributeValue("cn"); cn != "" {
+			groupList.Groups = append(groupList.Groups, cn)
+		}
+	}
+	sort.Strings(groupList.Groups)
+	return groupList, nil
+}
+
+// replaceGroupOwner replaces the owner in all groups, the owner is only removed if the new owner is empty
+func (m *LDAPManager) replaceGroupOwner(ownerDN, newOwnerDN string) error {
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.GroupsDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&(objectClass=posixGroup)(owner=%s))", escapeFilter(ownerDN)),
+		[]string{"dn"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return fmt.Errorf("failed to find groups owned by %q: %v", ownerDN, err)
+	}
+	for _, group := range result.Entries {
+		modifyRequest := ldap.NewModifyRequest(group.DN, []ldap.Control{})
+		modifyRequest.Delete("owner", []string{ownerDN})
+		if newOwnerDN != "" {
+			modifyRequest.Add("owner", []string{newOwnerDN})
+		}
+		if err := m.modify(modifyRequest); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) {
+			return fmt.Errorf("failed to update owners of group %q: %v", group.DN, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package ldapmanager
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestGroupOwners ...
+func TestGroupOwners(t *testing.T) {
+	if skipGroupOwnerTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	for _, username := range []string{"lead", "member"} {
+		if err := test.Manager.NewAccount(&pb.NewAccountRequest{Account: &pb.Account{
+			Username:  username,
+			Password:  "Hallo Welt",
+			Email:     username + "@example.org",
+			FirstName: "roman",
+			LastName:  "d",
+		}}, pb.HashingAlgorithm_DEFAULT); err != nil {
+			t.Fatalf("failed to add user %q: %v", username, err)
+		}
+	}
+	if err := test.Manager.NewGroup(&pb.NewGroupRequest{Name: "team", Members: []string{"member"}, Owners: []string{"lead"}}, false); err != nil {
+		t.Fatalf("failed to add group: %v", err)
+	}
+	group, err := test.Manager.GetGroup(&pb.GetGroupRequest{Name: "team"})
+	if err != nil {
+		t.Fatalf("failed to get group: %v", err)
+	}
+	if !reflect.DeepEqual(group.GetOwners(), []string{"lead"}) {
+		t.Errorf("expected lead to own team but got %v", group.GetOwners())
+	}
+	owned, err := test.Manager.GetOwnedGroups(&pb.GetOwnedGroupsRequest{Username: "lead"})
+	if err != nil {
+		t.Fatalf("failed to get owned groups: %v", err)
+	}
+	if !reflect.DeepEqual(owned.GetGroups(), []string{"team"}) {
+		t.Errorf("expected lead to own [team] but got %v", owned.GetGroups())
+	}
+	roles, err := test.Manager.GetRoles("lead")
+	if err != nil {
+		t.Fatalf("failed to get roles: %v", err)
+	}
+	if !reflect.DeepEqual(roles, []string{RoleGroupOwner}) {
+		t.Errorf("expected lead to be group owner but got %v", roles)
+	}
+
+	// protected groups can not have owners
+	if err := test.Manager.UpdateGroup(&pb.UpdateGroupRequest{Name: test.Manager.DefaultAdminGroup, AddOwners: []string{"lead"}}); err == nil {
+		t.Errorf("expected adding an owner to the admin group to fail")
+	}
+	test.Manager.RoleGroups = map[string][]string{RoleHelpdesk: {"team"}}
+	if err := test.Manager.UpdateGroup(&pb.UpdateGroupRequest{Name: "team", AddOwners: []string{"member"}}); err == nil {
+		t.Errorf("expected adding an owner to a group mapped to a role to fail")
+	}
+	test.Manager.RoleGroups = nil
+
+	// renaming the owner keeps the ownership
+	if _, _, err := test.Manager.UpdateAccount(&pb.UpdateAccountRequest{Username: "lead", Update: &pb.Account{Username: "chief"}}, pb.HashingAlgorithm_DEFAULT, true); err != nil {
+		t.Fatalf("failed to rename owner: %v", err)
+	}
+	if owner, err := test.Manager.IsGroupOwner("team", "chief"); err != nil || !owner {
+		t.Errorf("expected chief to own team after the rename (err=%v)", err)
+	}
+
+	if err := test.Manager.UpdateGroup(&pb.UpdateGroupRequest{Name: "team", AddOwners: []string{"member"}, RemoveOwners: []string{"chief"}}); err != nil {
+		t.Fatalf("failed to update owners: %v", err)
+	}
+	if group, err = test.Manager.GetGroup(&pb.GetGroupRequest{Name: "team"}); err != nil {
+		t.Fatalf("failed to get group: %v", err)
+	}
+	if !reflect.DeepEqual(group.GetOwners(), []string{"member"}) {
+		t.Errorf("expected member to own team but got %v", group.GetOwners())
+	}
+
+	// deleting the owner removes the ownership
+	if err := test.Manager.DeleteAccount(&pb.DeleteAccountRequest{Username: "member"}, true); err != nil {
+		t.Fatalf("failed to delete owner: %v", err)
+	}
+	if group, err = test.Manager.GetGroup(&pb.GetGroupRequest{Name: "team"}); err != nil {
+		t.Fatalf("failed to get group: %v", err)
+	}
+	if len(group.GetOwners()) != 0 {
+		t.Errorf("expected team to have no owners but got %v", group.GetOwners())
+	}
+}
@@ -2,7 +2,6 @@ package ldapmanager
 
 import (
 	"fmt"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -90,13 +89,21 @@ func (m *LDAPManager) NewGroup(req *pb.NewGroupRequest, strict bool) error {
 	if len(result.Entries) > 0 {
 		return &GroupAlreadyExistsError{Group: req.GetName()}
 	}
-	highestGID, err := m.getHighestID(m.GroupAttribute)
+	var owners []string
+	if len(req.GetOwners()) > 0 {
+		if err := m.checkGroupOwners(req.GetName()); err != nil {
+			return err
+		}
+		if owners, err = m.ownerDNs(req.GetOwners()); err != nil {
+			return err
+		}
+	}
+	newGID, err := m.AllocateGID(req.GetIdPool())
 	if err != nil {
 		return err
 	}
-	newGID := highestGID + 1
 
-	var memberList []string
+	var memberList, usernames []string
 	for _, username := range req.GetMembers() {
 		if strict {
 			memberStatus, err := m.IsGroupMember(&pb.IsGroupMemberRequest{Username: username, Group: m.DefaultUserGroup})
@@ -113,6 +120,7 @@ func (m *LDAPManager) NewGroup(req *pb.NewGroupRequest, strict bool) error {
 			member = m.AccountNamed(username)
 		}
 		memberList = append(memberList, member)
+		usernames = append(usernames, username)
 	}
 
 	var groupAttributes []ldap.Attribute
@@ -136,6 +144,19 @@ func (m *LDAPManager) NewGroup(req *pb.NewGroupRequest, strict bool) error {
 	groupAttributes = append(groupAttributes, ldap.Attribute{
 		Type: m.GroupMembershipAttribute, Vals: memberList,
 	})
+	for _, attribute := range m.groupMembershipAttributes()[1:] {
+		var values []string
+		for _, username := range usernames {
+			value, _ := m.memberValue(attribute, username, false)
+			values = append(values, value)
+		}
+		if len(values) > 0 {
+			groupAttributes = append(groupAttributes, ldap.Attribute{Type: attribute, Vals: values})
+		}
+	}
+	if len(owners) > 0 {
+		groupAttributes = append(groupAttributes, ldap.Attribute{Type: "owner", Vals: owners})
+	}
 
 	addGroupRequest := &ldap.AddRequest{
 		DN:         m.GroupNamed(req.GetName()),
@@ -143,10 +164,7 @@ func (m *LDAPManager) NewGroup(req *pb.NewGroupRequest, strict bool) error {
 		Controls:   []ldap.Control{},
 	}
 	log.Debugf("addGroupRequest=%v", addGroupRequest)
-	if err := m.ldap.Add(addGroupRequest); err != nil {
-		return err
-	}
-	if err := m.updateLastID("lastGID", newGID); err != nil {
+	if err := m.add(addGroupRequest); err != nil {
 		return err
 	}
 	log.Infof("added new group %q with %d members (gid=%d)", req.GetName(), len(memberList), newGID)
@@ -161,7 +179,7 @@ func (m *LDAPManager) DeleteGroup(req *pb.DeleteGroupRequest) error {
 	if m.IsProtectedGroup(req.GetName()) {
 		return &ValidationError{Message: "deleting the default user or admin group is not allowed"}
 	}
-	if err := m.ldap.Del(ldap.NewDelRequest(
+	if err := m.del(ldap.NewDelRequest(
 		m.GroupNamed(req.GetName()),
 		[]ldap.Control{},
 	)); err != nil {
@@ -170,6 +188,12 @@ func (m *LDAPManager) DeleteGroup(req *pb.DeleteGroupRequest) error {
 		}
 		return err
 	}
+	if err := m.deleteJoinRequests(fmt.Sprintf("(ou=%s)", escapeFilter(req.GetName()))); err != nil {
+		log.Warn(err)
+	}
+	if err := m.replaceNestedGroup(m.GroupNamed(req.GetName()), ""); err != nil {
+		log.Warn(err)
+	}
 	log.Infof("removed group %q", req.GetName())
 	return nil
 }
@@ -189,10 +213,16 @@ func (m *LDAPManager) UpdateGroup(req *pb.UpdateGroupRequest) error {
 			NewSuperior:  "",
 		}
 		log.Debugf("UpdateGroup modifyRequest=%v", modifyRequest)
-		if err := m.ldap.ModifyDN(modifyRequest); err != nil {
+		if err := m.modifyDN(modifyRequest); err != nil {
 			return err
 		}
 		log.Infof("renamed group from %q to %q", req.GetName(), req.GetNewName())
+		if err := m.moveJoinRequests(fmt.Sprintf("(ou=%s)", escapeFilter(req.GetName())), "ou", req.GetNewName()); err != nil {
+			log.Warn(err)
+		}
+		if err := m.replaceNestedGroup(m.GroupNamed(req.GetName()), m.GroupNamed(req.GetNewName())); err != nil {
+			log.Warn(err)
+		}
 		groupName = req.GetNewName()
 	}
 
@@ -203,62 +233,36 @@ func (m *LDAPManager) UpdateGroup(req *pb.UpdateGroupRequest) error {
 	if req.GetGid() >= MinGID {
 		modifyGroupRequest.Replace("gidNumber", []string{strconv.Itoa(int(req.GetGid()))})
 	}
-	if err := m.ldap.Modify(modifyGroupRequest); err != nil {
+	if err := m.modify(modifyGroupRequest); err != nil {
 		return fmt.Errorf("failed to modify group %q: %v", groupName, err)
 	}
 	log.Infof("updated %d attributes of group %q", len(modifyGroupRequest.Changes), groupName)
-	return nil
-}
-
-func (m *LDAPManager) countGroups() (int, error) {
-	result, err := m.ldap.Search(ldap.NewSearchRequest(
-		m.GroupsDN,
-		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
-		"(objectClass=posixGroup)",
-		[]string{"cn"},
-		[]ldap.Control{},
-	))
-	if err != nil {
-		return 0, err
-	}
-	return len(result.Entries), nil
+	return m.updateGroupOwners(groupName, req.GetAddOwners(), req.GetRemoveOwners())
 }
 
 // GetGroupList ...
 func (m *LDAPManager) GetGroupList(req *pb.GetGroupListRequest) (*pb.GroupList, error) {
 	filter := parseFilter(req.Filter)
-	result, err := m.ldap.Search(ldap.NewSearchRequest(
+	entries, total, nextPageToken, err := m.listPage(
 		m.GroupsDN,
-		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 		fmt.Sprintf("(&(objectClass=posixGroup)%s)", filter),
-		[]string{},
-		[]ldap.Control{},
-	))
-	if err != nil {
-		return nil, err
-	}
-	total, err := m.countGroups()
+		[]string{"cn"},
+		&pageRequest{
+			start:   req.GetStart(),
+			end:     req.GetEnd(),
+			size:    req.GetPageSize(),
+			token:   req.GetPageToken(),
+			sortKey: "cn",
+			order:   req.GetSortOrder(),
+			filter:  filter,
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
-	groupList := &pb.GroupList{Total: int64(total)}
-	for _, group := range result.Entries {
-		if cn := group.GetAttributeValue("cn"); cn != "" {
-			groupList.Groups = append(groupList.Groups, cn)
-		}
-	}
-	// Sort
-	groups := groupList.GetGroups()
-	sort.Slice(groups, func(i, j int) bool {
-		asc := groups[i] < groups[j]
-		if req.GetSortOrder() == pb.SortOrder_DESCENDING {
-			return !asc
-		}
-		return asc
-	})
-	// Clip
-	if req.GetStart() >= 0 && req.GetEnd() < int32(len(groups)) && req.GetStart() < req.GetEnd() {
-		groupList.Groups = groups[req.GetStart():req.GetEnd()]
+	groupList := &pb.GroupList{Total: int64(total), NextPageToken: nextPageToken}
+	for _, group := range entries {
+		groupList.Groups = append(groupList.Groups, group.GetAttributeValue("cn"))
 	}
 	return groupList, nil
 }
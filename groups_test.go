@@ -51,18 +51,19 @@ func addSampleGroup(manager *LDAPManager, name string, members []string, num int
 }
 
 func assertHasGroups(t *testing.T, manager *LDAPManager, expected []string) {
-	groups, err := manager.GetGroupList(&pb.GetGroupListRequest{})
+	groups, err := manager.GetGroupList(&pb.GetGroupListRequest{SortOrder: pb.SortOrder_ASCENDING})
 	if err != nil {
 		t.Errorf("failed to get groups: %v", err)
 	}
-	// Sort for deterministic order
+	// groups are returned in the requested order
 	expected = append(expected, []string{manager.DefaultUserGroup, manager.DefaultAdminGroup}...)
 	sort.Strings(expected)
-	observed := groups.GetGroups()
-	sort.Strings(observed)
-	if diff := cmp.Diff(expected, observed); diff != "" {
+	if diff := cmp.Diff(expected, groups.GetGroups()); diff != "" {
 		t.Errorf("got unexpected groups: (-want +got):\n%s", diff)
 	}
+	if groups.GetTotal() != int64(len(expected)) {
+		t.Errorf("expected a total of %d groups but got %d", len(expected), groups.GetTotal())
+	}
 }
 
 // TestNewGroup ...
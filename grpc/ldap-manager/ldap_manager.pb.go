@@ -28,6 +28,88 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// Permission is granted to users through their roles
+type Permission int32
+
+const (
+	Permission_AUTHENTICATED        Permission = 0
+	Permission_READ_ACCOUNTS        Permission = 1
+	Permission_MANAGE_ACCOUNTS      Permission = 2
+	Permission_RESET_PASSWORDS      Permission = 3
+	Permission_UNLOCK_ACCOUNTS      Permission = 4
+	Permission_MANAGE_SESSIONS      Permission = 5
+	Permission_READ_GROUPS          Permission = 6
+	Permission_MANAGE_GROUPS        Permission = 7
+	Permission_MANAGE_GROUP_MEMBERS Permission = 8
+	// MANAGE_OWNED_GROUP_MEMBERS is MANAGE_GROUP_MEMBERS limited to groups owned by the user.
+	// Owners of groups hold it through the group-owner role.
+	Permission_MANAGE_OWNED_GROUP_MEMBERS Permission = 9
+	Permission_READ_AUDIT_LOG             Permission = 10
+	Permission_READ_PASSWORD_POLICIES     Permission = 11
+	Permission_MANAGE_PASSWORD_POLICIES   Permission = 12
+)
+
+// Enum value maps for Permission.
+var (
+	Permission_name = map[int32]string{
+		0:  "AUTHENTICATED",
+		1:  "READ_ACCOUNTS",
+		2:  "MANAGE_ACCOUNTS",
+		3:  "RESET_PASSWORDS",
+		4:  "UNLOCK_ACCOUNTS",
+		5:  "MANAGE_SESSIONS",
+		6:  "READ_GROUPS",
+		7:  "MANAGE_GROUPS",
+		8:  "MANAGE_GROUP_MEMBERS",
+		9:  "MANAGE_OWNED_GROUP_MEMBERS",
+		10: "READ_AUDIT_LOG",
+		11: "READ_PASSWORD_POLICIES",
+		12: "MANAGE_PASSWORD_POLICIES",
+	}
+	Permission_value = map[string]int32{
+		"AUTHENTICATED":              0,
+		"READ_ACCOUNTS":              1,
+		"MANAGE_ACCOUNTS":            2,
+		"RESET_PASSWORDS":            3,
+		"UNLOCK_ACCOUNTS":            4,
+		"MANAGE_SESSIONS":            5,
+		"READ_GROUPS":                6,
+		"MANAGE_GROUPS":              7,
+		"MANAGE_GROUP_MEMBERS":       8,
+		"MANAGE_OWNED_GROUP_MEMBERS": 9,
+		"READ_AUDIT_LOG":             10,
+		"READ_PASSWORD_POLICIES":     11,
+		"MANAGE_PASSWORD_POLICIES":   12,
+	}
+)
+
+func (x Permission) Enum() *Permission {
+	p := new(Permission)
+	*p = x
+	return p
+}
+
+func (x Permission) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Permission) Descriptor() protoreflect.EnumDescriptor {
+	return file_ldap_manager_proto_enumTypes[0].Descriptor()
+}
+
+func (Permission) Type() protoreflect.EnumType {
+	return &file_ldap_manager_proto_enumTypes[0]
+}
+
+func (x Permission) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Permission.Descriptor instead.
+func (Permission) EnumDescriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{0}
+}
+
 type SortOrder int32
 
 const (
@@ -58,11 +140,11 @@ func (x SortOrder) String() string {
 }
 
 func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
-	return file_ldap_manager_proto_enumTypes[0].Descriptor()
+	return file_ldap_manager_proto_enumTypes[1].Descriptor()
 }
 
 func (SortOrder) Type() protoreflect.EnumType {
-	return &file_ldap_manager_proto_enumTypes[0]
+	return &file_ldap_manager_proto_enumTypes[1]
 }
 
 func (x SortOrder) Number() protoreflect.EnumNumber {
@@ -71,24 +153,80 @@ func (x SortOrder) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SortOrder.Descriptor instead.
 func (SortOrder) EnumDescriptor() ([]byte, []int) {
-	return file_ldap_manager_proto_rawDescGZIP(), []int{0}
+	return file_ldap_manager_proto_rawDescGZIP(), []int{1}
+}
+
+type AccountStatus int32
+
+const (
+	AccountStatus_ANY AccountStatus = 0
+	// neither locked nor expired
+	AccountStatus_ACTIVE  AccountStatus = 1
+	AccountStatus_LOCKED  AccountStatus = 2
+	AccountStatus_EXPIRED AccountStatus = 3
+)
+
+// Enum value maps for AccountStatus.
+var (
+	AccountStatus_name = map[int32]string{
+		0: "ANY",
+		1: "ACTIVE",
+		2: "LOCKED",
+		3: "EXPIRED",
+	}
+	AccountStatus_value = map[string]int32{
+		"ANY":     0,
+		"ACTIVE":  1,
+		"LOCKED":  2,
+		"EXPIRED": 3,
+	}
+)
+
+func (x AccountStatus) Enum() *AccountStatus {
+	p := new(AccountStatus)
+	*p = x
+	return p
+}
+
+func (x AccountStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccountStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_ldap_manager_proto_enumTypes[2].Descriptor()
+}
+
+func (AccountStatus) Type() protoreflect.EnumType {
+	return &file_ldap_manager_proto_enumTypes[2]
+}
+
+func (x AccountStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccountStatus.Descriptor instead.
+func (AccountStatus) EnumDescriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{2}
 }
 
 type HashingAlgorithm int32
 
 const (
-	HashingAlgorithm_DEFAULT     HashingAlgorithm = 0
-	HashingAlgorithm_SHA512CRYPT HashingAlgorithm = 1
-	HashingAlgorithm_SHA256CRYPT HashingAlgorithm = 2
-	HashingAlgorithm_BLOWFISH    HashingAlgorithm = 3
-	HashingAlgorithm_EXTDES      HashingAlgorithm = 4
-	HashingAlgorithm_MD5CRYPT    HashingAlgorithm = 5
-	HashingAlgorithm_SMD5        HashingAlgorithm = 6
-	HashingAlgorithm_MD5         HashingAlgorithm = 7
-	HashingAlgorithm_SHA         HashingAlgorithm = 8
-	HashingAlgorithm_SSHA        HashingAlgorithm = 9
-	HashingAlgorithm_CRYPT       HashingAlgorithm = 10
-	HashingAlgorithm_CLEAR       HashingAlgorithm = 11
+	HashingAlgorithm_DEFAULT       HashingAlgorithm = 0
+	HashingAlgorithm_SHA512CRYPT   HashingAlgorithm = 1
+	HashingAlgorithm_SHA256CRYPT   HashingAlgorithm = 2
+	HashingAlgorithm_BLOWFISH      HashingAlgorithm = 3
+	HashingAlgorithm_EXTDES        HashingAlgorithm = 4
+	HashingAlgorithm_MD5CRYPT      HashingAlgorithm = 5
+	HashingAlgorithm_SMD5          HashingAlgorithm = 6
+	HashingAlgorithm_MD5           HashingAlgorithm = 7
+	HashingAlgorithm_SHA           HashingAlgorithm = 8
+	HashingAlgorithm_SSHA          HashingAlgorithm = 9
+	HashingAlgorithm_CRYPT         HashingAlgorithm = 10
+	HashingAlgorithm_CLEAR         HashingAlgorithm = 11
+	HashingAlgorithm_ARGON2ID      HashingAlgorithm = 12
+	HashingAlgorithm_PBKDF2_SHA256 HashingAlgorithm = 13
+	HashingAlgorithm_PBKDF2_SHA512 HashingAlgorithm = 14
 )
 
 // Enum value maps for HashingAlgorithm.
@@ -106,20 +244,26 @@ var (
 		9:  "SSHA",
 		10: "CRYPT",
 		11: "CLEAR",
+		12: "ARGON2ID",
+		13: "PBKDF2_SHA256",
+		14: "PBKDF2_SHA512",
 	}
 	HashingAlgorithm_value = map[string]int32{
-		"DEFAULT":     0,
-		"SHA512CRYPT": 1,
-		"SHA256CRYPT": 2,
-		"BLOWFISH":    3,
-		"EXTDES":      4,
-		"MD5CRYPT":    5,
-		"SMD5":        6,
-		"MD5":         7,
-		"SHA":         8,
-		"SSHA":        9,
-		"CRYPT":       10,
-		"CLEAR":       11,
+		"DEFAULT":       0,
+		"SHA512CRYPT":   1,
+		"SHA256CRYPT":   2,
+		"BLOWFISH":      3,
+		"EXTDES":        4,
+		"MD5CRYPT":      5,
+		"SMD5":          6,
+		"MD5":           7,
+		"SHA":           8,
+		"SSHA":          9,
+		"CRYPT":         10,
+		"CLEAR":         11,
+		"ARGON2ID":      12,
+		"PBKDF2_SHA256": 13,
+		"PBKDF2_SHA512": 14,
 	}
 )
 
@@ -134,11 +278,11 @@ func (x HashingAlgorithm) String() string {
 }
 
 func (HashingAlgorithm) Descriptor() protoreflect.EnumDescriptor {
-	return file_ldap_manager_proto_enumTypes[1].Descriptor()
+	return file_ldap_manager_proto_enumTypes[3].Descriptor()
 }
 
 func (HashingAlgorithm) Type() protoreflect.EnumType {
-	return &file_ldap_manager_proto_enumTypes[1]
+	return &file_ldap_manager_proto_enumTypes[3]
 }
 
 func (x HashingAlgorithm) Number() protoreflect.EnumNumber {
@@ -147,7 +291,7 @@ func (x HashingAlgorithm) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use HashingAlgorithm.Descriptor instead.
 func (HashingAlgorithm) EnumDescriptor() ([]byte, []int) {
-	return file_ldap_manager_proto_rawDescGZIP(), []int{1}
+	return file_ldap_manager_proto_rawDescGZIP(), []int{3}
 }
 
 type Empty struct {
@@ -193,11 +337,16 @@ type GetUserListRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Start     int32     `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
-	End       int32     `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   int32 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	// entries are returned in the requested order (ASCENDING used to return descending order)
 	SortOrder SortOrder `protobuf:"varint,3,opt,name=sort_order,json=sortOrder,proto3,enum=ldapmanager.SortOrder" json:"sort_order,omitempty"`
 	SortKey   string    `protobuf:"bytes,4,opt,name=sort_key,json=sortKey,proto3" json:"sort_key,omitempty"`
-	Filter    []string  `protobuf:"bytes,10,rep,name=filter,proto3" json:"filter,omitempty"`
+	// cursor based paging, takes precedence over start and end
+	PageSize  int32         `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string        `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter    []string      `protobuf:"bytes,10,rep,name=filter,proto3" json:"filter,omitempty"`
+	Status    AccountStatus `protobuf:"varint,11,opt,name=status,proto3,enum=ldapmanager.AccountStatus" json:"status,omitempty"`
 }
 
 func (x *GetUserListRequest) Reset() {
@@ -260,6 +409,20 @@ func (x *GetUserListRequest) GetSortKey() string {
 	return ""
 }
 
+func (x *GetUserListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetUserListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 func (x *GetUserListRequest) GetFilter() []string {
 	if x != nil {
 		return x.Filter
@@ -267,6 +430,13 @@ func (x *GetUserListRequest) GetFilter() []string {
 	return nil
 }
 
+func (x *GetUserListRequest) GetStatus() AccountStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AccountStatus_ANY
+}
+
 type User struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -319,8 +489,10 @@ type UserList struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Users []*User `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	Total int64   `protobuf:"varint,10,opt,name=total,proto3" json:"total,omitempty"`
+	Users         []*User `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string  `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// number of accounts matching the filter and status (used to be the number of all accounts)
+	Total int64 `protobuf:"varint,10,opt,name=total,proto3" json:"total,omitempty"`
 }
 
 func (x *UserList) Reset() {
@@ -362,6 +534,13 @@ func (x *UserList) GetUsers() []*User {
 	return nil
 }
 
+func (x *UserList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 func (x *UserList) GetTotal() int64 {
 	if x != nil {
 		return x.Total
@@ -482,9 +661,14 @@ type Account struct {
 	Gid           int32  `protobuf:"varint,11,opt,name=gid,proto3" json:"gid,omitempty"`
 	LoginShell    string `protobuf:"bytes,12,opt,name=login_shell,json=loginShell,proto3" json:"login_shell,omitempty"`
 	HomeDirectory string `protobuf:"bytes,13,opt,name=home_directory,json=homeDirectory,proto3" json:"home_directory,omitempty"`
-	Username      string `protobuf:"bytes,20,opt,name=username,proto3" json:"username,omitempty"`
-	Email         string `protobuf:"bytes,21,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string `protobuf:"bytes,22,opt,name=password,proto3" json:"password,omitempty"`
+	// allocate the uid from a named pool instead of the default range
+	IdPool string `protobuf:"bytes,14,opt,name=id_pool,json=idPool,proto3" json:"id_pool,omitempty"`
+	// date (YYYY-MM-DD) from which on the account can no longer be used,
+	// "never" removes the expiry date of an existing account
+	Expires  string `protobuf:"bytes,15,opt,name=expires,proto3" json:"expires,omitempty"`
+	Username string `protobuf:"bytes,20,opt,name=username,proto3" json:"username,omitempty"`
+	Email    string `protobuf:"bytes,21,opt,name=email,proto3" json:"email,omitempty"`
+	Password string `protobuf:"bytes,22,opt,name=password,proto3" json:"password,omitempty"`
 }
 
 func (x *Account) Reset() {
@@ -561,6 +745,20 @@ func (x *Account) GetHomeDirectory() string {
 	return ""
 }
 
+func (x *Account) GetIdPool() string {
+	if x != nil {
+		return x.IdPool
+	}
+	return ""
+}
+
+func (x *Account) GetExpires() string {
+	if x != nil {
+		return x.Expires
+	}
+	return ""
+}
+
 func (x *Account) GetUsername() string {
 	if x != nil {
 		return x.Username
@@ -629,17 +827,18 @@ func (x *NewAccountRequest) GetAccount() *Account {
 	return nil
 }
 
-type UpdateAccountRequest struct {
+type BulkAccount struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Username string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Update   *Account `protobuf:"bytes,10,opt,name=update,proto3" json:"update,omitempty"`
+	// the password "generate" creates a random password
+	Account *Account `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Groups  []string `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
 }
 
-func (x *UpdateAccountRequest) Reset() {
-	*x = UpdateAccountRequest{}
+func (x *BulkAccount) Reset() {
+	*x = BulkAccount{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ldap_manager_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -647,13 +846,13 @@ func (x *UpdateAccountRequest) Reset() {
 	}
 }
 
-func (x *UpdateAccountRequest) String() string {
+func (x *BulkAccount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAccountRequest) ProtoMessage() {}
+func (*BulkAccount) ProtoMessage() {}
 
-func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
+func (x *BulkAccount) ProtoReflect() protoreflect.Message {
 	mi := &file_ldap_manager_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -665,35 +864,35 @@ func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAccountRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAccountRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkAccount.ProtoReflect.Descriptor instead.
+func (*BulkAccount) Descriptor() ([]byte, []int) {
 	return file_ldap_manager_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UpdateAccountRequest) GetUsername() string {
+func (x *BulkAccount) GetAccount() *Account {
 	if x != nil {
-		return x.Username
+		return x.Account
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateAccountRequest) GetUpdate() *Account {
+func (x *BulkAccount) GetGroups() []string {
 	if x != nil {
-		return x.Update
+		return x.Groups
 	}
 	return nil
 }
 
-type DeleteAccountRequest struct {
+type BulkNewAccountsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Accounts []*BulkAccount `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
 }
 
-func (x *DeleteAccountRequest) Reset() {
-	*x = DeleteAccountRequest{}
+func (x *BulkNewAccountsRequest) Reset() {
+	*x = BulkNewAccountsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ldap_manager_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -701,13 +900,13 @@ func (x *DeleteAccountRequest) Reset() {
 	}
 }
 
-func (x *DeleteAccountRequest) String() string {
+func (x *BulkNewAccountsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAccountRequest) ProtoMessage() {}
+func (*BulkNewAccountsRequest) ProtoMessage() {}
 
-func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+func (x *BulkNewAccountsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ldap_manager_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -719,29 +918,32 @@ func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkNewAccountsRequest.ProtoReflect.Descriptor instead.
+func (*BulkNewAccountsRequest) Descriptor() ([]byte, []int) {
 	return file_ldap_manager_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *DeleteAccountRequest) GetUsername() string {
+func (x *BulkNewAccountsRequest) GetAccounts() []*BulkAccount {
 	if x != nil {
-		return x.Username
+		return x.Accounts
 	}
-	return ""
+	return nil
 }
 
-type NewGroupRequest struct {
+type BulkAccountResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Members []string `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	Row               int32  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Username          string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Success           bool   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Error             string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	GeneratedPassword string `protobuf:"bytes,5,opt,name=generated_password,json=generatedPassword,proto3" json:"generated_password,omitempty"`
 }
 
-func (x *NewGroupRequest) Reset() {
-	*x = NewGroupRequest{}
+func (x *BulkAccountResult) Reset() {
+	*x = BulkAccountResult{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ldap_manager_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -749,13 +951,13 @@ func (x *NewGroupRequest) Reset() {
 	}
 }
 
-func (x *NewGroupRequest) String() string {
+func (x *BulkAccountResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NewGroupRequest) ProtoMessage() {}
+func (*BulkAccountResult) ProtoMessage() {}
 
-func (x *NewGroupRequest) ProtoReflect() protoreflect.Message {
+func (x *BulkAccountResult) ProtoReflect() protoreflect.Message {
 	mi := &file_ldap_manager_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -767,35 +969,57 @@ func (x *NewGroupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NewGroupRequest.ProtoReflect.Descriptor instead.
-func (*NewGroupRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkAccountResult.ProtoReflect.Descriptor instead.
+func (*BulkAccountResult) Descriptor() ([]byte, []int) {
 	return file_ldap_manager_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *NewGroupRequest) GetName() string {
+func (x *BulkAccountResult) GetRow() int32 {
 	if x != nil {
-		return x.Name
+		return x.Row
+	}
+	return 0
+}
+
+func (x *BulkAccountResult) GetUsername() string {
+	if x != nil {
+		return x.Username
 	}
 	return ""
 }
 
-func (x *NewGroupRequest) GetMembers() []string {
+func (x *BulkAccountResult) GetSuccess() bool {
 	if x != nil {
-		return x.Members
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-type DeleteGroupRequest struct {
+func (x *BulkAccountResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BulkAccountResult) GetGeneratedPassword() string {
+	if x != nil {
+		return x.GeneratedPassword
+	}
+	return ""
+}
+
+type BulkNewAccountsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Results []*BulkAccountResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created int32                `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
 }
 
-func (x *DeleteGroupRequest) Reset() {
-	*x = DeleteGroupRequest{}
+func (x *BulkNewAccountsResponse) Reset() {
+	*x = BulkNewAccountsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ldap_manager_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -803,13 +1027,13 @@ func (x *DeleteGroupRequest) Reset() {
 	}
 }
 
-func (x *DeleteGroupRequest) String() string {
+func (x *BulkNewAccountsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteGroupRequest) ProtoMessage() {}
+func (*BulkNewAccountsResponse) ProtoMessage() {}
 
-func (x *DeleteGroupRequest) ProtoReflect() protoreflect.Message {
+func (x *BulkNewAccountsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_ldap_manager_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -821,30 +1045,36 @@ func (x *DeleteGroupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteGroupRequest.ProtoReflect.Descriptor instead.
-func (*DeleteGroupRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkNewAccountsResponse.ProtoReflect.Descriptor instead.
+func (*BulkNewAccountsResponse) Descriptor() ([]byte, []int) {
 	return file_ldap_manager_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *DeleteGroupRequest) GetName() string {
+func (x *BulkNewAccountsResponse) GetResults() []*BulkAccountResult {
 	if x != nil {
-		return x.Name
+		return x.Results
 	}
-	return ""
+	return nil
 }
 
-type UpdateGroupRequest struct {
+func (x *BulkNewAccountsResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+type UpdateAccountRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	NewName string `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
-	Gid     int32  `protobuf:"varint,3,opt,name=gid,proto3" json:"gid,omitempty"`
+	Username string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Update   *Account `protobuf:"bytes,10,opt,name=update,proto3" json:"update,omitempty"`
 }
 
-func (x *UpdateGroupRequest) Reset() {
-	*x = UpdateGroupRequest{}
+func (x *UpdateAccountRequest) Reset() {
+	*x = UpdateAccountRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ldap_manager_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -852,13 +1082,13 @@ func (x *UpdateGroupRequest) Reset() {
 	}
 }
 
-func (x *UpdateGroupRequest) String() string {
+func (x *UpdateAccountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateGroupRequest) ProtoMessage() {}
+func (*UpdateAccountRequest) ProtoMessage() {}
 
-func (x *UpdateGroupRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateAccountRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ldap_manager_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -870,46 +1100,35 @@ func (x *UpdateGroupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateGroupRequest.ProtoReflect.Descriptor instead.
-func (*UpdateGroupRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateAccountRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAccountRequest) Descriptor() ([]byte, []int) {
 	return file_ldap_manager_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *UpdateGroupRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *UpdateGroupRequest) GetNewName() string {
+func (x *UpdateAccountRequest) GetUsername() string {
 	if x != nil {
-		return x.NewName
+		return x.Username
 	}
 	return ""
 }
 
-func (x *UpdateGroupRequest) GetGid() int32 {
+func (x *UpdateAccountRequest) GetUpdate() *Account {
 	if x != nil {
-		return x.Gid
+		return x.Update
 	}
-	return 0
+	return nil
 }
 
-type GetGroupListRequest struct {
+type DeleteAccountRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Start     int32     `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
-	End       int32     `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
-	SortOrder SortOrder `protobuf:"varint,3,opt,name=sort_order,json=sortOrder,proto3,enum=ldapmanager.SortOrder" json:"sort_order,omitempty"`
-	SortKey   string    `protobuf:"bytes,4,opt,name=sort_key,json=sortKey,proto3" json:"sort_key,omitempty"`
-	Filter    []string  `protobuf:"bytes,10,rep,name=filter,proto3" json:"filter,omitempty"`
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 }
 
-func (x *GetGroupListRequest) Reset() {
-	*x = GetGroupListRequest{}
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ldap_manager_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -917,13 +1136,13 @@ func (x *GetGroupListRequest) Reset() {
 	}
 }
 
-func (x *GetGroupListRequest) String() string {
+func (x *DeleteAccountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGroupListRequest) ProtoMessage() {}
+func (*DeleteAccountRequest) ProtoMessage() {}
 
-func (x *GetGroupListRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ldap_manager_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -935,57 +1154,28 @@ func (x *GetGroupListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGroupListRequest.ProtoReflect.Descriptor instead.
-func (*GetGroupListRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
 	return file_ldap_manager_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetGroupListRequest) GetStart() int32 {
+func (x *DeleteAccountRequest) GetUsername() string {
 	if x != nil {
-		return x.Start
+		return x.Username
 	}
-	return 0
+	return ""
 }
 
-func (x *GetGroupListRequest) GetEnd() int32 {
-	if x != nil {
-		return x.End
-	}
-	return 0
-}
+type LockAccountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *GetGroupListRequest) GetSortOrder() SortOrder {
-	if x != nil {
-		return x.SortOrder
-	}
-	return SortOrder_ASCENDING
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 }
 
-func (x *GetGroupListRequest) GetSortKey() string {
-	if x != nil {
-		return x.SortKey
-	}
-	return ""
-}
-
-func (x *GetGroupListRequest) GetFilter() []string {
-	if x != nil {
-		return x.Filter
-	}
-	return nil
-}
-
-type GroupList struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Groups []string `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
-	Total  int64    `protobuf:"varint,10,opt,name=total,proto3" json:"total,omitempty"`
-}
-
-func (x *GroupList) Reset() {
-	*x = GroupList{}
+func (x *LockAccountRequest) Reset() {
+	*x = LockAccountRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ldap_manager_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -993,13 +1183,13 @@ func (x *GroupList) Reset() {
 	}
 }
 
-func (x *GroupList) String() string {
+func (x *LockAccountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GroupList) ProtoMessage() {}
+func (*LockAccountRequest) ProtoMessage() {}
 
-func (x *GroupList) ProtoReflect() protoreflect.Message {
+func (x *LockAccountRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ldap_manager_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1011,36 +1201,28 @@ func (x *GroupList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GroupList.ProtoReflect.Descriptor instead.
-func (*GroupList) Descriptor() ([]byte, []int) {
+// Deprecated: Use LockAccountRequest.ProtoReflect.Descriptor instead.
+func (*LockAccountRequest) Descriptor() ([]byte, []int) {
 	return file_ldap_manager_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GroupList) GetGroups() []string {
-	if x != nil {
-		return x.Groups
-	}
-	return nil
-}
-
-func (x *GroupList) GetTotal() int64 {
+func (x *LockAccountRequest) GetUsername() string {
 	if x != nil {
-		return x.Total
+		return x.Username
 	}
-	return 0
+	return ""
 }
 
-type IsGroupMemberRequest struct {
+type UnlockAccountRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Group    string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
 }
 
-func (x *IsGroupMemberRequest) Reset() {
-	*x = IsGroupMemberRequest{}
+func (x *UnlockAccountRequest) Reset() {
+	*x = UnlockAccountRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ldap_manager_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1048,13 +1230,13 @@ func (x *IsGroupMemberRequest) Reset() {
 	}
 }
 
-func (x *IsGroupMemberRequest) String() string {
+func (x *UnlockAccountRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IsGroupMemberRequest) ProtoMessage() {}
+func (*UnlockAccountRequest) ProtoMessage() {}
 
-func (x *IsGroupMemberRequest) ProtoReflect() protoreflect.Message {
+func (x *UnlockAccountRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ldap_manager_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1066,35 +1248,33 @@ func (x *IsGroupMemberRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IsGroupMemberRequest.ProtoReflect.Descriptor instead.
-func (*IsGroupMemberRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UnlockAccountRequest.ProtoReflect.Descriptor instead.
+func (*UnlockAccountRequest) Descriptor() ([]byte, []int) {
 	return file_ldap_manager_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *IsGroupMemberRequest) GetUsername() string {
+func (x *UnlockAccountRequest) GetUsername() string {
 	if x != nil {
 		return x.Username
 	}
 	return ""
 }
 
-func (x *IsGroupMemberRequest) GetGroup() string {
-	if x != nil {
-		return x.Group
-	}
-	return ""
-}
-
-type GroupMemberStatus struct {
+type NewGroupRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IsMember bool `protobuf:"varint,1,opt,name=is_member,json=isMember,proto3" json:"is_member,omitempty"`
+	Name    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Members []string `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	// allocate the gid from a named pool instead of the default range
+	IdPool string `protobuf:"bytes,3,opt,name=id_pool,json=idPool,proto3" json:"id_pool,omitempty"`
+	// owners can manage the members of the group
+	Owners []string `protobuf:"bytes,4,rep,name=owners,proto3" json:"owners,omitempty"`
 }
 
-func (x *GroupMemberStatus) Reset() {
-	*x = GroupMemberStatus{}
+func (x *NewGroupRequest) Reset() {
+	*x = NewGroupRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ldap_manager_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1102,13 +1282,13 @@ func (x *GroupMemberStatus) Reset() {
 	}
 }
 
-func (x *GroupMemberStatus) String() string {
+func (x *NewGroupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GroupMemberStatus) ProtoMessage() {}
+func (*NewGroupRequest) ProtoMessage() {}
 
-func (x *GroupMemberStatus) ProtoReflect() protoreflect.Message {
+func (x *NewGroupRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ldap_manager_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1120,122 +1300,64 @@ func (x *GroupMemberStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GroupMemberStatus.ProtoReflect.Descriptor instead.
-func (*GroupMemberStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use NewGroupRequest.ProtoReflect.Descriptor instead.
+func (*NewGroupRequest) Descriptor() ([]byte, []int) {
 	return file_ldap_manager_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *GroupMemberStatus) GetIsMember() bool {
-	if x != nil {
-		return x.IsMember
-	}
-	return false
-}
-
-type GetGroupRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Start     int32     `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
-	End       int32     `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
-	SortOrder SortOrder `protobuf:"varint,3,opt,name=sort_order,json=sortOrder,proto3,enum=ldapmanager.SortOrder" json:"sort_order,omitempty"`
-	SortKey   string    `protobuf:"bytes,4,opt,name=sort_key,json=sortKey,proto3" json:"sort_key,omitempty"`
-	Name      string    `protobuf:"bytes,10,opt,name=name,proto3" json:"name,omitempty"`
-}
-
-func (x *GetGroupRequest) Reset() {
-	*x = GetGroupRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_ldap_manager_proto_msgTypes[17]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *GetGroupRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetGroupRequest) ProtoMessage() {}
-
-func (x *GetGroupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ldap_manager_proto_msgTypes[17]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetGroupRequest.ProtoReflect.Descriptor instead.
-func (*GetGroupRequest) Descriptor() ([]byte, []int) {
-	return file_ldap_manager_proto_rawDescGZIP(), []int{17}
-}
-
-func (x *GetGroupRequest) GetStart() int32 {
-	if x != nil {
-		return x.Start
-	}
-	return 0
-}
-
-func (x *GetGroupRequest) GetEnd() int32 {
+func (x *NewGroupRequest) GetName() string {
 	if x != nil {
-		return x.End
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *GetGroupRequest) GetSortOrder() SortOrder {
+func (x *NewGroupRequest) GetMembers() []string {
 	if x != nil {
-		return x.SortOrder
+		return x.Members
 	}
-	return SortOrder_ASCENDING
+	return nil
 }
 
-func (x *GetGroupRequest) GetSortKey() string {
+func (x *NewGroupRequest) GetIdPool() string {
 	if x != nil {
-		return x.SortKey
+		return x.IdPool
 	}
 	return ""
 }
 
-func (x *GetGroupRequest) GetName() string {
+func (x *NewGroupRequest) GetOwners() []string {
 	if x != nil {
-		return x.Name
+		return x.Owners
 	}
-	return ""
+	return nil
 }
 
-type GetUserGroupsRequest struct {
+type DeleteGroupRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *GetUserGroupsRequest) Reset() {
-	*x = GetUserGroupsRequest{}
+func (x *DeleteGroupRequest) Reset() {
+	*x = DeleteGroupRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ldap_manager_proto_msgTypes[18]
+		mi := &file_ldap_manager_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetUserGroupsRequest) String() string {
+func (x *DeleteGroupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserGroupsRequest) ProtoMessage() {}
+func (*DeleteGroupRequest) ProtoMessage() {}
 
-func (x *GetUserGroupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ldap_manager_proto_msgTypes[18]
+func (x *DeleteGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1246,46 +1368,47 @@ func (x *GetUserGroupsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserGroupsRequest.ProtoReflect.Descriptor instead.
-func (*GetUserGroupsRequest) Descriptor() ([]byte, []int) {
-	return file_ldap_manager_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use DeleteGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGroupRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetUserGroupsRequest) GetUsername() string {
+func (x *DeleteGroupRequest) GetName() string {
 	if x != nil {
-		return x.Username
+		return x.Name
 	}
 	return ""
 }
 
-type Group struct {
+type UpdateGroupRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Members []string `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
-	Gid     int32    `protobuf:"varint,3,opt,name=gid,proto3" json:"gid,omitempty"`
-	Total   int64    `protobuf:"varint,10,opt,name=total,proto3" json:"total,omitempty"`
+	Name         string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NewName      string   `protobuf:"bytes,2,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	Gid          int32    `protobuf:"varint,3,opt,name=gid,proto3" json:"gid,omitempty"`
+	AddOwners    []string `protobuf:"bytes,4,rep,name=add_owners,json=addOwners,proto3" json:"add_owners,omitempty"`
+	RemoveOwners []string `protobuf:"bytes,5,rep,name=remove_owners,json=removeOwners,proto3" json:"remove_owners,omitempty"`
 }
 
-func (x *Group) Reset() {
-	*x = Group{}
+func (x *UpdateGroupRequest) Reset() {
+	*x = UpdateGroupRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ldap_manager_proto_msgTypes[19]
+		mi := &file_ldap_manager_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Group) String() string {
+func (x *UpdateGroupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Group) ProtoMessage() {}
+func (*UpdateGroupRequest) ProtoMessage() {}
 
-func (x *Group) ProtoReflect() protoreflect.Message {
-	mi := &file_ldap_manager_proto_msgTypes[19]
+func (x *UpdateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1296,65 +1419,79 @@ func (x *Group) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Group.ProtoReflect.Descriptor instead.
-func (*Group) Descriptor() ([]byte, []int) {
-	return file_ldap_manager_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use UpdateGroupRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *Group) GetName() string {
+func (x *UpdateGroupRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Group) GetMembers() []string {
+func (x *UpdateGroupRequest) GetNewName() string {
 	if x != nil {
-		return x.Members
+		return x.NewName
 	}
-	return nil
+	return ""
 }
 
-func (x *Group) GetGid() int32 {
+func (x *UpdateGroupRequest) GetGid() int32 {
 	if x != nil {
 		return x.Gid
 	}
 	return 0
 }
 
-func (x *Group) GetTotal() int64 {
+func (x *UpdateGroupRequest) GetAddOwners() []string {
 	if x != nil {
-		return x.Total
+		return x.AddOwners
 	}
-	return 0
+	return nil
 }
 
-type GroupMember struct {
+func (x *UpdateGroupRequest) GetRemoveOwners() []string {
+	if x != nil {
+		return x.RemoveOwners
+	}
+	return nil
+}
+
+type GetGroupListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Group    string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
-	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   int32 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	// entries are returned in the requested order (ASCENDING used to return descending order)
+	SortOrder SortOrder `protobuf:"varint,3,opt,name=sort_order,json=sortOrder,proto3,enum=ldapmanager.SortOrder" json:"sort_order,omitempty"`
+	SortKey   string    `protobuf:"bytes,4,opt,name=sort_key,json=sortKey,proto3" json:"sort_key,omitempty"`
+	// cursor based paging, takes precedence over start and end
+	PageSize  int32    `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string   `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter    []string `protobuf:"bytes,10,rep,name=filter,proto3" json:"filter,omitempty"`
 }
 
-func (x *GroupMember) Reset() {
-	*x = GroupMember{}
+func (x *GetGroupListRequest) Reset() {
+	*x = GetGroupListRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ldap_manager_proto_msgTypes[20]
+		mi := &file_ldap_manager_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GroupMember) String() string {
+func (x *GetGroupListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GroupMember) ProtoMessage() {}
+func (*GetGroupListRequest) ProtoMessage() {}
 
-func (x *GroupMember) ProtoReflect() protoreflect.Message {
-	mi := &file_ldap_manager_proto_msgTypes[20]
+func (x *GetGroupListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1365,37 +1502,137 @@ func (x *GroupMember) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GroupMember.ProtoReflect.Descriptor instead.
-func (*GroupMember) Descriptor() ([]byte, []int) {
-	return file_ldap_manager_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetGroupListRequest.ProtoReflect.Descriptor instead.
+func (*GetGroupListRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GroupMember) GetGroup() string {
+func (x *GetGroupListRequest) GetStart() int32 {
 	if x != nil {
-		return x.Group
+		return x.Start
 	}
-	return ""
+	return 0
 }
 
-func (x *GroupMember) GetUsername() string {
+func (x *GetGroupListRequest) GetEnd() int32 {
 	if x != nil {
-		return x.Username
+		return x.End
+	}
+	return 0
+}
+
+func (x *GetGroupListRequest) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_ASCENDING
+}
+
+func (x *GetGroupListRequest) GetSortKey() string {
+	if x != nil {
+		return x.SortKey
 	}
 	return ""
 }
 
-type ChangePasswordRequest struct {
+func (x *GetGroupListRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetGroupListRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetGroupListRequest) GetFilter() []string {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type GroupList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Username         string           `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password         string           `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	HashingAlgorithm HashingAlgorithm `protobuf:"varint,100,opt,name=hashing_algorithm,json=hashingAlgorithm,proto3,enum=ldapmanager.HashingAlgorithm" json:"hashing_algorithm,omitempty"`
+	Groups        []string `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	NextPageToken string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// number of groups matching the filter (used to be the number of all groups)
+	Total int64 `protobuf:"varint,10,opt,name=total,proto3" json:"total,omitempty"`
 }
 
-func (x *ChangePasswordRequest) Reset() {
-	*x = ChangePasswordRequest{}
+func (x *GroupList) Reset() {
+	*x = GroupList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupList) ProtoMessage() {}
+
+func (x *GroupList) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupList.ProtoReflect.Descriptor instead.
+func (*GroupList) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GroupList) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *GroupList) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GroupList) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type IsGroupMemberRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Group    string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	// also check the membership through nested groups
+	Effective bool `protobuf:"varint,3,opt,name=effective,proto3" json:"effective,omitempty"`
+}
+
+func (x *IsGroupMemberRequest) Reset() {
+	*x = IsGroupMemberRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ldap_manager_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1403,13 +1640,13 @@ func (x *ChangePasswordRequest) Reset() {
 	}
 }
 
-func (x *ChangePasswordRequest) String() string {
+func (x *IsGroupMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangePasswordRequest) ProtoMessage() {}
+func (*IsGroupMemberRequest) ProtoMessage() {}
 
-func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+func (x *IsGroupMemberRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ldap_manager_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1421,58 +1658,2087 @@ func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
-func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use IsGroupMemberRequest.ProtoReflect.Descriptor instead.
+func (*IsGroupMemberRequest) Descriptor() ([]byte, []int) {
 	return file_ldap_manager_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ChangePasswordRequest) GetUsername() string {
+func (x *IsGroupMemberRequest) GetUsername() string {
 	if x != nil {
 		return x.Username
 	}
 	return ""
 }
 
-func (x *ChangePasswordRequest) GetPassword() string {
+func (x *IsGroupMemberRequest) GetGroup() string {
 	if x != nil {
-		return x.Password
+		return x.Group
+	}
+	return ""
+}
+
+func (x *IsGroupMemberRequest) GetEffective() bool {
+	if x != nil {
+		return x.Effective
+	}
+	return false
+}
+
+type GroupMemberStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsMember bool `protobuf:"varint,1,opt,name=is_member,json=isMember,proto3" json:"is_member,omitempty"`
+}
+
+func (x *GroupMemberStatus) Reset() {
+	*x = GroupMemberStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupMemberStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMemberStatus) ProtoMessage() {}
+
+func (x *GroupMemberStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMemberStatus.ProtoReflect.Descriptor instead.
+func (*GroupMemberStatus) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GroupMemberStatus) GetIsMember() bool {
+	if x != nil {
+		return x.IsMember
+	}
+	return false
+}
+
+type GetGroupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start     int32     `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End       int32     `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	SortOrder SortOrder `protobuf:"varint,3,opt,name=sort_order,json=sortOrder,proto3,enum=ldapmanager.SortOrder" json:"sort_order,omitempty"`
+	SortKey   string    `protobuf:"bytes,4,opt,name=sort_key,json=sortKey,proto3" json:"sort_key,omitempty"`
+	// list the members of nested groups as members
+	Effective bool   `protobuf:"varint,5,opt,name=effective,proto3" json:"effective,omitempty"`
+	Name      string `protobuf:"bytes,10,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetGroupRequest) Reset() {
+	*x = GetGroupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGroupRequest) ProtoMessage() {}
+
+func (x *GetGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGroupRequest.ProtoReflect.Descriptor instead.
+func (*GetGroupRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetGroupRequest) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *GetGroupRequest) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *GetGroupRequest) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_ASCENDING
+}
+
+func (x *GetGroupRequest) GetSortKey() string {
+	if x != nil {
+		return x.SortKey
 	}
 	return ""
 }
 
-func (x *ChangePasswordRequest) GetHashingAlgorithm() HashingAlgorithm {
+func (x *GetGroupRequest) GetEffective() bool {
+	if x != nil {
+		return x.Effective
+	}
+	return false
+}
+
+func (x *GetGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetUserGroupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// also list the groups the user is a member of through nested groups
+	Effective bool `protobuf:"varint,2,opt,name=effective,proto3" json:"effective,omitempty"`
+}
+
+func (x *GetUserGroupsRequest) Reset() {
+	*x = GetUserGroupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUserGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserGroupsRequest) ProtoMessage() {}
+
+func (x *GetUserGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserGroupsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetUserGroupsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GetUserGroupsRequest) GetEffective() bool {
+	if x != nil {
+		return x.Effective
+	}
+	return false
+}
+
+type Group struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Members []string `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	Gid     int32    `protobuf:"varint,3,opt,name=gid,proto3" json:"gid,omitempty"`
+	Owners  []string `protobuf:"bytes,4,rep,name=owners,proto3" json:"owners,omitempty"`
+	// groups that are direct members of the group
+	MemberGroups []string `protobuf:"bytes,5,rep,name=member_groups,json=memberGroups,proto3" json:"member_groups,omitempty"`
+	Total        int64    `protobuf:"varint,10,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Group) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Group) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Group) GetGid() int32 {
+	if x != nil {
+		return x.Gid
+	}
+	return 0
+}
+
+func (x *Group) GetOwners() []string {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *Group) GetMemberGroups() []string {
+	if x != nil {
+		return x.MemberGroups
+	}
+	return nil
+}
+
+func (x *Group) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetOwnedGroupsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *GetOwnedGroupsRequest) Reset() {
+	*x = GetOwnedGroupsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOwnedGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOwnedGroupsRequest) ProtoMessage() {}
+
+func (x *GetOwnedGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOwnedGroupsRequest.ProtoReflect.Descriptor instead.
+func (*GetOwnedGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetOwnedGroupsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type GroupMember struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group    string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// nested group to add or remove instead of a user, requires the RFC2307BIS schema
+	MemberGroup string `protobuf:"bytes,3,opt,name=member_group,json=memberGroup,proto3" json:"member_group,omitempty"`
+}
+
+func (x *GroupMember) Reset() {
+	*x = GroupMember{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMember) ProtoMessage() {}
+
+func (x *GroupMember) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMember.ProtoReflect.Descriptor instead.
+func (*GroupMember) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GroupMember) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *GroupMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GroupMember) GetMemberGroup() string {
+	if x != nil {
+		return x.MemberGroup
+	}
+	return ""
+}
+
+type RepairGroupMembershipRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only repair this group, all groups are repaired if empty
+	Group string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	// membership attribute that is copied to the others, the members of all attributes are merged if empty
+	Authoritative string `protobuf:"bytes,2,opt,name=authoritative,proto3" json:"authoritative,omitempty"`
+	// only report the changes without applying them
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *RepairGroupMembershipRequest) Reset() {
+	*x = RepairGroupMembershipRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepairGroupMembershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepairGroupMembershipRequest) ProtoMessage() {}
+
+func (x *RepairGroupMembershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepairGroupMembershipRequest.ProtoReflect.Descriptor instead.
+func (*RepairGroupMembershipRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RepairGroupMembershipRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *RepairGroupMembershipRequest) GetAuthoritative() string {
+	if x != nil {
+		return x.Authoritative
+	}
+	return ""
+}
+
+func (x *RepairGroupMembershipRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type GroupMembershipRepair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group     string   `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Attribute string   `protobuf:"bytes,2,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Added     []string `protobuf:"bytes,3,rep,name=added,proto3" json:"added,omitempty"`
+	Removed   []string `protobuf:"bytes,4,rep,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (x *GroupMembershipRepair) Reset() {
+	*x = GroupMembershipRepair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupMembershipRepair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMembershipRepair) ProtoMessage() {}
+
+func (x *GroupMembershipRepair) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMembershipRepair.ProtoReflect.Descriptor instead.
+func (*GroupMembershipRepair) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GroupMembershipRepair) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *GroupMembershipRepair) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+func (x *GroupMembershipRepair) GetAdded() []string {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *GroupMembershipRepair) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+type GroupMembershipRepairs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Repairs []*GroupMembershipRepair `protobuf:"bytes,1,rep,name=repairs,proto3" json:"repairs,omitempty"`
+}
+
+func (x *GroupMembershipRepairs) Reset() {
+	*x = GroupMembershipRepairs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupMembershipRepairs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMembershipRepairs) ProtoMessage() {}
+
+func (x *GroupMembershipRepairs) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMembershipRepairs.ProtoReflect.Descriptor instead.
+func (*GroupMembershipRepairs) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GroupMembershipRepairs) GetRepairs() []*GroupMembershipRepair {
+	if x != nil {
+		return x.Repairs
+	}
+	return nil
+}
+
+type JoinRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Group    string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+	Username string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	// unix time after which the request is discarded
+	Expires int64 `protobuf:"varint,4,opt,name=expires,proto3" json:"expires,omitempty"`
+}
+
+func (x *JoinRequest) Reset() {
+	*x = JoinRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRequest) ProtoMessage() {}
+
+func (x *JoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *JoinRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetExpires() int64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
+type JoinRequestList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Requests []*JoinRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	Total    int64          `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *JoinRequestList) Reset() {
+	*x = JoinRequestList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinRequestList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRequestList) ProtoMessage() {}
+
+func (x *JoinRequestList) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRequestList.ProtoReflect.Descriptor instead.
+func (*JoinRequestList) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *JoinRequestList) GetRequests() []*JoinRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *JoinRequestList) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ListPendingRequestsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only list requests to join this group
+	Group string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	// only list requests of this user
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *ListPendingRequestsRequest) Reset() {
+	*x = ListPendingRequestsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPendingRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingRequestsRequest) ProtoMessage() {}
+
+func (x *ListPendingRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListPendingRequestsRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ListPendingRequestsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type JoinRequestDecision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Group string `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"`
+}
+
+func (x *JoinRequestDecision) Reset() {
+	*x = JoinRequestDecision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinRequestDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRequestDecision) ProtoMessage() {}
+
+func (x *JoinRequestDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRequestDecision.ProtoReflect.Descriptor instead.
+func (*JoinRequestDecision) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *JoinRequestDecision) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JoinRequestDecision) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+type ChangePasswordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username         string           `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password         string           `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	HashingAlgorithm HashingAlgorithm `protobuf:"varint,100,opt,name=hashing_algorithm,json=hashingAlgorithm,proto3,enum=ldapmanager.HashingAlgorithm" json:"hashing_algorithm,omitempty"`
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ChangePasswordRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetHashingAlgorithm() HashingAlgorithm {
+	if x != nil {
+		return x.HashingAlgorithm
+	}
+	return HashingAlgorithm_DEFAULT
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LoginRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type Token struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token       string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Username    string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	DisplayName string `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	IsAdmin     bool   `protobuf:"varint,4,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	// otp_required is set if the password was accepted but a one-time password is required to complete the login
+	OtpRequired  bool   `protobuf:"varint,5,opt,name=otp_required,json=otpRequired,proto3" json:"otp_required,omitempty"`
+	OtpChallenge string `protobuf:"bytes,6,opt,name=otp_challenge,json=otpChallenge,proto3" json:"otp_challenge,omitempty"`
+	// otp_enrollment_required is set for admins that must enroll two-factor authentication before getting admin privileges
+	OtpEnrollmentRequired bool `protobuf:"varint,7,opt,name=otp_enrollment_required,json=otpEnrollmentRequired,proto3" json:"otp_enrollment_required,omitempty"`
+	// password_expires_in is the number of seconds until the password expires if the password policy warns about it
+	PasswordExpiresIn int64 `protobuf:"varint,8,opt,name=password_expires_in,json=passwordExpiresIn,proto3" json:"password_expires_in,omitempty"`
+	// password_expired is set if the login used one of the remaining grace logins of an expired password
+	PasswordExpired      bool  `protobuf:"varint,9,opt,name=password_expired,json=passwordExpired,proto3" json:"password_expired,omitempty"`
+	Expiration           int64 `protobuf:"varint,10,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	GraceLoginsRemaining int32 `protobuf:"varint,11,opt,name=grace_logins_remaining,json=graceLoginsRemaining,proto3" json:"grace_logins_remaining,omitempty"`
+	// password_must_change is set if the password was reset and must be changed before the account can be used
+	PasswordMustChange bool         `protobuf:"varint,12,opt,name=password_must_change,json=passwordMustChange,proto3" json:"password_must_change,omitempty"`
+	Roles              []string     `protobuf:"bytes,15,rep,name=roles,proto3" json:"roles,omitempty"`
+	Permissions        []Permission `protobuf:"varint,16,rep,packed,name=permissions,proto3,enum=ldapmanager.Permission" json:"permissions,omitempty"`
+	// refresh_token issues a new token using RefreshToken until the session is revoked or expires
+	RefreshToken      string `protobuf:"bytes,13,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	RefreshExpiration int64  `protobuf:"varint,14,opt,name=refresh_expiration,json=refreshExpiration,proto3" json:"refresh_expiration,omitempty"`
+}
+
+func (x *Token) Reset() {
+	*x = Token{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Token) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Token) ProtoMessage() {}
+
+func (x *Token) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Token.ProtoReflect.Descriptor instead.
+func (*Token) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Token) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Token) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Token) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Token) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+func (x *Token) GetOtpRequired() bool {
+	if x != nil {
+		return x.OtpRequired
+	}
+	return false
+}
+
+func (x *Token) GetOtpChallenge() string {
+	if x != nil {
+		return x.OtpChallenge
+	}
+	return ""
+}
+
+func (x *Token) GetOtpEnrollmentRequired() bool {
+	if x != nil {
+		return x.OtpEnrollmentRequired
+	}
+	return false
+}
+
+func (x *Token) GetPasswordExpiresIn() int64 {
+	if x != nil {
+		return x.PasswordExpiresIn
+	}
+	return 0
+}
+
+func (x *Token) GetPasswordExpired() bool {
+	if x != nil {
+		return x.PasswordExpired
+	}
+	return false
+}
+
+func (x *Token) GetExpiration() int64 {
+	if x != nil {
+		return x.Expiration
+	}
+	return 0
+}
+
+func (x *Token) GetGraceLoginsRemaining() int32 {
+	if x != nil {
+		return x.GraceLoginsRemaining
+	}
+	return 0
+}
+
+func (x *Token) GetPasswordMustChange() bool {
+	if x != nil {
+		return x.PasswordMustChange
+	}
+	return false
+}
+
+func (x *Token) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *Token) GetPermissions() []Permission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *Token) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *Token) GetRefreshExpiration() int64 {
+	if x != nil {
+		return x.RefreshExpiration
+	}
+	return 0
+}
+
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RefreshToken string `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RevokeSessionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *RevokeSessionsRequest) Reset() {
+	*x = RevokeSessionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RevokeSessionsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type LoginOTPRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Challenge string `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	Code      string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *LoginOTPRequest) Reset() {
+	*x = LoginOTPRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoginOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginOTPRequest) ProtoMessage() {}
+
+func (x *LoginOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginOTPRequest.ProtoReflect.Descriptor instead.
+func (*LoginOTPRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *LoginOTPRequest) GetChallenge() string {
+	if x != nil {
+		return x.Challenge
+	}
+	return ""
+}
+
+func (x *LoginOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type EnrollTOTPRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EnrollTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPRequest) ProtoMessage() {}
+
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *EnrollTOTPRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type TOTPEnrollment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Secret        string   `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	Uri           string   `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	RecoveryCodes []string `protobuf:"bytes,3,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+}
+
+func (x *TOTPEnrollment) Reset() {
+	*x = TOTPEnrollment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TOTPEnrollment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TOTPEnrollment) ProtoMessage() {}
+
+func (x *TOTPEnrollment) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TOTPEnrollment.ProtoReflect.Descriptor instead.
+func (*TOTPEnrollment) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TOTPEnrollment) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *TOTPEnrollment) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *TOTPEnrollment) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type ConfirmTOTPRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Code     string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfirmTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPRequest) ProtoMessage() {}
+
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ConfirmTOTPRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ConfirmTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type DisableTOTPRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Code     string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPRequest) ProtoMessage() {}
+
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DisableTOTPRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *DisableTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type GetAuditLogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Actor     string `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	Operation string `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	// matches records whose target DN contains this value
+	Target string `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	Result string `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	// unix timestamps
+	Since int64 `protobuf:"varint,5,opt,name=since,proto3" json:"since,omitempty"`
+	Until int64 `protobuf:"varint,6,opt,name=until,proto3" json:"until,omitempty"`
+	Limit int32 `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *GetAuditLogRequest) Reset() {
+	*x = GetAuditLogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogRequest) ProtoMessage() {}
+
+func (x *GetAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*GetAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetAuditLogRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *GetAuditLogRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *GetAuditLogRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *GetAuditLogRequest) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *GetAuditLogRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *GetAuditLogRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *GetAuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AuditRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp  int64    `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Actor      string   `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	Operation  string   `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	Target     string   `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	Attributes []string `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	Result     string   `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	Error      string   `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *AuditRecord) Reset() {
+	*x = AuditRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditRecord) ProtoMessage() {}
+
+func (x *AuditRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditRecord.ProtoReflect.Descriptor instead.
+func (*AuditRecord) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AuditRecord) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *AuditRecord) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetAttributes() []string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *AuditRecord) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type AuditLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records []*AuditRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (x *AuditLog) Reset() {
+	*x = AuditLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLog) ProtoMessage() {}
+
+func (x *AuditLog) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLog.ProtoReflect.Descriptor instead.
+func (*AuditLog) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AuditLog) GetRecords() []*AuditRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type PasswordResetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Email    string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+}
+
+func (x *PasswordResetRequest) Reset() {
+	*x = PasswordResetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordResetRequest) ProtoMessage() {}
+
+func (x *PasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*PasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *PasswordResetRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *PasswordResetRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token    string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ResetPasswordRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// PwdPolicy is a password policy entry of the OpenLDAP ppolicy overlay.
+// Durations are in seconds, zero values use the defaults of the overlay.
+type PwdPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MaxAge    int64  `protobuf:"varint,2,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	MinAge    int64  `protobuf:"varint,3,opt,name=min_age,json=minAge,proto3" json:"min_age,omitempty"`
+	InHistory int32  `protobuf:"varint,4,opt,name=in_history,json=inHistory,proto3" json:"in_history,omitempty"`
+	// 0 disables quality checks, 1 checks if possible and 2 always checks
+	CheckQuality         int32 `protobuf:"varint,5,opt,name=check_quality,json=checkQuality,proto3" json:"check_quality,omitempty"`
+	MinLength            int32 `protobuf:"varint,6,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"`
+	ExpireWarning        int64 `protobuf:"varint,7,opt,name=expire_warning,json=expireWarning,proto3" json:"expire_warning,omitempty"`
+	GraceAuthnLimit      int32 `protobuf:"varint,8,opt,name=grace_authn_limit,json=graceAuthnLimit,proto3" json:"grace_authn_limit,omitempty"`
+	Lockout              bool  `protobuf:"varint,9,opt,name=lockout,proto3" json:"lockout,omitempty"`
+	LockoutDuration      int64 `protobuf:"varint,10,opt,name=lockout_duration,json=lockoutDuration,proto3" json:"lockout_duration,omitempty"`
+	MaxFailure           int32 `protobuf:"varint,11,opt,name=max_failure,json=maxFailure,proto3" json:"max_failure,omitempty"`
+	FailureCountInterval int64 `protobuf:"varint,12,opt,name=failure_count_interval,json=failureCountInterval,proto3" json:"failure_count_interval,omitempty"`
+	MustChange           bool  `protobuf:"varint,13,opt,name=must_change,json=mustChange,proto3" json:"must_change,omitempty"`
+	// deny_user_change prevents users from changing their own password
+	DenyUserChange bool `protobuf:"varint,14,opt,name=deny_user_change,json=denyUserChange,proto3" json:"deny_user_change,omitempty"`
+	SafeModify     bool `protobuf:"varint,15,opt,name=safe_modify,json=safeModify,proto3" json:"safe_modify,omitempty"`
+}
+
+func (x *PwdPolicy) Reset() {
+	*x = PwdPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PwdPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PwdPolicy) ProtoMessage() {}
+
+func (x *PwdPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PwdPolicy.ProtoReflect.Descriptor instead.
+func (*PwdPolicy) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PwdPolicy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PwdPolicy) GetMaxAge() int64 {
+	if x != nil {
+		return x.MaxAge
+	}
+	return 0
+}
+
+func (x *PwdPolicy) GetMinAge() int64 {
+	if x != nil {
+		return x.MinAge
+	}
+	return 0
+}
+
+func (x *PwdPolicy) GetInHistory() int32 {
+	if x != nil {
+		return x.InHistory
+	}
+	return 0
+}
+
+func (x *PwdPolicy) GetCheckQuality() int32 {
+	if x != nil {
+		return x.CheckQuality
+	}
+	return 0
+}
+
+func (x *PwdPolicy) GetMinLength() int32 {
+	if x != nil {
+		return x.MinLength
+	}
+	return 0
+}
+
+func (x *PwdPolicy) GetExpireWarning() int64 {
+	if x != nil {
+		return x.ExpireWarning
+	}
+	return 0
+}
+
+func (x *PwdPolicy) GetGraceAuthnLimit() int32 {
+	if x != nil {
+		return x.GraceAuthnLimit
+	}
+	return 0
+}
+
+func (x *PwdPolicy) GetLockout() bool {
+	if x != nil {
+		return x.Lockout
+	}
+	return false
+}
+
+func (x *PwdPolicy) GetLockoutDuration() int64 {
+	if x != nil {
+		return x.LockoutDuration
+	}
+	return 0
+}
+
+func (x *PwdPolicy) GetMaxFailure() int32 {
+	if x != nil {
+		return x.MaxFailure
+	}
+	return 0
+}
+
+func (x *PwdPolicy) GetFailureCountInterval() int64 {
+	if x != nil {
+		return x.FailureCountInterval
+	}
+	return 0
+}
+
+func (x *PwdPolicy) GetMustChange() bool {
+	if x != nil {
+		return x.MustChange
+	}
+	return false
+}
+
+func (x *PwdPolicy) GetDenyUserChange() bool {
+	if x != nil {
+		return x.DenyUserChange
+	}
+	return false
+}
+
+func (x *PwdPolicy) GetSafeModify() bool {
+	if x != nil {
+		return x.SafeModify
+	}
+	return false
+}
+
+type PwdPolicyList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policies []*PwdPolicy `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+}
+
+func (x *PwdPolicyList) Reset() {
+	*x = PwdPolicyList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PwdPolicyList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PwdPolicyList) ProtoMessage() {}
+
+func (x *PwdPolicyList) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PwdPolicyList.ProtoReflect.Descriptor instead.
+func (*PwdPolicyList) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *PwdPolicyList) GetPolicies() []*PwdPolicy {
 	if x != nil {
-		return x.HashingAlgorithm
+		return x.Policies
 	}
-	return HashingAlgorithm_DEFAULT
+	return nil
 }
 
-type LoginRequest struct {
+type GetPwdPolicyListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 }
 
-func (x *LoginRequest) Reset() {
-	*x = LoginRequest{}
+func (x *GetPwdPolicyListRequest) Reset() {
+	*x = GetPwdPolicyListRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ldap_manager_proto_msgTypes[22]
+		mi := &file_ldap_manager_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LoginRequest) String() string {
+func (x *GetPwdPolicyListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginRequest) ProtoMessage() {}
+func (*GetPwdPolicyListRequest) ProtoMessage() {}
 
-func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ldap_manager_proto_msgTypes[22]
+func (x *GetPwdPolicyListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1483,54 +3749,36 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
-func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_ldap_manager_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *LoginRequest) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
-}
-
-func (x *LoginRequest) GetPassword() string {
-	if x != nil {
-		return x.Password
-	}
-	return ""
+// Deprecated: Use GetPwdPolicyListRequest.ProtoReflect.Descriptor instead.
+func (*GetPwdPolicyListRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{52}
 }
 
-type Token struct {
+type GetPwdPolicyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token       string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Username    string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	DisplayName string `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
-	IsAdmin     bool   `protobuf:"varint,4,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
-	Expiration  int64  `protobuf:"varint,10,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *Token) Reset() {
-	*x = Token{}
+func (x *GetPwdPolicyRequest) Reset() {
+	*x = GetPwdPolicyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ldap_manager_proto_msgTypes[23]
+		mi := &file_ldap_manager_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Token) String() string {
+func (x *GetPwdPolicyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Token) ProtoMessage() {}
+func (*GetPwdPolicyRequest) ProtoMessage() {}
 
-func (x *Token) ProtoReflect() protoreflect.Message {
-	mi := &file_ldap_manager_proto_msgTypes[23]
+func (x *GetPwdPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1541,61 +3789,94 @@ func (x *Token) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Token.ProtoReflect.Descriptor instead.
-func (*Token) Descriptor() ([]byte, []int) {
-	return file_ldap_manager_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use GetPwdPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetPwdPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *Token) GetToken() string {
+func (x *GetPwdPolicyRequest) GetName() string {
 	if x != nil {
-		return x.Token
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Token) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
+type DeletePwdPolicyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *Token) GetDisplayName() string {
-	if x != nil {
-		return x.DisplayName
+func (x *DeletePwdPolicyRequest) Reset() {
+	*x = DeletePwdPolicyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ldap_manager_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *Token) GetIsAdmin() bool {
-	if x != nil {
-		return x.IsAdmin
+func (x *DeletePwdPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePwdPolicyRequest) ProtoMessage() {}
+
+func (x *DeletePwdPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ldap_manager_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *Token) GetExpiration() int64 {
+// Deprecated: Use DeletePwdPolicyRequest.ProtoReflect.Descriptor instead.
+func (*DeletePwdPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_ldap_manager_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeletePwdPolicyRequest) GetName() string {
 	if x != nil {
-		return x.Expiration
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
 var file_ldap_manager_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptor.MethodOptions)(nil),
+		ExtensionType: (*Permission)(nil),
+		Field:         51236,
+		Name:          "ldapmanager.permission",
+		Tag:           "varint,51236,opt,name=permission,enum=ldapmanager.Permission",
+		Filename:      "ldap_manager.proto",
+	},
 	{
 		ExtendedType:  (*descriptor.MethodOptions)(nil),
 		ExtensionType: (*bool)(nil),
-		Field:         51234,
-		Name:          "ldapmanager.require_admin",
-		Tag:           "varint,51234,opt,name=require_admin",
+		Field:         51237,
+		Name:          "ldapmanager.self_service",
+		Tag:           "varint,51237,opt,name=self_service",
 		Filename:      "ldap_manager.proto",
 	},
 }
 
 // Extension fields to descriptor.MethodOptions.
 var (
-	// optional bool require_admin = 51234;
-	E_RequireAdmin = &file_ldap_manager_proto_extTypes[0] // bool require_auth = 51235;
+	// permission is required to call a method, methods without a permission only require a valid token
+	//
+	// optional ldapmanager.Permission permission = 51236;
+	E_Permission = &file_ldap_manager_proto_extTypes[0]
+	// self_service allows users to call a method without the permission if the username of the request is their own
+	//
+	// optional bool self_service = 51237;
+	E_SelfService = &file_ldap_manager_proto_extTypes[1]
 )
 
 var File_ldap_manager_proto protoreflect.FileDescriptor
@@ -1607,7 +3888,7 @@ var file_ldap_manager_proto_rawDesc = []byte{
 	0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f,
 	0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0xa6, 0x01, 0x0a, 0x12, 0x47,
+	0x6f, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x96, 0x02, 0x0a, 0x12, 0x47,
 	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
 	0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02,
@@ -1616,71 +3897,125 @@ var file_ldap_manager_proto_rawDesc = []byte{
 	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x53, 0x6f, 0x72, 0x74,
 	0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x09, 0x73, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
 	0x12, 0x19, 0x0a, 0x08, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x66,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x22, 0x70, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x04, 0x64,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x32, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1a, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0x70, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x04, 0x64,
 	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x64, 0x61, 0x70,
 	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x61, 0x74,
 	0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x37, 0x0a, 0x09,
 	0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
 	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x49, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x71, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73,
 	0x74, 0x12, 0x27, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x11, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x55,
-	0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
-	0x22, 0x51, 0x0a, 0x17, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65,
-	0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
-	0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
-	0x6f, 0x72, 0x64, 0x22, 0x2f, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
-	0x6e, 0x61, 0x6d, 0x65, 0x22, 0xff, 0x01, 0x0a, 0x07, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03,
-	0x75, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10,
-	0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x67, 0x69, 0x64,
-	0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x18,
-	0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x53, 0x68, 0x65, 0x6c,
-	0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x6f, 0x6d, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x68, 0x6f, 0x6d, 0x65, 0x44,
-	0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x15, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61,
-	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61,
-	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x43, 0x0a, 0x11, 0x4e, 0x65, 0x77, 0x41, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x07, 0x61,
-	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c,
-	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x60, 0x0a, 0x14, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x51, 0x0a, 0x17, 0x41, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x2c, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x32, 0x0a,
-	0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
-	0x65, 0x22, 0x3f, 0x0a, 0x0f, 0x4e, 0x65, 0x77, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62,
-	0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65,
-	0x72, 0x73, 0x22, 0x28, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x55, 0x0a, 0x12,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
-	0x67, 0x69, 0x64, 0x22, 0xa7, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x2f, 0x0a, 0x11, 0x47,
+	0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xb2, 0x02, 0x0a,
+	0x07, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x69,
+	0x6e, 0x5f, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c,
+	0x6f, 0x67, 0x69, 0x6e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x6f, 0x6d,
+	0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x68, 0x6f, 0x6d, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
+	0x12, 0x17, 0x0a, 0x07, 0x69, 0x64, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x69, 0x64, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x22, 0x43, 0x0a, 0x11, 0x4e, 0x65, 0x77, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x07, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x55, 0x0a, 0x0b, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x07, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x4e, 0x0a,
+	0x16, 0x42, 0x75, 0x6c, 0x6b, 0x4e, 0x65, 0x77, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x64, 0x61, 0x70,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x08, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x22, 0xa0, 0x01,
+	0x0a, 0x11, 0x42, 0x75, 0x6c, 0x6b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x2d, 0x0a, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x70,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x22, 0x6d, 0x0a, 0x17, 0x42, 0x75, 0x6c, 0x6b, 0x4e, 0x65, 0x77, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c,
+	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x22,
+	0x60, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x22, 0x32, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x30, 0x0a, 0x12, 0x4c, 0x6f, 0x63, 0x6b, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x32, 0x0a, 0x14, 0x55, 0x6e, 0x6c, 0x6f, 0x63,
+	0x6b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x70, 0x0a, 0x0f, 0x4e,
+	0x65, 0x77, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x17, 0x0a, 0x07,
+	0x69, 0x64, 0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69,
+	0x64, 0x50, 0x6f, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x22, 0x28, 0x0a,
+	0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x67, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x67, 0x69, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x61, 0x64, 0x64, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x73, 0x22, 0xe3, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
 	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73,
 	0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72,
 	0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
@@ -1689,190 +4024,600 @@ var file_ldap_manager_proto_rawDesc = []byte{
 	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
 	0x09, 0x73, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x6f,
 	0x72, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6f,
-	0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
-	0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x39, 0x0a,
-	0x09, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72,
-	0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75,
-	0x70, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x48, 0x0a, 0x14, 0x49, 0x73, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f,
-	0x75, 0x70, 0x22, 0x30, 0x0a, 0x11, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65,
-	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x6d, 0x65,
-	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x4d, 0x65,
-	0x6d, 0x62, 0x65, 0x72, 0x22, 0x9f, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10,
-	0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x65, 0x6e, 0x64,
-	0x12, 0x35, 0x0a, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x72, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x09, 0x73, 0x6f,
-	0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x6f, 0x72, 0x74, 0x5f,
-	0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x4b,
-	0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x32, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65,
-	0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
-	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x5d, 0x0a, 0x05, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65,
-	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x73, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
-	0x67, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x0a, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x3f, 0x0a, 0x0b, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75,
-	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1a,
-	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x9b, 0x01, 0x0a, 0x15, 0x43,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71,
+	0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x61, 0x0a, 0x09, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x66, 0x0a, 0x14,
+	0x49, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x4a, 0x0a, 0x11,
-	0x68, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68,
-	0x6d, 0x18, 0x64, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61,
-	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67,
-	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x10, 0x68, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x41,
-	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x22, 0x46, 0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x69,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
-	0x22, 0x97, 0x01, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
-	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c,
-	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x69, 0x73, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78,
+	0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x66, 0x66, 0x65, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x22, 0x30, 0x0a, 0x11, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f,
+	0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xbd, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x65,
+	0x6e, 0x64, 0x12, 0x35, 0x0a, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x72, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x09,
+	0x73, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x6f, 0x72,
+	0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6f, 0x72,
+	0x74, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x50, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65,
+	0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x66,
+	0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65,
+	0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x22, 0x9a, 0x01, 0x0a, 0x05, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x67,
+	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x33, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4f, 0x77, 0x6e, 0x65,
+	0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x62, 0x0a, 0x0b, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x73,
+	0x0a, 0x1c, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x74, 0x61, 0x74, 0x69, 0x76, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72,
+	0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79,
+	0x52, 0x75, 0x6e, 0x22, 0x7b, 0x0a, 0x15, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x22, 0x56, 0x0a, 0x16, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73,
+	0x68, 0x69, 0x70, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x73, 0x12, 0x3c, 0x0a, 0x07, 0x72, 0x65,
+	0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x64,
+	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d,
+	0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52,
+	0x07, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x73, 0x22, 0x69, 0x0a, 0x0b, 0x4a, 0x6f, 0x69, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x22, 0x5d, 0x0a, 0x0f, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x22, 0x4e, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x3b, 0x0a, 0x13, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22,
+	0x9b, 0x01, 0x0a, 0x15, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x12, 0x4a, 0x0a, 0x11, 0x68, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x64, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x6c,
+	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x10, 0x68, 0x61, 0x73,
+	0x68, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x22, 0x46, 0x0a,
+	0x0c, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0xff, 0x04, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12,
+	0x21, 0x0a, 0x0c, 0x6f, 0x74, 0x70, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x6f, 0x74, 0x70, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x74, 0x70, 0x5f, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x74, 0x70, 0x43, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x6f, 0x74, 0x70, 0x5f, 0x65,
+	0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x6f, 0x74, 0x70, 0x45, 0x6e, 0x72,
+	0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12,
+	0x2e, 0x0a, 0x13, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x5f, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49, 0x6e, 0x12,
+	0x29, 0x0a, 0x10, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78,
 	0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
-	0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0x2a, 0x0a, 0x09, 0x53, 0x6f,
-	0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x53, 0x43, 0x45, 0x4e,
-	0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x45, 0x53, 0x43, 0x45, 0x4e,
-	0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x2a, 0xa5, 0x01, 0x0a, 0x10, 0x48, 0x61, 0x73, 0x68, 0x69,
-	0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0b, 0x0a, 0x07, 0x44,
-	0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x48, 0x41, 0x35,
-	0x31, 0x32, 0x43, 0x52, 0x59, 0x50, 0x54, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x48, 0x41,
-	0x32, 0x35, 0x36, 0x43, 0x52, 0x59, 0x50, 0x54, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x42, 0x4c,
-	0x4f, 0x57, 0x46, 0x49, 0x53, 0x48, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x58, 0x54, 0x44,
-	0x45, 0x53, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x4d, 0x44, 0x35, 0x43, 0x52, 0x59, 0x50, 0x54,
-	0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x4d, 0x44, 0x35, 0x10, 0x06, 0x12, 0x07, 0x0a, 0x03,
-	0x4d, 0x44, 0x35, 0x10, 0x07, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x48, 0x41, 0x10, 0x08, 0x12, 0x08,
-	0x0a, 0x04, 0x53, 0x53, 0x48, 0x41, 0x10, 0x09, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x52, 0x59, 0x50,
-	0x54, 0x10, 0x0a, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x10, 0x0b, 0x32, 0xcb,
-	0x0c, 0x0a, 0x0b, 0x4c, 0x44, 0x41, 0x50, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x12, 0x4c,
-	0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x19, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61,
-	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
-	0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x22, 0x09,
-	0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x3a, 0x01, 0x2a, 0x12, 0x5f, 0x0a, 0x0b,
-	0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1f, 0x2e, 0x6c, 0x64,
-	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65,
-	0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6c,
-	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c,
-	0x69, 0x73, 0x74, 0x22, 0x18, 0x90, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12,
-	0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x5f, 0x0a,
-	0x0a, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x6c, 0x64,
-	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6c, 0x64,
-	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x22, 0x1e,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x12, 0x16, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0x5c,
-	0x0a, 0x0a, 0x4e, 0x65, 0x77, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x6c,
-	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x65, 0x77, 0x41, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c,
-	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x1a, 0x90, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x1a, 0x0b, 0x2f, 0x76,
-	0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x70, 0x0a, 0x0d,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x2e,
-	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x28, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x22, 0x1d, 0x2f, 0x76,
-	0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e,
-	0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x66,
-	0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
-	0x21, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x2a, 0x16,
-	0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73, 0x65,
-	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0x69, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x22, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c,
-	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x22, 0x14, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x3a, 0x01,
-	0x2a, 0x12, 0x56, 0x0a, 0x08, 0x4e, 0x65, 0x77, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1c, 0x2e,
-	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x65, 0x77, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x16, 0x67, 0x72,
+	0x61, 0x63, 0x65, 0x5f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x73, 0x5f, 0x72, 0x65, 0x6d, 0x61, 0x69,
+	0x6e, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x67, 0x72, 0x61, 0x63,
+	0x65, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67,
+	0x12, 0x30, 0x0a, 0x14, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x6d, 0x75, 0x73,
+	0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4d, 0x75, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3a, 0x0a, 0x13, 0x52, 0x65, 0x66, 0x72, 0x65,
+	0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23,
+	0x0a, 0x0d, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x33, 0x0a, 0x15, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x43, 0x0a, 0x0f, 0x4c, 0x6f, 0x67, 0x69,
+	0x6e, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x2f, 0x0a,
+	0x11, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x54, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x61,
+	0x0a, 0x0e, 0x54, 0x4f, 0x54, 0x50, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x64, 0x65,
+	0x73, 0x22, 0x44, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x54, 0x4f, 0x54, 0x50,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x44, 0x0a, 0x12, 0x44, 0x69, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x54, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0xba, 0x01,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75,
+	0x6e, 0x74, 0x69, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xc5, 0x01, 0x0a, 0x0b, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x1c,
+	0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0x3e, 0x0a, 0x08, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x32,
+	0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x73, 0x22, 0x48, 0x0a, 0x14, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65,
+	0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x48, 0x0a, 0x14,
+	0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x8f, 0x04, 0x0a, 0x09, 0x50, 0x77, 0x64, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67,
+	0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x41, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e,
+	0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x69, 0x6e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x25, 0x0a,
+	0x0e, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x57, 0x61, 0x72,
+	0x6e, 0x69, 0x6e, 0x67, 0x12, 0x2a, 0x0a, 0x11, 0x67, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x61, 0x75,
+	0x74, 0x68, 0x6e, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0f, 0x67, 0x72, 0x61, 0x63, 0x65, 0x41, 0x75, 0x74, 0x68, 0x6e, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x6c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x6c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x6f,
+	0x63, 0x6b, 0x6f, 0x75, 0x74, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x61, 0x69,
+	0x6c, 0x75, 0x72, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6d, 0x75, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x6d, 0x75, 0x73, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x28, 0x0a,
+	0x10, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x64, 0x65, 0x6e, 0x79, 0x55, 0x73, 0x65,
+	0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x66, 0x65, 0x5f,
+	0x6d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x61,
+	0x66, 0x65, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x22, 0x43, 0x0a, 0x0d, 0x50, 0x77, 0x64, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x64,
+	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x22, 0x19, 0x0a,
+	0x17, 0x47, 0x65, 0x74, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x29, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x50,
+	0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x2c, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x77, 0x64,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x2a, 0xb2, 0x02, 0x0a, 0x0a, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x11, 0x0a, 0x0d, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41, 0x54, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x45, 0x41, 0x44, 0x5f, 0x41, 0x43, 0x43, 0x4f,
+	0x55, 0x4e, 0x54, 0x53, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45,
+	0x5f, 0x41, 0x43, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x53, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x52,
+	0x45, 0x53, 0x45, 0x54, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x53, 0x10, 0x03,
+	0x12, 0x13, 0x0a, 0x0f, 0x55, 0x4e, 0x4c, 0x4f, 0x43, 0x4b, 0x5f, 0x41, 0x43, 0x43, 0x4f, 0x55,
+	0x4e, 0x54, 0x53, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x5f,
+	0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x05, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x45,
+	0x41, 0x44, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x53, 0x10, 0x06, 0x12, 0x11, 0x0a, 0x0d, 0x4d,
+	0x41, 0x4e, 0x41, 0x47, 0x45, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x53, 0x10, 0x07, 0x12, 0x18,
+	0x0a, 0x14, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x4d,
+	0x45, 0x4d, 0x42, 0x45, 0x52, 0x53, 0x10, 0x08, 0x12, 0x1e, 0x0a, 0x1a, 0x4d, 0x41, 0x4e, 0x41,
+	0x47, 0x45, 0x5f, 0x4f, 0x57, 0x4e, 0x45, 0x44, 0x5f, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x4d,
+	0x45, 0x4d, 0x42, 0x45, 0x52, 0x53, 0x10, 0x09, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x45, 0x41, 0x44,
+	0x5f, 0x41, 0x55, 0x44, 0x49, 0x54, 0x5f, 0x4c, 0x4f, 0x47, 0x10, 0x0a, 0x12, 0x1a, 0x0a, 0x16,
+	0x52, 0x45, 0x41, 0x44, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x50, 0x4f,
+	0x4c, 0x49, 0x43, 0x49, 0x45, 0x53, 0x10, 0x0b, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x41, 0x4e, 0x41,
+	0x47, 0x45, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x50, 0x4f, 0x4c, 0x49,
+	0x43, 0x49, 0x45, 0x53, 0x10, 0x0c, 0x2a, 0x2a, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x53, 0x43, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47,
+	0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x45, 0x53, 0x43, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47,
+	0x10, 0x01, 0x2a, 0x3d, 0x0a, 0x0d, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4e, 0x59, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06,
+	0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x4f, 0x43, 0x4b,
+	0x45, 0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10,
+	0x03, 0x2a, 0xd9, 0x01, 0x0a, 0x10, 0x48, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c,
+	0x54, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x48, 0x41, 0x35, 0x31, 0x32, 0x43, 0x52, 0x59,
+	0x50, 0x54, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x43, 0x52,
+	0x59, 0x50, 0x54, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x42, 0x4c, 0x4f, 0x57, 0x46, 0x49, 0x53,
+	0x48, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x58, 0x54, 0x44, 0x45, 0x53, 0x10, 0x04, 0x12,
+	0x0c, 0x0a, 0x08, 0x4d, 0x44, 0x35, 0x43, 0x52, 0x59, 0x50, 0x54, 0x10, 0x05, 0x12, 0x08, 0x0a,
+	0x04, 0x53, 0x4d, 0x44, 0x35, 0x10, 0x06, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x35, 0x10, 0x07,
+	0x12, 0x07, 0x0a, 0x03, 0x53, 0x48, 0x41, 0x10, 0x08, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x53, 0x48,
+	0x41, 0x10, 0x09, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x52, 0x59, 0x50, 0x54, 0x10, 0x0a, 0x12, 0x09,
+	0x0a, 0x05, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x10, 0x0b, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x52, 0x47,
+	0x4f, 0x4e, 0x32, 0x49, 0x44, 0x10, 0x0c, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x42, 0x4b, 0x44, 0x46,
+	0x32, 0x5f, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x0d, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x42,
+	0x4b, 0x44, 0x46, 0x32, 0x5f, 0x53, 0x48, 0x41, 0x35, 0x31, 0x32, 0x10, 0x0e, 0x32, 0x99, 0x22,
+	0x0a, 0x0b, 0x4c, 0x44, 0x41, 0x50, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x12, 0x4c, 0x0a,
+	0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x19, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x3a, 0x01, 0x2a,
+	0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x56, 0x0a, 0x08, 0x4c,
+	0x6f, 0x67, 0x69, 0x6e, 0x4f, 0x54, 0x50, 0x12, 0x1c, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x4f, 0x54, 0x50, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x12, 0x3a, 0x01, 0x2a, 0x22, 0x0d, 0x2f, 0x76, 0x31, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x2f,
+	0x6f, 0x74, 0x70, 0x12, 0x78, 0x0a, 0x0a, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x54, 0x4f, 0x54,
+	0x50, 0x12, 0x1e, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x54, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1b, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x54, 0x4f, 0x54, 0x50, 0x45, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x2d,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x22, 0x22, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x74,
+	0x6f, 0x74, 0x70, 0x2f, 0x65, 0x6e, 0x72, 0x6f, 0x6c, 0x6c, 0x3a, 0x01, 0x2a, 0x12, 0x72, 0x0a,
+	0x0b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x54, 0x4f, 0x54, 0x50, 0x12, 0x1f, 0x2e, 0x6c,
+	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x54, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
+	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x2e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x28, 0x22, 0x23, 0x2f, 0x76, 0x31, 0x2f, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x7d, 0x2f, 0x74, 0x6f, 0x74, 0x70, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x3a, 0x01,
+	0x2a, 0x12, 0x7a, 0x0a, 0x0b, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x4f, 0x54, 0x50,
+	0x12, 0x1f, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x44,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x4f, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x36, 0xa0, 0x82, 0x19, 0x02, 0xa8, 0x82, 0x19, 0x01, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x28, 0x3a, 0x01, 0x2a, 0x22, 0x23, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d,
+	0x2f, 0x74, 0x6f, 0x74, 0x70, 0x2f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x62, 0x0a,
+	0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x20, 0x2e,
+	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x22, 0x11, 0x2f, 0x76, 0x31,
+	0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x2f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x3a, 0x01,
+	0x2a, 0x12, 0x47, 0x0a, 0x06, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x12, 0x12, 0x2e, 0x6c, 0x64,
+	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x22, 0x0a, 0x2f, 0x76, 0x31,
+	0x2f, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x83, 0x01, 0x0a, 0x0e, 0x52,
+	0x65, 0x76, 0x6f, 0x6b, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x2e,
+	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x76, 0x6f,
+	0x6b, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x39, 0xa0, 0x82, 0x19, 0x05, 0xa8, 0x82, 0x19, 0x01, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x2b, 0x22, 0x26, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x3a, 0x01, 0x2a,
+	0x12, 0x74, 0x0a, 0x14, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x21, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52,
+	0x65, 0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64,
 	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
-	0x18, 0x90, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x1a, 0x09, 0x2f, 0x76, 0x31,
-	0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x3a, 0x01, 0x2a, 0x12, 0x60, 0x0a, 0x0b, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1f, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70,
-	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1c, 0x90,
-	0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x2a, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x67,
-	0x72, 0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0x6a, 0x0a, 0x0b, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1f, 0x2e, 0x6c, 0x64, 0x61,
-	0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47,
+	0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x22, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x2f, 0x72, 0x65, 0x73, 0x65, 0x74, 0x2f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x65, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x21, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61,
+	0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1d,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x22, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x2f, 0x72, 0x65, 0x73, 0x65, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x5f, 0x0a,
+	0x0b, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1f, 0x2e, 0x6c,
+	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72,
+	0x4c, 0x69, 0x73, 0x74, 0x22, 0x18, 0xa0, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e,
+	0x12, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x67,
+	0x0a, 0x0a, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x6c,
+	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6c,
+	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x22,
+	0x26, 0xa0, 0x82, 0x19, 0x01, 0xa8, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x12,
+	0x16, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73,
+	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0x5c, 0x0a, 0x0a, 0x4e, 0x65, 0x77, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x2e, 0x4e, 0x65, 0x77, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1a, 0xa0, 0x82, 0x19, 0x02, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x10, 0x3a, 0x01, 0x2a, 0x1a, 0x0b, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x79, 0x0a, 0x0f, 0x42, 0x75, 0x6c, 0x6b, 0x4e, 0x65, 0x77,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x4e, 0x65, 0x77, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e,
+	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x42, 0x75, 0x6c, 0x6b,
+	0x4e, 0x65, 0x77, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x1b, 0xa0, 0x82, 0x19, 0x02, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x1a,
+	0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x3a, 0x01, 0x2a,
+	0x12, 0x78, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x21, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x72, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x30, 0xa0, 0x82, 0x19, 0x02, 0xa8, 0x82,
+	0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x22, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d,
+	0x2f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x6e, 0x0a, 0x0d, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x2e, 0x6c, 0x64,
+	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
+	0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x26, 0xa0, 0x82, 0x19, 0x02, 0xa8, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x18, 0x2a, 0x16, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f,
+	0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0x6e, 0x0a, 0x0b, 0x4c, 0x6f,
+	0x63, 0x6b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x2e, 0x6c, 0x64, 0x61, 0x70,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61,
+	0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2a,
+	0xa0, 0x82, 0x19, 0x02, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x22, 0x1b, 0x2f, 0x76, 0x31, 0x2f,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x7d, 0x2f, 0x6c, 0x6f, 0x63, 0x6b, 0x3a, 0x01, 0x2a, 0x12, 0x74, 0x0a, 0x0d, 0x55, 0x6e,
+	0x6c, 0x6f, 0x63, 0x6b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x2e, 0x6c, 0x64,
+	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
+	0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x2c, 0xa0, 0x82, 0x19, 0x04, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x22, 0x1d,
+	0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x3a, 0x01, 0x2a,
+	0x12, 0x71, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x12, 0x22, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x27, 0xa0, 0x82, 0x19, 0x03,
+	0xa8, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f,
+	0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x12, 0x56, 0x0a, 0x08, 0x4e, 0x65, 0x77, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x1c, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4e, 0x65,
+	0x77, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
+	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x18, 0xa0, 0x82, 0x19, 0x07, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x3a, 0x01, 0x2a,
+	0x1a, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x60, 0x0a, 0x0b, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1f, 0x2e, 0x6c, 0x64, 0x61,
+	0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47,
 	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64,
 	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
-	0x26, 0x90, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x22, 0x17, 0x2f, 0x76, 0x31,
-	0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x75, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x5c, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x20, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61,
-	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69,
-	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6c, 0x64, 0x61, 0x70,
-	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x73,
-	0x74, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x76, 0x31, 0x2f, 0x67,
-	0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x71, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x21, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e,
-	0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x47, 0x72, 0x6f, 0x75,
+	0x1c, 0xa0, 0x82, 0x19, 0x07, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x2a, 0x10, 0x2f, 0x76, 0x31,
+	0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0x6a, 0x0a,
+	0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1f, 0x2e, 0x6c,
+	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
+	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x26, 0xa0, 0x82, 0x19, 0x07, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x22, 0x17, 0x2f,
+	0x76, 0x31, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x5c, 0x0a, 0x0c, 0x47, 0x65, 0x74,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x20, 0x2e, 0x6c, 0x64, 0x61, 0x70,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6c, 0x64,
+	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c,
+	0x69, 0x73, 0x74, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x76, 0x31,
+	0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x79, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x21, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6c, 0x64,
+	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c,
+	0x69, 0x73, 0x74, 0x22, 0x2d, 0xa0, 0x82, 0x19, 0x06, 0xa8, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1f, 0x12, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x12, 0x81, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x22, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75,
 	0x70, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6c, 0x64, 0x61, 0x70,
 	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4c, 0x69, 0x73,
-	0x74, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x12, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x61,
-	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
-	0x7d, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x58, 0x0a, 0x0d, 0x49, 0x73, 0x47, 0x72,
-	0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x21, 0x2e, 0x6c, 0x64, 0x61, 0x70,
-	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x49, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d,
-	0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6c,
-	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x04, 0x90, 0x82,
-	0x19, 0x01, 0x12, 0x5a, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1c,
-	0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c,
-	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70,
-	0x22, 0x1c, 0x90, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x76,
-	0x31, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0x68,
-	0x0a, 0x0e, 0x41, 0x64, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
-	0x12, 0x18, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47,
-	0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61,
-	0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x28,
-	0x90, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x1a, 0x19, 0x2f, 0x76, 0x31, 0x2f,
-	0x67, 0x72, 0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x7d, 0x2f, 0x6d, 0x65,
-	0x6d, 0x62, 0x65, 0x72, 0x73, 0x3a, 0x01, 0x2a, 0x12, 0x72, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18, 0x2e,
-	0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61,
-	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2f, 0x90, 0x82, 0x19,
-	0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x25, 0x2a, 0x23, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x6f,
-	0x75, 0x70, 0x2f, 0x7b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x7d, 0x2f, 0x6d, 0x65, 0x6d, 0x62, 0x65,
-	0x72, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x3a, 0x45, 0x0a, 0x0d,
-	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x1e, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xa2, 0x90,
-	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x41, 0x64,
-	0x6d, 0x69, 0x6e, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x72, 0x6f, 0x6d, 0x6e, 0x6e, 0x6e, 0x2f, 0x6c, 0x64, 0x61, 0x70, 0x2d, 0x6d, 0x61,
-	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x6c, 0x64, 0x61, 0x70, 0x2d,
-	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x3b, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61,
-	0x67, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x22, 0x33, 0xa0, 0x82, 0x19, 0x06, 0xa8, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x25, 0x12, 0x23, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x7b,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x6f, 0x77, 0x6e, 0x65, 0x64, 0x2d,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x5c, 0x0a, 0x0d, 0x49, 0x73, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x21, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x49, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d,
+	0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x6c, 0x64, 0x61,
+	0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x08, 0xa0, 0x82, 0x19, 0x06,
+	0xa8, 0x82, 0x19, 0x01, 0x12, 0x5a, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x12, 0x1c, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47,
+	0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
+	0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x22, 0x1c, 0xa0, 0x82, 0x19, 0x06, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10,
+	0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d,
+	0x12, 0x68, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x18, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x12, 0x2e, 0x6c,
+	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x28, 0xa0, 0x82, 0x19, 0x08, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x3a, 0x01, 0x2a, 0x1a,
+	0x19, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x7d, 0x2f, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x76, 0x0a, 0x11, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x18, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x33, 0xa0,
+	0x82, 0x19, 0x08, 0xa8, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x25, 0x2a, 0x23, 0x2f,
+	0x76, 0x31, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x7d,
+	0x2f, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x7d, 0x12, 0x89, 0x01, 0x0a, 0x15, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x29, 0x2e, 0x6c,
+	0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x70, 0x61, 0x69,
+	0x72, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x73, 0x22, 0x20, 0xa0, 0x82,
+	0x19, 0x07, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x22, 0x11, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x2f, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x3a, 0x01, 0x2a, 0x12, 0x7b,
+	0x0a, 0x16, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x18, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x4d, 0x65, 0x6d, 0x62,
+	0x65, 0x72, 0x1a, 0x18, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2d, 0xa0, 0x82,
+	0x19, 0x08, 0xa8, 0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x22, 0x1a, 0x2f, 0x76,
+	0x31, 0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x7d, 0x2f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x3a, 0x01, 0x2a, 0x12, 0x7a, 0x0a, 0x13, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x12, 0x27, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6c, 0x64,
+	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x1c, 0xa0, 0x82, 0x19, 0x08, 0xa8,
+	0x82, 0x19, 0x01, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x7e, 0x0a, 0x0e, 0x41, 0x70, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x2e, 0x6c, 0x64, 0x61, 0x70,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x12, 0x2e, 0x6c, 0x64,
+	0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x36, 0xa0, 0x82, 0x19, 0x08, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2c, 0x22, 0x27, 0x2f, 0x76, 0x31,
+	0x2f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x7d, 0x2f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x61, 0x70, 0x70,
+	0x72, 0x6f, 0x76, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x78, 0x0a, 0x0b, 0x44, 0x65, 0x6e, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x72, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x33, 0xa0, 0x82,
+	0x19, 0x08, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x29, 0x22, 0x24, 0x2f, 0x76, 0x31, 0x2f, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x2f, 0x7b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x7d, 0x2f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x64, 0x65, 0x6e, 0x79, 0x3a, 0x01,
+	0x2a, 0x12, 0x5c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67,
+	0x12, 0x1f, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47,
+	0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x22, 0x15, 0xa0, 0x82, 0x19, 0x0a, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x12,
+	0x6f, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x24, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6c, 0x64, 0x61, 0x70,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x19, 0xa0, 0x82, 0x19, 0x0b, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x0f, 0x12, 0x0d, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73,
+	0x12, 0x68, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x20, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x47,
+	0x65, 0x74, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x2e, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0x1e, 0xa0, 0x82, 0x19, 0x0b,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0x58, 0x0a, 0x0c, 0x4e, 0x65,
+	0x77, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x16, 0x2e, 0x6c, 0x64, 0x61,
+	0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1c, 0xa0, 0x82, 0x19, 0x0c, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x12, 0x3a, 0x01, 0x2a, 0x1a, 0x0d, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x69, 0x65, 0x73, 0x12, 0x67, 0x0a, 0x0f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x77,
+	0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x16, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x1a,
+	0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x28, 0xa0, 0x82, 0x19, 0x0c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x22,
+	0x19, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2f, 0x7b, 0x6e, 0x61,
+	0x6d, 0x65, 0x7d, 0x2f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x6a, 0x0a,
+	0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x23, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x77, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1e, 0xa0, 0x82, 0x19, 0x0c, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x14, 0x2a, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x2f, 0x7b, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x3a, 0x59, 0x0a, 0x0a, 0x70, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xa4, 0x90, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x17, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x3a, 0x43, 0x0a, 0x0c, 0x73, 0x65, 0x6c, 0x66, 0x5f, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x1e, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0xa5, 0x90, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73, 0x65,
+	0x6c, 0x66, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x6f, 0x6d, 0x6e, 0x6e, 0x2f, 0x6c, 0x64,
+	0x61, 0x70, 0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f,
+	0x6c, 0x64, 0x61, 0x70, 0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x3b, 0x6c, 0x64, 0x61,
+	0x70, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1887,85 +4632,177 @@ func file_ldap_manager_proto_rawDescGZIP() []byte {
 	return file_ldap_manager_proto_rawDescData
 }
 
-var file_ldap_manager_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_ldap_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_ldap_manager_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_ldap_manager_proto_msgTypes = make([]protoimpl.MessageInfo, 56)
 var file_ldap_manager_proto_goTypes = []interface{}{
-	(SortOrder)(0),                   // 0: ldapmanager.SortOrder
-	(HashingAlgorithm)(0),            // 1: ldapmanager.HashingAlgorithm
-	(*Empty)(nil),                    // 2: ldapmanager.Empty
-	(*GetUserListRequest)(nil),       // 3: ldapmanager.GetUserListRequest
-	(*User)(nil),                     // 4: ldapmanager.User
-	(*UserList)(nil),                 // 5: ldapmanager.UserList
-	(*AuthenticateUserRequest)(nil),  // 6: ldapmanager.AuthenticateUserRequest
-	(*GetAccountRequest)(nil),        // 7: ldapmanager.GetAccountRequest
-	(*Account)(nil),                  // 8: ldapmanager.Account
-	(*NewAccountRequest)(nil),        // 9: ldapmanager.NewAccountRequest
-	(*UpdateAccountRequest)(nil),     // 10: ldapmanager.UpdateAccountRequest
-	(*DeleteAccountRequest)(nil),     // 11: ldapmanager.DeleteAccountRequest
-	(*NewGroupRequest)(nil),          // 12: ldapmanager.NewGroupRequest
-	(*DeleteGroupRequest)(nil),       // 13: ldapmanager.DeleteGroupRequest
-	(*UpdateGroupRequest)(nil),       // 14: ldapmanager.UpdateGroupRequest
-	(*GetGroupListRequest)(nil),      // 15: ldapmanager.GetGroupListRequest
-	(*GroupList)(nil),                // 16: ldapmanager.GroupList
-	(*IsGroupMemberRequest)(nil),     // 17: ldapmanager.IsGroupMemberRequest
-	(*GroupMemberStatus)(nil),        // 18: ldapmanager.GroupMemberStatus
-	(*GetGroupRequest)(nil),          // 19: ldapmanager.GetGroupRequest
-	(*GetUserGroupsRequest)(nil),     // 20: ldapmanager.GetUserGroupsRequest
-	(*Group)(nil),                    // 21: ldapmanager.Group
-	(*GroupMember)(nil),              // 22: ldapmanager.GroupMember
-	(*ChangePasswordRequest)(nil),    // 23: ldapmanager.ChangePasswordRequest
-	(*LoginRequest)(nil),             // 24: ldapmanager.LoginRequest
-	(*Token)(nil),                    // 25: ldapmanager.Token
-	nil,                              // 26: ldapmanager.User.DataEntry
-	(*descriptor.MethodOptions)(nil), // 27: google.protobuf.MethodOptions
+	(Permission)(0),                      // 0: ldapmanager.Permission
+	(SortOrder)(0),                       // 1: ldapmanager.SortOrder
+	(AccountStatus)(0),                   // 2: ldapmanager.AccountStatus
+	(HashingAlgorithm)(0),                // 3: ldapmanager.HashingAlgorithm
+	(*Empty)(nil),                        // 4: ldapmanager.Empty
+	(*GetUserListRequest)(nil),           // 5: ldapmanager.GetUserListRequest
+	(*User)(nil),                         // 6: ldapmanager.User
+	(*UserList)(nil),                     // 7: ldapmanager.UserList
+	(*AuthenticateUserRequest)(nil),      // 8: ldapmanager.AuthenticateUserRequest
+	(*GetAccountRequest)(nil),            // 9: ldapmanager.GetAccountRequest
+	(*Account)(nil),                      // 10: ldapmanager.Account
+	(*NewAccountRequest)(nil),            // 11: ldapmanager.NewAccountRequest
+	(*BulkAccount)(nil),                  // 12: ldapmanager.BulkAccount
+	(*BulkNewAccountsRequest)(nil),       // 13: ldapmanager.BulkNewAccountsRequest
+	(*BulkAccountResult)(nil),            // 14: ldapmanager.BulkAccountResult
+	(*BulkNewAccountsResponse)(nil),      // 15: ldapmanager.BulkNewAccountsResponse
+	(*UpdateAccountRequest)(nil),         // 16: ldapmanager.UpdateAccountRequest
+	(*DeleteAccountRequest)(nil),         // 17: ldapmanager.DeleteAccountRequest
+	(*LockAccountRequest)(nil),           // 18: ldapmanager.LockAccountRequest
+	(*UnlockAccountRequest)(nil),         // 19: ldapmanager.UnlockAccountRequest
+	(*NewGroupRequest)(nil),              // 20: ldapmanager.NewGroupRequest
+	(*DeleteGroupRequest)(nil),           // 21: ldapmanager.DeleteGroupRequest
+	(*UpdateGroupRequest)(nil),           // 22: ldapmanager.UpdateGroupRequest
+	(*GetGroupListRequest)(nil),          // 23: ldapmanager.GetGroupListRequest
+	(*GroupList)(nil),                    // 24: ldapmanager.GroupList
+	(*IsGroupMemberRequest)(nil),         // 25: ldapmanager.IsGroupMemberRequest
+	(*GroupMemberStatus)(nil),            // 26: ldapmanager.GroupMemberStatus
+	(*GetGroupRequest)(nil),              // 27: ldapmanager.GetGroupRequest
+	(*GetUserGroupsRequest)(nil),         // 28: ldapmanager.GetUserGroupsRequest
+	(*Group)(nil),                        // 29: ldapmanager.Group
+	(*GetOwnedGroupsRequest)(nil),        // 30: ldapmanager.GetOwnedGroupsRequest
+	(*GroupMember)(nil),                  // 31: ldapmanager.GroupMember
+	(*RepairGroupMembershipRequest)(nil), // 32: ldapmanager.RepairGroupMembershipRequest
+	(*GroupMembershipRepair)(nil),        // 33: ldapmanager.GroupMembershipRepair
+	(*GroupMembershipRepairs)(nil),       // 34: ldapmanager.GroupMembershipRepairs
+	(*JoinRequest)(nil),                  // 35: ldapmanager.JoinRequest
+	(*JoinRequestList)(nil),              // 36: ldapmanager.JoinRequestList
+	(*ListPendingRequestsRequest)(nil),   // 37: ldapmanager.ListPendingRequestsRequest
+	(*JoinRequestDecision)(nil),          // 38: ldapmanager.JoinRequestDecision
+	(*ChangePasswordRequest)(nil),        // 39: ldapmanager.ChangePasswordRequest
+	(*LoginRequest)(nil),                 // 40: ldapmanager.LoginRequest
+	(*Token)(nil),                        // 41: ldapmanager.Token
+	(*RefreshTokenRequest)(nil),          // 42: ldapmanager.RefreshTokenRequest
+	(*RevokeSessionsRequest)(nil),        // 43: ldapmanager.RevokeSessionsRequest
+	(*LoginOTPRequest)(nil),              // 44: ldapmanager.LoginOTPRequest
+	(*EnrollTOTPRequest)(nil),            // 45: ldapmanager.EnrollTOTPRequest
+	(*TOTPEnrollment)(nil),               // 46: ldapmanager.TOTPEnrollment
+	(*ConfirmTOTPRequest)(nil),           // 47: ldapmanager.ConfirmTOTPRequest
+	(*DisableTOTPRequest)(nil),           // 48: ldapmanager.DisableTOTPRequest
+	(*GetAuditLogRequest)(nil),           // 49: ldapmanager.GetAuditLogRequest
+	(*AuditRecord)(nil),                  // 50: ldapmanager.AuditRecord
+	(*AuditLog)(nil),                     // 51: ldapmanager.AuditLog
+	(*PasswordResetRequest)(nil),         // 52: ldapmanager.PasswordResetRequest
+	(*ResetPasswordRequest)(nil),         // 53: ldapmanager.ResetPasswordRequest
+	(*PwdPolicy)(nil),                    // 54: ldapmanager.PwdPolicy
+	(*PwdPolicyList)(nil),                // 55: ldapmanager.PwdPolicyList
+	(*GetPwdPolicyListRequest)(nil),      // 56: ldapmanager.GetPwdPolicyListRequest
+	(*GetPwdPolicyRequest)(nil),          // 57: ldapmanager.GetPwdPolicyRequest
+	(*DeletePwdPolicyRequest)(nil),       // 58: ldapmanager.DeletePwdPolicyRequest
+	nil,                                  // 59: ldapmanager.User.DataEntry
+	(*descriptor.MethodOptions)(nil),     // 60: google.protobuf.MethodOptions
 }
 var file_ldap_manager_proto_depIdxs = []int32{
-	0,  // 0: ldapmanager.GetUserListRequest.sort_order:type_name -> ldapmanager.SortOrder
-	26, // 1: ldapmanager.User.data:type_name -> ldapmanager.User.DataEntry
-	4,  // 2: ldapmanager.UserList.users:type_name -> ldapmanager.User
-	8,  // 3: ldapmanager.NewAccountRequest.account:type_name -> ldapmanager.Account
-	8,  // 4: ldapmanager.UpdateAccountRequest.update:type_name -> ldapmanager.Account
-	0,  // 5: ldapmanager.GetGroupListRequest.sort_order:type_name -> ldapmanager.SortOrder
-	0,  // 6: ldapmanager.GetGroupRequest.sort_order:type_name -> ldapmanager.SortOrder
-	1,  // 7: ldapmanager.ChangePasswordRequest.hashing_algorithm:type_name -> ldapmanager.HashingAlgorithm
-	27, // 8: ldapmanager.require_admin:extendee -> google.protobuf.MethodOptions
-	24, // 9: ldapmanager.LDAPManager.Login:input_type -> ldapmanager.LoginRequest
-	3,  // 10: ldapmanager.LDAPManager.GetUserList:input_type -> ldapmanager.GetUserListRequest
-	7,  // 11: ldapmanager.LDAPManager.GetAccount:input_type -> ldapmanager.GetAccountRequest
-	9,  // 12: ldapmanager.LDAPManager.NewAccount:input_type -> ldapmanager.NewAccountRequest
-	10, // 13: ldapmanager.LDAPManager.UpdateAccount:input_type -> ldapmanager.UpdateAccountRequest
-	11, // 14: ldapmanager.LDAPManager.DeleteAccount:input_type -> ldapmanager.DeleteAccountRequest
-	23, // 15: ldapmanager.LDAPManager.ChangePassword:input_type -> ldapmanager.ChangePasswordRequest
-	12, // 16: ldapmanager.LDAPManager.NewGroup:input_type -> ldapmanager.NewGroupRequest
-	13, // 17: ldapmanager.LDAPManager.DeleteGroup:input_type -> ldapmanager.DeleteGroupRequest
-	14, // 18: ldapmanager.LDAPManager.UpdateGroup:input_type -> ldapmanager.UpdateGroupRequest
-	15, // 19: ldapmanager.LDAPManager.GetGroupList:input_type -> ldapmanager.GetGroupListRequest
-	20, // 20: ldapmanager.LDAPManager.GetUserGroups:input_type -> ldapmanager.GetUserGroupsRequest
-	17, // 21: ldapmanager.LDAPManager.IsGroupMember:input_type -> ldapmanager.IsGroupMemberRequest
-	19, // 22: ldapmanager.LDAPManager.GetGroup:input_type -> ldapmanager.GetGroupRequest
-	22, // 23: ldapmanager.LDAPManager.AddGroupMember:input_type -> ldapmanager.GroupMember
-	22, // 24: ldapmanager.LDAPManager.DeleteGroupMember:input_type -> ldapmanager.GroupMember
-	25, // 25: ldapmanager.LDAPManager.Login:output_type -> ldapmanager.Token
-	5,  // 26: ldapmanager.LDAPManager.GetUserList:output_type -> ldapmanager.UserList
-	4,  // 27: ldapmanager.LDAPManager.GetAccount:output_type -> ldapmanager.User
-	2,  // 28: ldapmanager.LDAPManager.NewAccount:output_type -> ldapmanager.Empty
-	25, // 29: ldapmanager.LDAPManager.UpdateAccount:output_type -> ldapmanager.Token
-	2,  // 30: ldapmanager.LDAPManager.DeleteAccount:output_type -> ldapmanager.Empty
-	2,  // 31: ldapmanager.LDAPManager.ChangePassword:output_type -> ldapmanager.Empty
-	2,  // 32: ldapmanager.LDAPManager.NewGroup:output_type -> ldapmanager.Empty
-	2,  // 33: ldapmanager.LDAPManager.DeleteGroup:output_type -> ldapmanager.Empty
-	2,  // 34: ldapmanager.LDAPManager.UpdateGroup:output_type -> ldapmanager.Empty
-	16, // 35: ldapmanager.LDAPManager.GetGroupList:output_type -> ldapmanager.GroupList
-	16, // 36: ldapmanager.LDAPManager.GetUserGroups:output_type -> ldapmanager.GroupList
-	18, // 37: ldapmanager.LDAPManager.IsGroupMember:output_type -> ldapmanager.GroupMemberStatus
-	21, // 38: ldapmanager.LDAPManager.GetGroup:output_type -> ldapmanager.Group
-	2,  // 39: ldapmanager.LDAPManager.AddGroupMember:output_type -> ldapmanager.Empty
-	2,  // 40: ldapmanager.LDAPManager.DeleteGroupMember:output_type -> ldapmanager.Empty
-	25, // [25:41] is the sub-list for method output_type
-	9,  // [9:25] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	8,  // [8:9] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	1,  // 0: ldapmanager.GetUserListRequest.sort_order:type_name -> ldapmanager.SortOrder
+	2,  // 1: ldapmanager.GetUserListRequest.status:type_name -> ldapmanager.AccountStatus
+	59, // 2: ldapmanager.User.data:type_name -> ldapmanager.User.DataEntry
+	6,  // 3: ldapmanager.UserList.users:type_name -> ldapmanager.User
+	10, // 4: ldapmanager.NewAccountRequest.account:type_name -> ldapmanager.Account
+	10, // 5: ldapmanager.BulkAccount.account:type_name -> ldapmanager.Account
+	12, // 6: ldapmanager.BulkNewAccountsRequest.accounts:type_name -> ldapmanager.BulkAccount
+	14, // 7: ldapmanager.BulkNewAccountsResponse.results:type_name -> ldapmanager.BulkAccountResult
+	10, // 8: ldapmanager.UpdateAccountRequest.update:type_name -> ldapmanager.Account
+	1,  // 9: ldapmanager.GetGroupListRequest.sort_order:type_name -> ldapmanager.SortOrder
+	1,  // 10: ldapmanager.GetGroupRequest.sort_order:type_name -> ldapmanager.SortOrder
+	33, // 11: ldapmanager.GroupMembershipRepairs.repairs:type_name -> ldapmanager.GroupMembershipRepair
+	35, // 12: ldapmanager.JoinRequestList.requests:type_name -> ldapmanager.JoinRequest
+	3,  // 13: ldapmanager.ChangePasswordRequest.hashing_algorithm:type_name -> ldapmanager.HashingAlgorithm
+	0,  // 14: ldapmanager.Token.permissions:type_name -> ldapmanager.Permission
+	50, // 15: ldapmanager.AuditLog.records:type_name -> ldapmanager.AuditRecord
+	54, // 16: ldapmanager.PwdPolicyList.policies:type_name -> ldapmanager.PwdPolicy
+	60, // 17: ldapmanager.permission:extendee -> google.protobuf.MethodOptions
+	60, // 18: ldapmanager.self_service:extendee -> google.protobuf.MethodOptions
+	0,  // 19: ldapmanager.permission:type_name -> ldapmanager.Permission
+	40, // 20: ldapmanager.LDAPManager.Login:input_type -> ldapmanager.LoginRequest
+	44, // 21: ldapmanager.LDAPManager.LoginOTP:input_type -> ldapmanager.LoginOTPRequest
+	45, // 22: ldapmanager.LDAPManager.EnrollTOTP:input_type -> ldapmanager.EnrollTOTPRequest
+	47, // 23: ldapmanager.LDAPManager.ConfirmTOTP:input_type -> ldapmanager.ConfirmTOTPRequest
+	48, // 24: ldapmanager.LDAPManager.DisableTOTP:input_type -> ldapmanager.DisableTOTPRequest
+	42, // 25: ldapmanager.LDAPManager.RefreshToken:input_type -> ldapmanager.RefreshTokenRequest
+	4,  // 26: ldapmanager.LDAPManager.Logout:input_type -> ldapmanager.Empty
+	43, // 27: ldapmanager.LDAPManager.RevokeSessions:input_type -> ldapmanager.RevokeSessionsRequest
+	52, // 28: ldapmanager.LDAPManager.RequestPasswordReset:input_type -> ldapmanager.PasswordResetRequest
+	53, // 29: ldapmanager.LDAPManager.ResetPassword:input_type -> ldapmanager.ResetPasswordRequest
+	5,  // 30: ldapmanager.LDAPManager.GetUserList:input_type -> ldapmanager.GetUserListRequest
+	9,  // 31: ldapmanager.LDAPManager.GetAccount:input_type -> ldapmanager.GetAccountRequest
+	11, // 32: ldapmanager.LDAPManager.NewAccount:input_type -> ldapmanager.NewAccountRequest
+	13, // 33: ldapmanager.LDAPManager.BulkNewAccounts:input_type -> ldapmanager.BulkNewAccountsRequest
+	16, // 34: ldapmanager.LDAPManager.UpdateAccount:input_type -> ldapmanager.UpdateAccountRequest
+	17, // 35: ldapmanager.LDAPManager.DeleteAccount:input_type -> ldapmanager.DeleteAccountRequest
+	18, // 36: ldapmanager.LDAPManager.LockAccount:input_type -> ldapmanager.LockAccountRequest
+	19, // 37: ldapmanager.LDAPManager.UnlockAccount:input_type -> ldapmanager.UnlockAccountRequest
+	39, // 38: ldapmanager.LDAPManager.ChangePassword:input_type -> ldapmanager.ChangePasswordRequest
+	20, // 39: ldapmanager.LDAPManager.NewGroup:input_type -> ldapmanager.NewGroupRequest
+	21, // 40: ldapmanager.LDAPManager.DeleteGroup:input_type -> ldapmanager.DeleteGroupRequest
+	22, // 41: ldapmanager.LDAPManager.UpdateGroup:input_type -> ldapmanager.UpdateGroupRequest
+	23, // 42: ldapmanager.LDAPManager.GetGroupList:input_type -> ldapmanager.GetGroupListRequest
+	28, // 43: ldapmanager.LDAPManager.GetUserGroups:input_type -> ldapmanager.GetUserGroupsRequest
+	30, // 44: ldapmanager.LDAPManager.GetOwnedGroups:input_type -> ldapmanager.GetOwnedGroupsRequest
+	25, // 45: ldapmanager.LDAPManager.IsGroupMember:input_type -> ldapmanager.IsGroupMemberRequest
+	27, // 46: ldapmanager.LDAPManager.GetGroup:input_type -> ldapmanager.GetGroupRequest
+	31, // 47: ldapmanager.LDAPManager.AddGroupMember:input_type -> ldapmanager.GroupMember
+	31, // 48: ldapmanager.LDAPManager.DeleteGroupMember:input_type -> ldapmanager.GroupMember
+	32, // 49: ldapmanager.LDAPManager.RepairGroupMembership:input_type -> ldapmanager.RepairGroupMembershipRequest
+	31, // 50: ldapmanager.LDAPManager.RequestGroupMembership:input_type -> ldapmanager.GroupMember
+	37, // 51: ldapmanager.LDAPManager.ListPendingRequests:input_type -> ldapmanager.ListPendingRequestsRequest
+	38, // 52: ldapmanager.LDAPManager.ApproveRequest:input_type -> ldapmanager.JoinRequestDecision
+	38, // 53: ldapmanager.LDAPManager.DenyRequest:input_type -> ldapmanager.JoinRequestDecision
+	49, // 54: ldapmanager.LDAPManager.GetAuditLog:input_type -> ldapmanager.GetAuditLogRequest
+	56, // 55: ldapmanager.LDAPManager.GetPwdPolicyList:input_type -> ldapmanager.GetPwdPolicyListRequest
+	57, // 56: ldapmanager.LDAPManager.GetPwdPolicy:input_type -> ldapmanager.GetPwdPolicyRequest
+	54, // 57: ldapmanager.LDAPManager.NewPwdPolicy:input_type -> ldapmanager.PwdPolicy
+	54, // 58: ldapmanager.LDAPManager.UpdatePwdPolicy:input_type -> ldapmanager.PwdPolicy
+	58, // 59: ldapmanager.LDAPManager.DeletePwdPolicy:input_type -> ldapmanager.DeletePwdPolicyRequest
+	41, // 60: ldapmanager.LDAPManager.Login:output_type -> ldapmanager.Token
+	41, // 61: ldapmanager.LDAPManager.LoginOTP:output_type -> ldapmanager.Token
+	46, // 62: ldapmanager.LDAPManager.EnrollTOTP:output_type -> ldapmanager.TOTPEnrollment
+	4,  // 63: ldapmanager.LDAPManager.ConfirmTOTP:output_type -> ldapmanager.Empty
+	4,  // 64: ldapmanager.LDAPManager.DisableTOTP:output_type -> ldapmanager.Empty
+	41, // 65: ldapmanager.LDAPManager.RefreshToken:output_type -> ldapmanager.Token
+	4,  // 66: ldapmanager.LDAPManager.Logout:output_type -> ldapmanager.Empty
+	4,  // 67: ldapmanager.LDAPManager.RevokeSessions:output_type -> ldapmanager.Empty
+	4,  // 68: ldapmanager.LDAPManager.RequestPasswordReset:output_type -> ldapmanager.Empty
+	4,  // 69: ldapmanager.LDAPManager.ResetPassword:output_type -> ldapmanager.Empty
+	7,  // 70: ldapmanager.LDAPManager.GetUserList:output_type -> ldapmanager.UserList
+	6,  // 71: ldapmanager.LDAPManager.GetAccount:output_type -> ldapmanager.User
+	4,  // 72: ldapmanager.LDAPManager.NewAccount:output_type -> ldapmanager.Empty
+	15, // 73: ldapmanager.LDAPManager.BulkNewAccounts:output_type -> ldapmanager.BulkNewAccountsResponse
+	41, // 74: ldapmanager.LDAPManager.UpdateAccount:output_type -> ldapmanager.Token
+	4,  // 75: ldapmanager.LDAPManager.DeleteAccount:output_type -> ldapmanager.Empty
+	4,  // 76: ldapmanager.LDAPManager.LockAccount:output_type -> ldapmanager.Empty
+	4,  // 77: ldapmanager.LDAPManager.UnlockAccount:output_type -> ldapmanager.Empty
+	4,  // 78: ldapmanager.LDAPManager.ChangePassword:output_type -> ldapmanager.Empty
+	4,  // 79: ldapmanager.LDAPManager.NewGroup:output_type -> ldapmanager.Empty
+	4,  // 80: ldapmanager.LDAPManager.DeleteGroup:output_type -> ldapmanager.Empty
+	4,  // 81: ldapmanager.LDAPManager.UpdateGroup:output_type -> ldapmanager.Empty
+	24, // 82: ldapmanager.LDAPManager.GetGroupList:output_type -> ldapmanager.GroupList
+	24, // 83: ldapmanager.LDAPManager.GetUserGroups:output_type -> ldapmanager.GroupList
+	24, // 84: ldapmanager.LDAPManager.GetOwnedGroups:output_type -> ldapmanager.GroupList
+	26, // 85: ldapmanager.LDAPManager.IsGroupMember:output_type -> ldapmanager.GroupMemberStatus
+	29, // 86: ldapmanager.LDAPManager.GetGroup:output_type -> ldapmanager.Group
+	4,  // 87: ldapmanager.LDAPManager.AddGroupMember:output_type -> ldapmanager.Empty
+	4,  // 88: ldapmanager.LDAPManager.DeleteGroupMember:output_type -> ldapmanager.Empty
+	34, // 89: ldapmanager.LDAPManager.RepairGroupMembership:output_type -> ldapmanager.GroupMembershipRepairs
+	35, // 90: ldapmanager.LDAPManager.RequestGroupMembership:output_type -> ldapmanager.JoinRequest
+	36, // 91: ldapmanager.LDAPManager.ListPendingRequests:output_type -> ldapmanager.JoinRequestList
+	4,  // 92: ldapmanager.LDAPManager.ApproveRequest:output_type -> ldapmanager.Empty
+	4,  // 93: ldapmanager.LDAPManager.DenyRequest:output_type -> ldapmanager.Empty
+	51, // 94: ldapmanager.LDAPManager.GetAuditLog:output_type -> ldapmanager.AuditLog
+	55, // 95: ldapmanager.LDAPManager.GetPwdPolicyList:output_type -> ldapmanager.PwdPolicyList
+	54, // 96: ldapmanager.LDAPManager.GetPwdPolicy:output_type -> ldapmanager.PwdPolicy
+	4,  // 97: ldapmanager.LDAPManager.NewPwdPolicy:output_type -> ldapmanager.Empty
+	4,  // 98: ldapmanager.LDAPManager.UpdatePwdPolicy:output_type -> ldapmanager.Empty
+	4,  // 99: ldapmanager.LDAPManager.DeletePwdPolicy:output_type -> ldapmanager.Empty
+	60, // [60:100] is the sub-list for method output_type
+	20, // [20:60] is the sub-list for method input_type
+	19, // [19:20] is the sub-list for extension type_name
+	17, // [17:19] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_ldap_manager_proto_init() }
@@ -1986,8 +4823,152 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetUserListRequest); i {
+		file_ldap_manager_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUserListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*User); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthenticateUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAccountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Account); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewAccountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkAccount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkNewAccountsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkAccountResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkNewAccountsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateAccountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteAccountRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1998,8 +4979,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*User); i {
+		file_ldap_manager_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LockAccountRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2010,8 +4991,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserList); i {
+		file_ldap_manager_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnlockAccountRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2022,8 +5003,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AuthenticateUserRequest); i {
+		file_ldap_manager_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewGroupRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2034,8 +5015,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetAccountRequest); i {
+		file_ldap_manager_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteGroupRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2046,8 +5027,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Account); i {
+		file_ldap_manager_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateGroupRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2058,8 +5039,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewAccountRequest); i {
+		file_ldap_manager_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGroupListRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2070,8 +5051,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateAccountRequest); i {
+		file_ldap_manager_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2082,8 +5063,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteAccountRequest); i {
+		file_ldap_manager_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IsGroupMemberRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2094,8 +5075,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewGroupRequest); i {
+		file_ldap_manager_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupMemberStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2106,8 +5087,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteGroupRequest); i {
+		file_ldap_manager_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGroupRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2118,8 +5099,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateGroupRequest); i {
+		file_ldap_manager_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUserGroupsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2130,8 +5111,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetGroupListRequest); i {
+		file_ldap_manager_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Group); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2142,8 +5123,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GroupList); i {
+		file_ldap_manager_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOwnedGroupsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2154,8 +5135,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IsGroupMemberRequest); i {
+		file_ldap_manager_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupMember); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2166,8 +5147,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GroupMemberStatus); i {
+		file_ldap_manager_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepairGroupMembershipRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2178,8 +5159,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetGroupRequest); i {
+		file_ldap_manager_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupMembershipRepair); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2190,8 +5171,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetUserGroupsRequest); i {
+		file_ldap_manager_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupMembershipRepairs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2202,8 +5183,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Group); i {
+		file_ldap_manager_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2214,8 +5195,8 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GroupMember); i {
+		file_ldap_manager_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinRequestList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2226,7 +5207,31 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_ldap_manager_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPendingRequestsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinRequestDecision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ChangePasswordRequest); i {
 			case 0:
 				return &v.state
@@ -2238,7 +5243,7 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_ldap_manager_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LoginRequest); i {
 			case 0:
 				return &v.state
@@ -2250,7 +5255,7 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
-		file_ldap_manager_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_ldap_manager_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Token); i {
 			case 0:
 				return &v.state
@@ -2262,15 +5267,219 @@ func file_ldap_manager_proto_init() {
 				return nil
 			}
 		}
+		file_ldap_manager_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshTokenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeSessionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoginOTPRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnrollTOTPRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TOTPEnrollment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfirmTOTPRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DisableTOTPRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAuditLogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PasswordResetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetPasswordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PwdPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PwdPolicyList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPwdPolicyListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPwdPolicyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ldap_manager_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeletePwdPolicyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ldap_manager_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   25,
-			NumExtensions: 1,
+			NumEnums:      4,
+			NumMessages:   56,
+			NumExtensions: 2,
 			NumServices:   1,
 		},
 		GoTypes:           file_ldap_manager_proto_goTypes,
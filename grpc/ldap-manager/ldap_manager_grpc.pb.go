@@ -20,12 +20,24 @@ const _ = grpc.SupportPackageIsVersion6
 type LDAPManagerClient interface {
 	// Authentication
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*Token, error)
+	LoginOTP(ctx context.Context, in *LoginOTPRequest, opts ...grpc.CallOption) (*Token, error)
+	EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*Empty, error)
+	DisableTOTP(ctx context.Context, in *DisableTOTPRequest, opts ...grpc.CallOption) (*Empty, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*Token, error)
+	Logout(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	RevokeSessions(ctx context.Context, in *RevokeSessionsRequest, opts ...grpc.CallOption) (*Empty, error)
+	RequestPasswordReset(ctx context.Context, in *PasswordResetRequest, opts ...grpc.CallOption) (*Empty, error)
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*Empty, error)
 	// Accounts
 	GetUserList(ctx context.Context, in *GetUserListRequest, opts ...grpc.CallOption) (*UserList, error)
 	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*User, error)
 	NewAccount(ctx context.Context, in *NewAccountRequest, opts ...grpc.CallOption) (*Empty, error)
+	BulkNewAccounts(ctx context.Context, in *BulkNewAccountsRequest, opts ...grpc.CallOption) (*BulkNewAccountsResponse, error)
 	UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*Token, error)
 	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*Empty, error)
+	LockAccount(ctx context.Context, in *LockAccountRequest, opts ...grpc.CallOption) (*Empty, error)
+	UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*Empty, error)
 	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*Empty, error)
 	// Groups
 	NewGroup(ctx context.Context, in *NewGroupRequest, opts ...grpc.CallOption) (*Empty, error)
@@ -33,11 +45,28 @@ type LDAPManagerClient interface {
 	UpdateGroup(ctx context.Context, in *UpdateGroupRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetGroupList(ctx context.Context, in *GetGroupListRequest, opts ...grpc.CallOption) (*GroupList, error)
 	GetUserGroups(ctx context.Context, in *GetUserGroupsRequest, opts ...grpc.CallOption) (*GroupList, error)
+	GetOwnedGroups(ctx context.Context, in *GetOwnedGroupsRequest, opts ...grpc.CallOption) (*GroupList, error)
 	// Group members
 	IsGroupMember(ctx context.Context, in *IsGroupMemberRequest, opts ...grpc.CallOption) (*GroupMemberStatus, error)
+	// GetGroup required admin privileges before roles were introduced. READ_GROUPS additionally grants it to
+	// the helpdesk, group owners and auditors, regular users only see their own groups through GetUserGroups.
 	GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*Group, error)
 	AddGroupMember(ctx context.Context, in *GroupMember, opts ...grpc.CallOption) (*Empty, error)
 	DeleteGroupMember(ctx context.Context, in *GroupMember, opts ...grpc.CallOption) (*Empty, error)
+	RepairGroupMembership(ctx context.Context, in *RepairGroupMembershipRequest, opts ...grpc.CallOption) (*GroupMembershipRepairs, error)
+	// Group join requests
+	RequestGroupMembership(ctx context.Context, in *GroupMember, opts ...grpc.CallOption) (*JoinRequest, error)
+	ListPendingRequests(ctx context.Context, in *ListPendingRequestsRequest, opts ...grpc.CallOption) (*JoinRequestList, error)
+	ApproveRequest(ctx context.Context, in *JoinRequestDecision, opts ...grpc.CallOption) (*Empty, error)
+	DenyRequest(ctx context.Context, in *JoinRequestDecision, opts ...grpc.CallOption) (*Empty, error)
+	// Audit
+	GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*AuditLog, error)
+	// Password policies
+	GetPwdPolicyList(ctx context.Context, in *GetPwdPolicyListRequest, opts ...grpc.CallOption) (*PwdPolicyList, error)
+	GetPwdPolicy(ctx context.Context, in *GetPwdPolicyRequest, opts ...grpc.CallOption) (*PwdPolicy, error)
+	NewPwdPolicy(ctx context.Context, in *PwdPolicy, opts ...grpc.CallOption) (*Empty, error)
+	UpdatePwdPolicy(ctx context.Context, in *PwdPolicy, opts ...grpc.CallOption) (*Empty, error)
+	DeletePwdPolicy(ctx context.Context, in *DeletePwdPolicyRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type lDAPManagerClient struct {
@@ -57,6 +86,87 @@ func (c *lDAPManagerClient) Login(ctx context.Context, in *LoginRequest, opts ..
 	return out, nil
 }
 
+func (c *lDAPManagerClient) LoginOTP(ctx context.Context, in *LoginOTPRequest, opts ...grpc.CallOption) (*Token, error) {
+	out := new(Token)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/LoginOTP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) EnrollTOTP(ctx context.Context, in *EnrollTOTPRequest, opts ...grpc.CallOption) (*TOTPEnrollment, error) {
+	out := new(TOTPEnrollment)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/EnrollTOTP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) ConfirmTOTP(ctx context.Context, in *ConfirmTOTPRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/ConfirmTOTP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) DisableTOTP(ctx context.Context, in *DisableTOTPRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/DisableTOTP", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*Token, error) {
+	out := new(Token)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/RefreshToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) Logout(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) RevokeSessions(ctx context.Context, in *RevokeSessionsRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/RevokeSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) RequestPasswordReset(ctx context.Context, in *PasswordResetRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/RequestPasswordReset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/ResetPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lDAPManagerClient) GetUserList(ctx context.Context, in *GetUserListRequest, opts ...grpc.CallOption) (*UserList, error) {
 	out := new(UserList)
 	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/GetUserList", in, out, opts...)
@@ -84,6 +194,15 @@ func (c *lDAPManagerClient) NewAccount(ctx context.Context, in *NewAccountReques
 	return out, nil
 }
 
+func (c *lDAPManagerClient) BulkNewAccounts(ctx context.Context, in *BulkNewAccountsRequest, opts ...grpc.CallOption) (*BulkNewAccountsResponse, error) {
+	out := new(BulkNewAccountsResponse)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/BulkNewAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lDAPManagerClient) UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*Token, error) {
 	out := new(Token)
 	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/UpdateAccount", in, out, opts...)
@@ -102,6 +221,24 @@ func (c *lDAPManagerClient) DeleteAccount(ctx context.Context, in *DeleteAccount
 	return out, nil
 }
 
+func (c *lDAPManagerClient) LockAccount(ctx context.Context, in *LockAccountRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/LockAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/UnlockAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lDAPManagerClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/ChangePassword", in, out, opts...)
@@ -156,6 +293,15 @@ func (c *lDAPManagerClient) GetUserGroups(ctx context.Context, in *GetUserGroups
 	return out, nil
 }
 
+func (c *lDAPManagerClient) GetOwnedGroups(ctx context.Context, in *GetOwnedGroupsRequest, opts ...grpc.CallOption) (*GroupList, error) {
+	out := new(GroupList)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/GetOwnedGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lDAPManagerClient) IsGroupMember(ctx context.Context, in *IsGroupMemberRequest, opts ...grpc.CallOption) (*GroupMemberStatus, error) {
 	out := new(GroupMemberStatus)
 	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/IsGroupMember", in, out, opts...)
@@ -192,18 +338,129 @@ func (c *lDAPManagerClient) DeleteGroupMember(ctx context.Context, in *GroupMemb
 	return out, nil
 }
 
+func (c *lDAPManagerClient) RepairGroupMembership(ctx context.Context, in *RepairGroupMembershipRequest, opts ...grpc.CallOption) (*GroupMembershipRepairs, error) {
+	out := new(GroupMembershipRepairs)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/RepairGroupMembership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) RequestGroupMembership(ctx context.Context, in *GroupMember, opts ...grpc.CallOption) (*JoinRequest, error) {
+	out := new(JoinRequest)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/RequestGroupMembership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) ListPendingRequests(ctx context.Context, in *ListPendingRequestsRequest, opts ...grpc.CallOption) (*JoinRequestList, error) {
+	out := new(JoinRequestList)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/ListPendingRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) ApproveRequest(ctx context.Context, in *JoinRequestDecision, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/ApproveRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) DenyRequest(ctx context.Context, in *JoinRequestDecision, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/DenyRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*AuditLog, error) {
+	out := new(AuditLog)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/GetAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) GetPwdPolicyList(ctx context.Context, in *GetPwdPolicyListRequest, opts ...grpc.CallOption) (*PwdPolicyList, error) {
+	out := new(PwdPolicyList)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/GetPwdPolicyList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) GetPwdPolicy(ctx context.Context, in *GetPwdPolicyRequest, opts ...grpc.CallOption) (*PwdPolicy, error) {
+	out := new(PwdPolicy)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/GetPwdPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) NewPwdPolicy(ctx context.Context, in *PwdPolicy, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/NewPwdPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) UpdatePwdPolicy(ctx context.Context, in *PwdPolicy, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/UpdatePwdPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lDAPManagerClient) DeletePwdPolicy(ctx context.Context, in *DeletePwdPolicyRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/ldapmanager.LDAPManager/DeletePwdPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LDAPManagerServer is the server API for LDAPManager service.
 // All implementations must embed UnimplementedLDAPManagerServer
 // for forward compatibility
 type LDAPManagerServer interface {
 	// Authentication
 	Login(context.Context, *LoginRequest) (*Token, error)
+	LoginOTP(context.Context, *LoginOTPRequest) (*Token, error)
+	EnrollTOTP(context.Context, *EnrollTOTPRequest) (*TOTPEnrollment, error)
+	ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*Empty, error)
+	DisableTOTP(context.Context, *DisableTOTPRequest) (*Empty, error)
+	RefreshToken(context.Context, *RefreshTokenRequest) (*Token, error)
+	Logout(context.Context, *Empty) (*Empty, error)
+	RevokeSessions(context.Context, *RevokeSessionsRequest) (*Empty, error)
+	RequestPasswordReset(context.Context, *PasswordResetRequest) (*Empty, error)
+	ResetPassword(context.Context, *ResetPasswordRequest) (*Empty, error)
 	// Accounts
 	GetUserList(context.Context, *GetUserListRequest) (*UserList, error)
 	GetAccount(context.Context, *GetAccountRequest) (*User, error)
 	NewAccount(context.Context, *NewAccountRequest) (*Empty, error)
+	BulkNewAccounts(context.Context, *BulkNewAccountsRequest) (*BulkNewAccountsResponse, error)
 	UpdateAccount(context.Context, *UpdateAccountRequest) (*Token, error)
 	DeleteAccount(context.Context, *DeleteAccountRequest) (*Empty, error)
+	LockAccount(context.Context, *LockAccountRequest) (*Empty, error)
+	UnlockAccount(context.Context, *UnlockAccountRequest) (*Empty, error)
 	ChangePassword(context.Context, *ChangePasswordRequest) (*Empty, error)
 	// Groups
 	NewGroup(context.Context, *NewGroupRequest) (*Empty, error)
@@ -211,11 +468,28 @@ type LDAPManagerServer interface {
 	UpdateGroup(context.Context, *UpdateGroupRequest) (*Empty, error)
 	GetGroupList(context.Context, *GetGroupListRequest) (*GroupList, error)
 	GetUserGroups(context.Context, *GetUserGroupsRequest) (*GroupList, error)
+	GetOwnedGroups(context.Context, *GetOwnedGroupsRequest) (*GroupList, error)
 	// Group members
 	IsGroupMember(context.Context, *IsGroupMemberRequest) (*GroupMemberStatus, error)
+	// GetGroup required admin privileges before roles were introduced. READ_GROUPS additionally grants it to
+	// the helpdesk, group owners and auditors, regular users only see their own groups through GetUserGroups.
 	GetGroup(context.Context, *GetGroupRequest) (*Group, error)
 	AddGroupMember(context.Context, *GroupMember) (*Empty, error)
 	DeleteGroupMember(context.Context, *GroupMember) (*Empty, error)
+	RepairGroupMembership(context.Context, *RepairGroupMembershipRequest) (*GroupMembershipRepairs, error)
+	// Group join requests
+	RequestGroupMembership(context.Context, *GroupMember) (*JoinRequest, error)
+	ListPendingRequests(context.Context, *ListPendingRequestsRequest) (*JoinRequestList, error)
+	ApproveRequest(context.Context, *JoinRequestDecision) (*Empty, error)
+	DenyRequest(context.Context, *JoinRequestDecision) (*Empty, error)
+	// Audit
+	GetAuditLog(context.Context, *GetAuditLogRequest) (*AuditLog, error)
+	// Password policies
+	GetPwdPolicyList(context.Context, *GetPwdPolicyListRequest) (*PwdPolicyList, error)
+	GetPwdPolicy(context.Context, *GetPwdPolicyRequest) (*PwdPolicy, error)
+	NewPwdPolicy(context.Context, *PwdPolicy) (*Empty, error)
+	UpdatePwdPolicy(context.Context, *PwdPolicy) (*Empty, error)
+	DeletePwdPolicy(context.Context, *DeletePwdPolicyRequest) (*Empty, error)
 	mustEmbedUnimplementedLDAPManagerServer()
 }
 
@@ -226,6 +500,33 @@ type UnimplementedLDAPManagerServer struct {
 func (*UnimplementedLDAPManagerServer) Login(context.Context, *LoginRequest) (*Token, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
+func (*UnimplementedLDAPManagerServer) LoginOTP(context.Context, *LoginOTPRequest) (*Token, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoginOTP not implemented")
+}
+func (*UnimplementedLDAPManagerServer) EnrollTOTP(context.Context, *EnrollTOTPRequest) (*TOTPEnrollment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTOTP not implemented")
+}
+func (*UnimplementedLDAPManagerServer) ConfirmTOTP(context.Context, *ConfirmTOTPRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTP not implemented")
+}
+func (*UnimplementedLDAPManagerServer) DisableTOTP(context.Context, *DisableTOTPRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTOTP not implemented")
+}
+func (*UnimplementedLDAPManagerServer) RefreshToken(context.Context, *RefreshTokenRequest) (*Token, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (*UnimplementedLDAPManagerServer) Logout(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (*UnimplementedLDAPManagerServer) RevokeSessions(context.Context, *RevokeSessionsRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSessions not implemented")
+}
+func (*UnimplementedLDAPManagerServer) RequestPasswordReset(context.Context, *PasswordResetRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPasswordReset not implemented")
+}
+func (*UnimplementedLDAPManagerServer) ResetPassword(context.Context, *ResetPasswordRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
 func (*UnimplementedLDAPManagerServer) GetUserList(context.Context, *GetUserListRequest) (*UserList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserList not implemented")
 }
@@ -235,12 +536,21 @@ func (*UnimplementedLDAPManagerServer) GetAccount(context.Context, *GetAccountRe
 func (*UnimplementedLDAPManagerServer) NewAccount(context.Context, *NewAccountRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewAccount not implemented")
 }
+func (*UnimplementedLDAPManagerServer) BulkNewAccounts(context.Context, *BulkNewAccountsRequest) (*BulkNewAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkNewAccounts not implemented")
+}
 func (*UnimplementedLDAPManagerServer) UpdateAccount(context.Context, *UpdateAccountRequest) (*Token, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateAccount not implemented")
 }
 func (*UnimplementedLDAPManagerServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
 }
+func (*UnimplementedLDAPManagerServer) LockAccount(context.Context, *LockAccountRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockAccount not implemented")
+}
+func (*UnimplementedLDAPManagerServer) UnlockAccount(context.Context, *UnlockAccountRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockAccount not implemented")
+}
 func (*UnimplementedLDAPManagerServer) ChangePassword(context.Context, *ChangePasswordRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
 }
@@ -259,6 +569,9 @@ func (*UnimplementedLDAPManagerServer) GetGroupList(context.Context, *GetGroupLi
 func (*UnimplementedLDAPManagerServer) GetUserGroups(context.Context, *GetUserGroupsRequest) (*GroupList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserGroups not implemented")
 }
+func (*UnimplementedLDAPManagerServer) GetOwnedGroups(context.Context, *GetOwnedGroupsRequest) (*GroupList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOwnedGroups not implemented")
+}
 func (*UnimplementedLDAPManagerServer) IsGroupMember(context.Context, *IsGroupMemberRequest) (*GroupMemberStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsGroupMember not implemented")
 }
@@ -271,6 +584,39 @@ func (*UnimplementedLDAPManagerServer) AddGroupMember(context.Context, *GroupMem
 func (*UnimplementedLDAPManagerServer) DeleteGroupMember(context.Context, *GroupMember) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroupMember not implemented")
 }
+func (*UnimplementedLDAPManagerServer) RepairGroupMembership(context.Context, *RepairGroupMembershipRequest) (*GroupMembershipRepairs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepairGroupMembership not implemented")
+}
+func (*UnimplementedLDAPManagerServer) RequestGroupMembership(context.Context, *GroupMember) (*JoinRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestGroupMembership not implemented")
+}
+func (*UnimplementedLDAPManagerServer) ListPendingRequests(context.Context, *ListPendingRequestsRequest) (*JoinRequestList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingRequests not implemented")
+}
+func (*UnimplementedLDAPManagerServer) ApproveRequest(context.Context, *JoinRequestDecision) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveRequest not implemented")
+}
+func (*UnimplementedLDAPManagerServer) DenyRequest(context.Context, *JoinRequestDecision) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyRequest not implemented")
+}
+func (*UnimplementedLDAPManagerServer) GetAuditLog(context.Context, *GetAuditLogRequest) (*AuditLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLog not implemented")
+}
+func (*UnimplementedLDAPManagerServer) GetPwdPolicyList(context.Context, *GetPwdPolicyListRequest) (*PwdPolicyList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPwdPolicyList not implemented")
+}
+func (*UnimplementedLDAPManagerServer) GetPwdPolicy(context.Context, *GetPwdPolicyRequest) (*PwdPolicy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPwdPolicy not implemented")
+}
+func (*UnimplementedLDAPManagerServer) NewPwdPolicy(context.Context, *PwdPolicy) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewPwdPolicy not implemented")
+}
+func (*UnimplementedLDAPManagerServer) UpdatePwdPolicy(context.Context, *PwdPolicy) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePwdPolicy not implemented")
+}
+func (*UnimplementedLDAPManagerServer) DeletePwdPolicy(context.Context, *DeletePwdPolicyRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePwdPolicy not implemented")
+}
 func (*UnimplementedLDAPManagerServer) mustEmbedUnimplementedLDAPManagerServer() {}
 
 func RegisterLDAPManagerServer(s *grpc.Server, srv LDAPManagerServer) {
@@ -295,215 +641,449 @@ func _LDAPManager_Login_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_GetUserList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserListRequest)
+func _LDAPManager_LoginOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginOTPRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).GetUserList(ctx, in)
+		return srv.(LDAPManagerServer).LoginOTP(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/GetUserList",
+		FullMethod: "/ldapmanager.LDAPManager/LoginOTP",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LDAPManagerServer).GetUserList(ctx, req.(*GetUserListRequest))
+		return srv.(LDAPManagerServer).LoginOTP(ctx, req.(*LoginOTPRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAccountRequest)
+func _LDAPManager_EnrollTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollTOTPRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).GetAccount(ctx, in)
+		return srv.(LDAPManagerServer).EnrollTOTP(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/GetAccount",
+		FullMethod: "/ldapmanager.LDAPManager/EnrollTOTP",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LDAPManagerServer).GetAccount(ctx, req.(*GetAccountRequest))
+		return srv.(LDAPManagerServer).EnrollTOTP(ctx, req.(*EnrollTOTPRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_NewAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewAccountRequest)
+func _LDAPManager_ConfirmTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTOTPRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).NewAccount(ctx, in)
+		return srv.(LDAPManagerServer).ConfirmTOTP(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/NewAccount",
+		FullMethod: "/ldapmanager.LDAPManager/ConfirmTOTP",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LDAPManagerServer).NewAccount(ctx, req.(*NewAccountRequest))
+		return srv.(LDAPManagerServer).ConfirmTOTP(ctx, req.(*ConfirmTOTPRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_UpdateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateAccountRequest)
+func _LDAPManager_DisableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTOTPRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).UpdateAccount(ctx, in)
+		return srv.(LDAPManagerServer).DisableTOTP(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/UpdateAccount",
+		FullMethod: "/ldapmanager.LDAPManager/DisableTOTP",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LDAPManagerServer).UpdateAccount(ctx, req.(*UpdateAccountRequest))
+		return srv.(LDAPManagerServer).DisableTOTP(ctx, req.(*DisableTOTPRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteAccountRequest)
+func _LDAPManager_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).DeleteAccount(ctx, in)
+		return srv.(LDAPManagerServer).RefreshToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/DeleteAccount",
+		FullMethod: "/ldapmanager.LDAPManager/RefreshToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LDAPManagerServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+		return srv.(LDAPManagerServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangePasswordRequest)
+func _LDAPManager_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).ChangePassword(ctx, in)
+		return srv.(LDAPManagerServer).Logout(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/ChangePassword",
+		FullMethod: "/ldapmanager.LDAPManager/Logout",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LDAPManagerServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+		return srv.(LDAPManagerServer).Logout(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_NewGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewGroupRequest)
+func _LDAPManager_RevokeSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).NewGroup(ctx, in)
+		return srv.(LDAPManagerServer).RevokeSessions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/NewGroup",
+		FullMethod: "/ldapmanager.LDAPManager/RevokeSessions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LDAPManagerServer).NewGroup(ctx, req.(*NewGroupRequest))
+		return srv.(LDAPManagerServer).RevokeSessions(ctx, req.(*RevokeSessionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteGroupRequest)
+func _LDAPManager_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasswordResetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).DeleteGroup(ctx, in)
+		return srv.(LDAPManagerServer).RequestPasswordReset(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/DeleteGroup",
+		FullMethod: "/ldapmanager.LDAPManager/RequestPasswordReset",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LDAPManagerServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
+		return srv.(LDAPManagerServer).RequestPasswordReset(ctx, req.(*PasswordResetRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateGroupRequest)
+func _LDAPManager_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).UpdateGroup(ctx, in)
+		return srv.(LDAPManagerServer).ResetPassword(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/UpdateGroup",
+		FullMethod: "/ldapmanager.LDAPManager/ResetPassword",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LDAPManagerServer).UpdateGroup(ctx, req.(*UpdateGroupRequest))
+		return srv.(LDAPManagerServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_GetGroupList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetGroupListRequest)
+func _LDAPManager_GetUserList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserListRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).GetGroupList(ctx, in)
+		return srv.(LDAPManagerServer).GetUserList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/GetGroupList",
+		FullMethod: "/ldapmanager.LDAPManager/GetUserList",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LDAPManagerServer).GetGroupList(ctx, req.(*GetGroupListRequest))
+		return srv.(LDAPManagerServer).GetUserList(ctx, req.(*GetUserListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_GetUserGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserGroupsRequest)
+func _LDAPManager_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).GetUserGroups(ctx, in)
+		return srv.(LDAPManagerServer).GetAccount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/GetUserGroups",
+		FullMethod: "/ldapmanager.LDAPManager/GetAccount",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LDAPManagerServer).GetUserGroups(ctx, req.(*GetUserGroupsRequest))
+		return srv.(LDAPManagerServer).GetAccount(ctx, req.(*GetAccountRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LDAPManager_IsGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IsGroupMemberRequest)
+func _LDAPManager_NewAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewAccountRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LDAPManagerServer).IsGroupMember(ctx, in)
+		return srv.(LDAPManagerServer).NewAccount(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ldapmanager.LDAPManager/IsGroupMember",
+		FullMethod: "/ldapmanager.LDAPManager/NewAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).NewAccount(ctx, req.(*NewAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_BulkNewAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkNewAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).BulkNewAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/BulkNewAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).BulkNewAccounts(ctx, req.(*BulkNewAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_UpdateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).UpdateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/UpdateAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).UpdateAccount(ctx, req.(*UpdateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/DeleteAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).DeleteAccount(ctx, req.(*DeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_LockAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).LockAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/LockAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).LockAccount(ctx, req.(*LockAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_UnlockAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).UnlockAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/UnlockAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).UnlockAccount(ctx, req.(*UnlockAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/ChangePassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_NewGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).NewGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/NewGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).NewGroup(ctx, req.(*NewGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/DeleteGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).DeleteGroup(ctx, req.(*DeleteGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_UpdateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).UpdateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/UpdateGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).UpdateGroup(ctx, req.(*UpdateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_GetGroupList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).GetGroupList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/GetGroupList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).GetGroupList(ctx, req.(*GetGroupListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_GetUserGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).GetUserGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/GetUserGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).GetUserGroups(ctx, req.(*GetUserGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_GetOwnedGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOwnedGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).GetOwnedGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/GetOwnedGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).GetOwnedGroups(ctx, req.(*GetOwnedGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_IsGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsGroupMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).IsGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/IsGroupMember",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(LDAPManagerServer).IsGroupMember(ctx, req.(*IsGroupMemberRequest))
@@ -565,6 +1145,204 @@ func _LDAPManager_DeleteGroupMember_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LDAPManager_RepairGroupMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepairGroupMembershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).RepairGroupMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/RepairGroupMembership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).RepairGroupMembership(ctx, req.(*RepairGroupMembershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_RequestGroupMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupMember)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).RequestGroupMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/RequestGroupMembership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).RequestGroupMembership(ctx, req.(*GroupMember))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_ListPendingRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).ListPendingRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/ListPendingRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).ListPendingRequests(ctx, req.(*ListPendingRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_ApproveRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequestDecision)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).ApproveRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/ApproveRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).ApproveRequest(ctx, req.(*JoinRequestDecision))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_DenyRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequestDecision)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).DenyRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/DenyRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).DenyRequest(ctx, req.(*JoinRequestDecision))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_GetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).GetAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/GetAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).GetAuditLog(ctx, req.(*GetAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_GetPwdPolicyList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPwdPolicyListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).GetPwdPolicyList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/GetPwdPolicyList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).GetPwdPolicyList(ctx, req.(*GetPwdPolicyListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_GetPwdPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPwdPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).GetPwdPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/GetPwdPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).GetPwdPolicy(ctx, req.(*GetPwdPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_NewPwdPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PwdPolicy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).NewPwdPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/NewPwdPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).NewPwdPolicy(ctx, req.(*PwdPolicy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_UpdatePwdPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PwdPolicy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).UpdatePwdPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/UpdatePwdPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).UpdatePwdPolicy(ctx, req.(*PwdPolicy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LDAPManager_DeletePwdPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePwdPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LDAPManagerServer).DeletePwdPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ldapmanager.LDAPManager/DeletePwdPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LDAPManagerServer).DeletePwdPolicy(ctx, req.(*DeletePwdPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _LDAPManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "ldapmanager.LDAPManager",
 	HandlerType: (*LDAPManagerServer)(nil),
@@ -573,6 +1351,42 @@ var _LDAPManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Login",
 			Handler:    _LDAPManager_Login_Handler,
 		},
+		{
+			MethodName: "LoginOTP",
+			Handler:    _LDAPManager_LoginOTP_Handler,
+		},
+		{
+			MethodName: "EnrollTOTP",
+			Handler:    _LDAPManager_EnrollTOTP_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTP",
+			Handler:    _LDAPManager_ConfirmTOTP_Handler,
+		},
+		{
+			MethodName: "DisableTOTP",
+			Handler:    _LDAPManager_DisableTOTP_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _LDAPManager_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _LDAPManager_Logout_Handler,
+		},
+		{
+			MethodName: "RevokeSessions",
+			Handler:    _LDAPManager_RevokeSessions_Handler,
+		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _LDAPManager_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _LDAPManager_ResetPassword_Handler,
+		},
 		{
 			MethodName: "GetUserList",
 			Handler:    _LDAPManager_GetUserList_Handler,
@@ -585,6 +1399,10 @@ var _LDAPManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "NewAccount",
 			Handler:    _LDAPManager_NewAccount_Handler,
 		},
+		{
+			MethodName: "BulkNewAccounts",
+			Handler:    _LDAPManager_BulkNewAccounts_Handler,
+		},
 		{
 			MethodName: "UpdateAccount",
 			Handler:    _LDAPManager_UpdateAccount_Handler,
@@ -593,6 +1411,14 @@ var _LDAPManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAccount",
 			Handler:    _LDAPManager_DeleteAccount_Handler,
 		},
+		{
+			MethodName: "LockAccount",
+			Handler:    _LDAPManager_LockAccount_Handler,
+		},
+		{
+			MethodName: "UnlockAccount",
+			Handler:    _LDAPManager_UnlockAccount_Handler,
+		},
 		{
 			MethodName: "ChangePassword",
 			Handler:    _LDAPManager_ChangePassword_Handler,
@@ -617,6 +1443,10 @@ var _LDAPManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserGroups",
 			Handler:    _LDAPManager_GetUserGroups_Handler,
 		},
+		{
+			MethodName: "GetOwnedGroups",
+			Handler:    _LDAPManager_GetOwnedGroups_Handler,
+		},
 		{
 			MethodName: "IsGroupMember",
 			Handler:    _LDAPManager_IsGroupMember_Handler,
@@ -633,6 +1463,50 @@ var _LDAPManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteGroupMember",
 			Handler:    _LDAPManager_DeleteGroupMember_Handler,
 		},
+		{
+			MethodName: "RepairGroupMembership",
+			Handler:    _LDAPManager_RepairGroupMembership_Handler,
+		},
+		{
+			MethodName: "RequestGroupMembership",
+			Handler:    _LDAPManager_RequestGroupMembership_Handler,
+		},
+		{
+			MethodName: "ListPendingRequests",
+			Handler:    _LDAPManager_ListPendingRequests_Handler,
+		},
+		{
+			MethodName: "ApproveRequest",
+			Handler:    _LDAPManager_ApproveRequest_Handler,
+		},
+		{
+			MethodName: "DenyRequest",
+			Handler:    _LDAPManager_DenyRequest_Handler,
+		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    _LDAPManager_GetAuditLog_Handler,
+		},
+		{
+			MethodName: "GetPwdPolicyList",
+			Handler:    _LDAPManager_GetPwdPolicyList_Handler,
+		},
+		{
+			MethodName: "GetPwdPolicy",
+			Handler:    _LDAPManager_GetPwdPolicy_Handler,
+		},
+		{
+			MethodName: "NewPwdPolicy",
+			Handler:    _LDAPManager_NewPwdPolicy_Handler,
+		},
+		{
+			MethodName: "UpdatePwdPolicy",
+			Handler:    _LDAPManager_UpdatePwdPolicy_Handler,
+		},
+		{
+			MethodName: "DeletePwdPolicy",
+			Handler:    _LDAPManager_DeletePwdPolicy_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ldap_manager.proto",
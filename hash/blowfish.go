@@ -30,6 +30,15 @@ const (
 	minHashSize        = 59
 )
 
+const (
+	// MinBcryptCost is the lowest cost accepted by crypt(3) implementations
+	MinBcryptCost = 4
+	// MaxBcryptCost ...
+	MaxBcryptCost = 31
+	// DefaultBcryptCost ...
+	DefaultBcryptCost = 12
+)
+
 // magicCipherData is an IV for the 64 Blowfish encryption calls in
 // bcrypt(). It's the string "OrpheanBeholderScryDoubt" in big-endian bytes.
 var magicCipherData = []byte{
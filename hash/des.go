@@ -0,0 +1,263 @@
+package hash
+
+import (
+	"errors"
+	"strings"
+)
+
+// Traditional DES crypt(3) and BSDi extended DES crypt.
+// crypt(3) modifies DES with a salt that swaps bits of the expansion (E-box) output,
+// which is why the standard library implementation can not be used.
+
+const (
+	// DefaultEXTDESRounds is the number of DES rounds used for BSDi extended DES hashes (_J9..)
+	DefaultEXTDESRounds = 725
+	maxEXTDESRounds     = 1<<24 - 1
+)
+
+var (
+	desIP = [64]byte{
+		58, 50, 42, 34, 26, 18, 10, 2, 60, 52, 44, 36, 28, 20, 12, 4,
+		62, 54, 46, 38, 30, 22, 14, 6, 64, 56, 48, 40, 32, 24, 16, 8,
+		57, 49, 41, 33, 25, 17, 9, 1, 59, 51, 43, 35, 27, 19, 11, 3,
+		61, 53, 45, 37, 29, 21, 13, 5, 63, 55, 47, 39, 31, 23, 15, 7,
+	}
+	desFP = [64]byte{
+		40, 8, 48, 16, 56, 24, 64, 32, 39, 7, 47, 15, 55, 23, 63, 31,
+		38, 6, 46, 14, 54, 22, 62, 30, 37, 5, 45, 13, 53, 21, 61, 29,
+		36, 4, 44, 12, 52, 20, 60, 28, 35, 3, 43, 11, 51, 19, 59, 27,
+		34, 2, 42, 10, 50, 18, 58, 26, 33, 1, 41, 9, 49, 17, 57, 25,
+	}
+	desE = [48]byte{
+		32, 1, 2, 3, 4, 5, 4, 5, 6, 7, 8, 9,
+		8, 9, 10, 11, 12, 13, 12, 13, 14, 15, 16, 17,
+		16, 17, 18, 19, 20, 21, 20, 21, 22, 23, 24, 25,
+		24, 25, 26, 27, 28, 29, 28, 29, 30, 31, 32, 1,
+	}
+	desP = [32]byte{
+		16, 7, 20, 21, 29, 12, 28, 17, 1, 15, 23, 26, 5, 18, 31, 10,
+		2, 8, 24, 14, 32, 27, 3, 9, 19, 13, 30, 6, 22, 11, 4, 25,
+	}
+	desPC1 = [56]byte{
+		57, 49, 41, 33, 25, 17, 9, 1, 58, 50, 42, 34, 26, 18,
+		10, 2, 59, 51, 43, 35, 27, 19, 11, 3, 60, 52, 44, 36,
+		63, 55, 47, 39, 31, 23, 15, 7, 62, 54, 46, 38, 30, 22,
+		14, 6, 61, 53, 45, 37, 29, 21, 13, 5, 28, 20, 12, 4,
+	}
+	desPC2 = [48]byte{
+		14, 17, 11, 24, 1, 5, 3, 28, 15, 6, 21, 10,
+		23, 19, 12, 4, 26, 8, 16, 7, 27, 20, 13, 2,
+		41, 52, 31, 37, 47, 55, 30, 40, 51, 45, 33, 48,
+		44, 49, 39, 56, 34, 53, 46, 42, 50, 36, 29, 32,
+	}
+	desShifts = [16]uint{1, 1, 2, 2, 2, 2, 2, 2, 1, 2, 2, 2, 2, 2, 2, 1}
+	desSBoxes = [8][64]byte{
+		{
+			14, 4, 13, 1, 2, 15, 11, 8, 3, 10, 6, 12, 5, 9, 0, 7,
+			0, 15, 7, 4, 14, 2, 13, 1, 10, 6, 12, 11, 9, 5, 3, 8,
+			4, 1, 14, 8, 13, 6, 2, 11, 15, 12, 9, 7, 3, 10, 5, 0,
+			15, 12, 8, 2, 4, 9, 1, 7, 5, 11, 3, 14, 10, 0, 6, 13,
+		},
+		{
+			15, 1, 8, 14, 6, 11, 3, 4, 9, 7, 2, 13, 12, 0, 5, 10,
+			3, 13, 4, 7, 15, 2, 8, 14, 12, 0, 1, 10, 6, 9, 11, 5,
+			0, 14, 7, 11, 10, 4, 13, 1, 5, 8, 12, 6, 9, 3, 2, 15,
+			13, 8, 10, 1, 3, 15, 4, 2, 11, 6, 7, 12, 0, 5, 14, 9,
+		},
+		{
+			10, 0, 9, 14, 6, 3, 15, 5, 1, 13, 12, 7, 11, 4, 2, 8,
+			13, 7, 0, 9, 3, 4, 6, 10, 2, 8, 5, 14, 12, 11, 15, 1,
+			13, 6, 4, 9, 8, 15, 3, 0, 11, 1, 2, 12, 5, 10, 14, 7,
+			1, 10, 13, 0, 6, 9, 8, 7, 4, 15, 14, 3, 11, 5, 2, 12,
+		},
+		{
+			7, 13, 14, 3, 0, 6, 9, 10, 1, 2, 8, 5, 11, 12, 4, 15,
+			13, 8, 11, 5, 6, 15, 0, 3, 4, 7, 2, 12, 1, 10, 14, 9,
+			10, 6, 9, 0, 12, 11, 7, 13, 15, 1, 3, 14, 5, 2, 8, 4,
+			3, 15, 0, 6, 10, 1, 13, 8, 9, 4, 5, 11, 12, 7, 2, 14,
+		},
+		{
+			2, 12, 4, 1, 7, 10, 11, 6, 8, 5, 3, 15, 13, 0, 14, 9,
+			14, 11, 2, 12, 4, 7, 13, 1, 5, 0, 15, 10, 3, 9, 8, 6,
+			4, 2, 1, 11, 10, 13, 7, 8, 15, 9, 12, 5, 6, 3, 0, 14,
+			11, 8, 12, 7, 1, 14, 2, 13, 6, 15, 0, 9, 10, 4, 5, 3,
+		},
+		{
+			12, 1, 10, 15, 9, 2, 6, 8, 0, 13, 3, 4, 14, 7, 5, 11,
+			10, 15, 4, 2, 7, 12, 9, 5, 6, 1, 13, 14, 0, 11, 3, 8,
+			9, 14, 15, 5, 2, 8, 12, 3, 7, 0, 4, 10, 1, 13, 11, 6,
+			4, 3, 2, 12, 9, 5, 15, 10, 11, 14, 1, 7, 6, 0, 8, 13,
+		},
+		{
+			4, 11, 2, 14, 15, 0, 8, 13, 3, 12, 9, 7, 5, 10, 6, 1,
+			13, 0, 11, 7, 4, 9, 1, 10, 14, 3, 5, 12, 2, 15, 8, 6,
+			1, 4, 11, 13, 12, 3, 7, 14, 10, 15, 6, 8, 0, 5, 9, 2,
+			6, 11, 13, 8, 1, 4, 10, 7, 9, 5, 0, 15, 14, 2, 3, 12,
+		},
+		{
+			13, 2, 8, 4, 6, 15, 11, 1, 10, 9, 3, 14, 5, 0, 12, 7,
+			1, 15, 13, 8, 10, 3, 7, 4, 12, 5, 6, 11, 0, 14, 9, 2,
+			7, 11, 4, 1, 9, 12, 14, 2, 0, 6, 10, 13, 15, 3, 5, 8,
+			2, 1, 14, 7, 4, 10, 8, 13, 15, 12, 9, 0, 3, 5, 6, 11,
+		},
+	}
+)
+
+// permute maps the bits of in (numbered from 1 at the most significant of its width bits) according to the table
+func permute(in uint64, width uint, table []byte) uint64 {
+	var out uint64
+	for _, bit := range table {
+		out = out<<1 | (in>>(width-uint(bit)))&1
+	}
+	return out
+}
+
+// desSubkeys computes the 16 round keys of a 64 bit key
+func desSubkeys(key uint64) [16]uint64 {
+	var subkeys [16]uint64
+	cd := permute(key, 64, desPC1[:])
+	c, d := cd>>28, cd&0xfffffff
+	for i, shift := range desShifts {
+		c = (c<<shift | c>>(28-shift)) & 0xfffffff
+		d = (d<<shift | d>>(28-shift)) & 0xfffffff
+		subkeys[i] = permute(c<<28|d, 56, desPC2[:])
+	}
+	return subkeys
+}
+
+// desSaltBits converts the salt into a mask of bits that are swapped between both halves of the E-box output
+func desSaltBits(salt uint32) uint64 {
+	var saltBits uint64
+	for i := uint(0); i < 24; i++ {
+		if salt&(1<<i) != 0 {
+			saltBits |= 0x800000 >> i
+		}
+	}
+	return saltBits
+}
+
+func desFeistel(r uint32, subkey, saltBits uint64) uint32 {
+	e := permute(uint64(r), 32, desE[:])
+	left, right := e>>24, e&0xffffff
+	swap := (left ^ right) & saltBits
+	e = (left^swap)<<24 | (right ^ swap)
+	e ^= subkey
+	var s uint64
+	for i := uint(0); i < 8; i++ {
+		six := (e >> (42 - 6*i)) & 0x3f
+		row := (six>>4)&2 | six&1
+		col := (six >> 1) & 0xf
+		s = s<<4 | uint64(desSBoxes[i][row*16+col])
+	}
+	return uint32(permute(s, 32, desP[:]))
+}
+
+// desEncrypt encrypts the block count times with the salted DES variant of crypt(3)
+func desEncrypt(block uint64, subkeys *[16]uint64, saltBits uint64, count int) uint64 {
+	for n := 0; n < count; n++ {
+		block = permute(block, 64, desIP[:])
+		l, r := uint32(block>>32), uint32(block)
+		for i := 0; i < 16; i++ {
+			l, r = r, l^desFeistel(r, subkeys[i], saltBits)
+		}
+		block = permute(uint64(r)<<32|uint64(l), 64, desFP[:])
+	}
+	return block
+}
+
+// desKey builds a DES key from up to 8 characters of the password, using the lower 7 bits of each character
+func desKey(password []byte) uint64 {
+	var key uint64
+	for i := 0; i < 8; i++ {
+		key <<= 8
+		if i < len(password) {
+			key |= uint64(password[i]<<1) & 0xff
+		}
+	}
+	return key
+}
+
+func hash64Value(c byte) (uint32, error) {
+	i := strings.IndexByte(Hash64Chars, c)
+	if i < 0 {
+		return 0, errors.New("invalid character in salt")
+	}
+	return uint32(i), nil
+}
+
+func hash64Decode(s string) (uint32, error) {
+	var value uint32
+	for i := 0; i < len(s); i++ {
+		v, err := hash64Value(s[i])
+		if err != nil {
+			return 0, err
+		}
+		value |= v << (6 * uint(i))
+	}
+	return value, nil
+}
+
+func hash64Encode(value uint32, length int) string {
+	out := make([]byte, length)
+	for i := range out {
+		out[i] = Hash64Chars[value&0x3f]
+		value >>= 6
+	}
+	return string(out)
+}
+
+// encodeDESBlock encodes the 64 bit result as 11 characters, most significant bits first
+func encodeDESBlock(block uint64) string {
+	out := make([]byte, 11)
+	for i := 0; i < 10; i++ {
+		out[i] = Hash64Chars[(block>>uint(58-6*i))&0x3f]
+	}
+	// the last character holds the remaining 4 bits padded with two zero bits
+	out[10] = Hash64Chars[(block<<2)&0x3f]
+	return string(out)
+}
+
+// DESCrypt computes the traditional crypt(3) hash of the password with a 2 character salt
+func DESCrypt(password, salt string) (string, error) {
+	if len(salt) < 2 {
+		return "", errors.New("DES crypt requires a 2 character salt")
+	}
+	saltValue, err := hash64Decode(salt[:2])
+	if err != nil {
+		return "", err
+	}
+	subkeys := desSubkeys(desKey([]byte(password)))
+	block := desEncrypt(0, &subkeys, desSaltBits(saltValue), 25)
+	return salt[:2] + encodeDESBlock(block), nil
+}
+
+// EXTDESCrypt computes the BSDi extended DES hash of the password for a setting of the form _CCCCSSSS,
+// where CCCC are the encoded number of rounds and SSSS the encoded 24 bit salt
+func EXTDESCrypt(password, setting string) (string, error) {
+	if len(setting) < 9 || setting[0] != '_' {
+		return "", errors.New("extended DES crypt requires a setting of the form _CCCCSSSS")
+	}
+	count, err := hash64Decode(setting[1:5])
+	if err != nil {
+		return "", err
+	}
+	if count == 0 {
+		return "", errors.New("extended DES crypt requires at least one round")
+	}
+	saltValue, err := hash64Decode(setting[5:9])
+	if err != nil {
+		return "", err
+	}
+
+	// passwords longer than 8 characters are folded into the key by encrypting the key with itself
+	key := []byte(password)
+	keyBlock := desKey(key)
+	subkeys := desSubkeys(keyBlock)
+	for len(key) > 8 {
+		key = key[8:]
+		keyBlock = desEncrypt(keyBlock, &subkeys, 0, 1) ^ desKey(key)
+		subkeys = desSubkeys(keyBlock)
+	}
+	block := desEncrypt(0, &subkeys, desSaltBits(saltValue), int(count))
+	return setting[:9] + encodeDESBlock(block), nil
+}
@@ -3,13 +3,11 @@ package hash
 import (
 	"crypto/md5"
 	"crypto/rand"
+	"crypto/sha1"
 	"encoding/base64"
-	"errors"
 	"fmt"
+	"math"
 
-	// "crypto/des"
-	"crypto/sha1"
-	// "math/big"
 	"github.com/GehirnInc/crypt"
 	// UNIX crypt(3)
 	_ "github.com/GehirnInc/crypt/md5_crypt"
@@ -23,17 +21,24 @@ var LDAPPasswordHashingAlgorithms = []pb.HashingAlgorithm{
 	pb.HashingAlgorithm_DEFAULT,
 	pb.HashingAlgorithm_SHA512CRYPT,
 	pb.HashingAlgorithm_SHA256CRYPT,
-	// pb.HashingAlgorithm_BLOWFISH,
-	// pb.HashingAlgorithm_EXTDES,
+	pb.HashingAlgorithm_BLOWFISH,
+	pb.HashingAlgorithm_EXTDES,
 	pb.HashingAlgorithm_MD5CRYPT,
 	pb.HashingAlgorithm_SMD5,
 	pb.HashingAlgorithm_MD5,
 	pb.HashingAlgorithm_SHA,
 	pb.HashingAlgorithm_SSHA,
-	// pb.HashingAlgorithm_CRYPT,
+	pb.HashingAlgorithm_CRYPT,
 	pb.HashingAlgorithm_CLEAR,
 }
 
+// ModulePasswordHashingAlgorithms can only be verified by slapd if the pw-argon2 and pw-pbkdf2 modules are loaded
+var ModulePasswordHashingAlgorithms = []pb.HashingAlgorithm{
+	pb.HashingAlgorithm_ARGON2ID,
+	pb.HashingAlgorithm_PBKDF2_SHA256,
+	pb.HashingAlgorithm_PBKDF2_SHA512,
+}
+
 func encodeSSHA(pw string) string {
 	// $salt = generate_salt(8)
 	// '{SSHA}' . base64_encode(sha1($password . $salt, TRUE) . $salt)
@@ -59,21 +64,21 @@ func encodeSMD5(pw string) string {
 func encodeSHA256(pw string) string {
 	// '{CRYPT}' . crypt($password, '$5$' . generate_salt(8))
 	crypt := crypt.SHA256.New()
-	hash, _ := crypt.Generate([]byte(pw), append([]byte("$5$"), generateSalt(8)...))
+	hash, _ := crypt.Generate([]byte(pw), append([]byte("$5$"), generateCryptSalt(8)...))
 	return fmt.Sprintf("{CRYPT}%s", hash)
 }
 
 func encodeSHA512(pw string) string {
 	// '{CRYPT}' . crypt($password, '$6$' . generate_salt(8));
 	crypt := crypt.SHA512.New()
-	hash, _ := crypt.Generate([]byte(pw), append([]byte("$6$"), generateSalt(8)...))
+	hash, _ := crypt.Generate([]byte(pw), append([]byte("$6$"), generateCryptSalt(8)...))
 	return fmt.Sprintf("{CRYPT}%s", hash)
 }
 
 func encodeMD5CRYPT(pw string) string {
 	// '{CRYPT}' . crypt($password, '$1$' . generate_salt(9));
 	crypt := crypt.MD5.New()
-	hash, _ := crypt.Generate([]byte(pw), append([]byte("$1$"), generateSalt(9)...))
+	hash, _ := crypt.Generate([]byte(pw), append([]byte("$1$"), generateCryptSalt(8)...))
 	return fmt.Sprintf("{CRYPT}%s", hash)
 }
 
@@ -95,48 +100,33 @@ func encodeSHA(pw string) string {
 	return fmt.Sprintf("{SHA}%s", base64.StdEncoding.EncodeToString(h.Sum(nil)))
 }
 
-func encodeBLOWFISH(pw string) string {
-	// '{CRYPT}' . crypt($password, '$2a$12$' . generate_salt(13));
-	hash, _ := bcrypt([]byte(pw), 13, generateSalt(13))
-	fmt.Println(string(hash))
-	return fmt.Sprintf("{CRYPT}%s", string(hash))
+func encodeBLOWFISH(pw string, cost int) (string, error) {
+	// '{CRYPT}' . crypt($password, '$2b$12$' . generate_salt(22));
+	salt := base64Encode(generateSalt(maxSaltSize))
+	hash, err := bcrypt([]byte(pw), cost, salt)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("{CRYPT}$2b$%02d$%s%s", cost, salt, hash), nil
 }
 
-func encodeCRYPT(pw string) string {
-	/*
-		The checksum is formed by a modified version of the DES cipher in encrypt mode:
-
-		Given a password string and a salt string.
-
-		The 2 character salt string is decoded to a 12-bit integer salt value; The salt string uses little-endian hash64 encoding.
-
-		If the password is less than 8 bytes, it’s NULL padded at the end to 8 bytes.
-
-		The lower 7 bits of the first 8 bytes of the password are used to form a 56-bit integer; with the first byte providing the most significant 7 bits, and the 8th byte providing the least significant 7 bits.
-
-		The remainder of the password (if any) is ignored.
-
-		25 repeated rounds of modified DES encryption are performed; starting with a null input block, and using the 56-bit integer from step 4 as the DES key.
-
-		The salt is used to to mutate the normal DES encrypt operation by swapping bits i and i+24 in the DES E-Box output if and only if bit i is set in the salt value. Thus, if the salt is set to 0, normal DES encryption is performed. (This was intended to prevent optimized implementations of regular DES encryption to be useful in attacking this algorithm).
-
-		The 64-bit result of the last round of step 5 is then lsb-padded with 2 zero bits.
-
-		The resulting 66-bit integer is encoded in big-endian order using the hash64-big format.
-	*/
-
+func encodeCRYPT(pw string) (string, error) {
 	// '{CRYPT}' . crypt($password, generate_salt(2));
-	// here, crypt uses CRYPT_STD_DES
-	// c, _ := des.NewCipher(append([]byte(pw), generateSalt(2)...))
+	hash, err := DESCrypt(pw, string(generateCryptSalt(2)))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("{CRYPT}%s", hash), nil
+}
 
-	/*
-		salt := "JQ"
-		h := md5.New()
-		h.Write([]byte(pw))
-	*/
-	hashed := Crypt(pw, "JQ")
-	return fmt.Sprintf("{CRYPT}%s", hashed)
-	// return fmt.Sprintf("{CRYPT}%s", )
+func encodeEXTDES(pw string, rounds int) (string, error) {
+	// '{CRYPT}' . crypt($password, '_' . encode_count(rounds) . generate_salt(4));
+	setting := "_" + hash64Encode(uint32(rounds), 4) + string(generateCryptSalt(4))
+	hash, err := EXTDESCrypt(pw, setting)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("{CRYPT}%s", hash), nil
 }
 
 // generateSalt generates a byte array containing random bytes
@@ -146,8 +136,101 @@ func generateSalt(l int) []byte {
 	return sbytes
 }
 
-// Password ...
+// generateCryptSalt generates a random salt of characters that are valid in crypt(3) hashes
+func generateCryptSalt(l int) []byte {
+	sbytes := generateSalt(l)
+	for i, b := range sbytes {
+		sbytes[i] = Hash64Chars[b&0x3f]
+	}
+	return sbytes
+}
+
+// Options configures the cost of the hashing algorithms. Zero values use the defaults.
+type Options struct {
+	// BcryptCost is the log2 of the number of bcrypt key expansion rounds (4-31)
+	BcryptCost int
+	// EXTDESRounds is the number of DES rounds of BSDi extended DES hashes (1-16777215)
+	EXTDESRounds int
+	// Argon2Time is the number of passes over the memory of argon2id
+	Argon2Time int
+	// Argon2Memory is the memory used by argon2id in KiB
+	Argon2Memory int
+	// Argon2Threads is the parallelism of argon2id (1-255)
+	Argon2Threads int
+	// PBKDF2Iterations is the number of PBKDF2 iterations
+	PBKDF2Iterations int
+}
+
+// DefaultOptions ...
+func DefaultOptions() Options {
+	return Options{
+		BcryptCost:       DefaultBcryptCost,
+		EXTDESRounds:     DefaultEXTDESRounds,
+		Argon2Time:       DefaultArgon2Time,
+		Argon2Memory:     DefaultArgon2Memory,
+		Argon2Threads:    DefaultArgon2Threads,
+		PBKDF2Iterations: DefaultPBKDF2Iterations,
+	}
+}
+
+func (o Options) withDefaults() Options {
+	defaults := DefaultOptions()
+	if o.BcryptCost == 0 {
+		o.BcryptCost = defaults.BcryptCost
+	}
+	if o.EXTDESRounds == 0 {
+		o.EXTDESRounds = defaults.EXTDESRounds
+	}
+	if o.Argon2Time == 0 {
+		o.Argon2Time = defaults.Argon2Time
+	}
+	if o.Argon2Memory == 0 {
+		o.Argon2Memory = defaults.Argon2Memory
+	}
+	if o.Argon2Threads == 0 {
+		o.Argon2Threads = defaults.Argon2Threads
+	}
+	if o.PBKDF2Iterations == 0 {
+		o.PBKDF2Iterations = defaults.PBKDF2Iterations
+	}
+	return o
+}
+
+// Validate ...
+func (o Options) Validate() error {
+	o = o.withDefaults()
+	if o.BcryptCost < MinBcryptCost || o.BcryptCost > MaxBcryptCost {
+		return fmt.Errorf("bcrypt cost must be between %d and %d", MinBcryptCost, MaxBcryptCost)
+	}
+	if o.EXTDESRounds < 1 || o.EXTDESRounds > maxEXTDESRounds {
+		return fmt.Errorf("extended DES rounds must be between 1 and %d", maxEXTDESRounds)
+	}
+	if o.Argon2Time < 1 || int64(o.Argon2Time) > math.MaxUint32 {
+		return fmt.Errorf("argon2 time must be at least 1")
+	}
+	if o.Argon2Threads < 1 || o.Argon2Threads > math.MaxUint8 {
+		return fmt.Errorf("argon2 threads must be between 1 and %d", math.MaxUint8)
+	}
+	if o.Argon2Memory < argon2MinMemory*o.Argon2Threads || int64(o.Argon2Memory) > math.MaxUint32 {
+		return fmt.Errorf("argon2 memory must be at least %d KiB per thread", argon2MinMemory)
+	}
+	if o.PBKDF2Iterations < 1 {
+		return fmt.Errorf("PBKDF2 iterations must be at least 1")
+	}
+	return nil
+}
+
+// Password hashes the password with the default options
 func Password(password string, algorithm pb.HashingAlgorithm) (string, error) {
+	return PasswordWithOptions(password, algorithm, DefaultOptions())
+}
+
+// PasswordWithOptions hashes the password using the costs configured in the options
+func PasswordWithOptions(password string, algorithm pb.HashingAlgorithm, options Options) (string, error) {
+	options = options.withDefaults()
+	if err := options.Validate(); err != nil {
+		return "", err
+	}
 	switch algorithm {
 	case pb.HashingAlgorithm_SSHA, pb.HashingAlgorithm_DEFAULT:
 		return encodeSSHA(password), nil
@@ -156,8 +239,7 @@ func Password(password string, algorithm pb.HashingAlgorithm) (string, error) {
 	case pb.HashingAlgorithm_SHA512CRYPT:
 		return encodeSHA512(password), nil
 	case pb.HashingAlgorithm_BLOWFISH:
-		return "", errors.New("BLOWFISH is currently not supported")
-		// return encodeBLOWFISH(password), nil
+		return encodeBLOWFISH(password, options.BcryptCost)
 	case pb.HashingAlgorithm_MD5:
 		return encodeMD5(password), nil
 	case pb.HashingAlgorithm_MD5CRYPT:
@@ -167,13 +249,17 @@ func Password(password string, algorithm pb.HashingAlgorithm) (string, error) {
 	case pb.HashingAlgorithm_SHA:
 		return encodeSHA(password), nil
 	case pb.HashingAlgorithm_CRYPT:
-		return "", errors.New("CRYPT is currently not supported")
-		// return encodeCRYPT(password), nil
+		return encodeCRYPT(password)
 	case pb.HashingAlgorithm_EXTDES:
-		return "", errors.New("EXTDES is currently not supported")
-		// return encodeEXTDES(password), nil
+		return encodeEXTDES(password, options.EXTDESRounds)
 	case pb.HashingAlgorithm_CLEAR:
 		return encodeCLEAR(password), nil
+	case pb.HashingAlgorithm_ARGON2ID:
+		return encodeARGON2ID(password, uint32(options.Argon2Time), uint32(options.Argon2Memory), uint8(options.Argon2Threads)), nil
+	case pb.HashingAlgorithm_PBKDF2_SHA256:
+		return encodePBKDF2(password, pbkdf2SHA256, options.PBKDF2Iterations), nil
+	case pb.HashingAlgorithm_PBKDF2_SHA512:
+		return encodePBKDF2(password, pbkdf2SHA512, options.PBKDF2Iterations), nil
 	default:
 		return encodeSSHA(password), nil
 	}
@@ -1,18 +1,235 @@
 package hash
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
 )
 
-// TestDESHash ...
-func TestDESHash(t *testing.T) {
-	/*
-		pw := "password"
-		hashed := encodeCRYPT(pw)
-		expected := "{CRYPT}JQMuyS6H.AGMo"
-		if hashed != expected {
-			t.Errorf("expected encodeCRYPT(%q) == %q but got %s", pw, expected, hashed)
-		}
-	*/
-	// TODO: Test hash functions
+// TestCryptReferenceVectors checks DES, extended DES and bcrypt hashes against values generated with libxcrypt's crypt(3)
+func TestCryptReferenceVectors(t *testing.T) {
+	cases := []struct {
+		password string
+		setting  string
+		expected string
+	}{
+		{"password", "JQ", "JQMuyS6H.AGMo"},
+		{"secret", "ab", "abNANd1rDfiNc"},
+		{"secret", "./", "./17m29LFAflg"},
+		// only the first 8 characters are used by traditional DES
+		{"Hallo Welt 12345", "zZ", "zZKWNzyDkWbOQ"},
+		{"password", "_J9..salt", "_J9..saltJW8FtKdEkNM"},
+		{"secret", "_J9..abcd", "_J9..abcd/H6HLCcf7nw"},
+		{"a much longer secret passphrase", "_J9..abcd", "_J9..abcdQ94d.uvfv6I"},
+		{"12345678", "_/...xyzA", "_/...xyzAOqWYh8D9m.Q"},
+		{"Hallo Welt", "$2b$05$CCCCCCCCCCCCCCCCCCCCC.", "$2b$05$CCCCCCCCCCCCCCCCCCCCC.aI5KxELMCEuVbCLAZE7PhTyL0Eo62.y"},
+		{"secret", "$2b$10$abcdefghijklmnopqrstuu", "$2b$10$abcdefghijklmnopqrstuuqflPDzB6gcMhKa1rZqKiun2YGL5sa2u"},
+		// bcrypt only uses the first 72 bytes
+		{strings.Repeat("x", 80), "$2b$04$abcdefghijklmnopqrstuu", "$2b$04$abcdefghijklmnopqrstuubzadhGtS2zEF.gu0yd0opP6cVzb.e0i"},
+	}
+	for _, c := range cases {
+		var hashed string
+		var err error
+		switch {
+		case strings.HasPrefix(c.setting, "$2b$"):
+			var cost int
+			fmt.Sscanf(c.setting, "$2b$%d$", &cost)
+			var hash []byte
+			hash, err = bcrypt([]byte(c.password), cost, []byte(c.setting[7:]))
+			hashed = c.setting + string(hash)
+		case strings.HasPrefix(c.setting, "_"):
+			hashed, err = EXTDESCrypt(c.password, c.setting)
+		default:
+			hashed, err = DESCrypt(c.password, c.setting)
+		}
+		if err != nil {
+			t.Errorf("failed to hash %q with setting %q: %v", c.password, c.setting, err)
+			continue
+		}
+		if hashed != c.expected {
+			t.Errorf("expected crypt(%q, %q) == %q but got %q", c.password, c.setting, c.expected, hashed)
+		}
+		if matches, err := VerifyPassword(c.password, "{CRYPT}"+c.expected); err != nil || !matches {
+			t.Errorf("expected %q to match %q (err: %v)", c.password, c.expected, err)
+		}
+	}
+}
+
+// TestCryptFormats checks the format of generated crypt(3) hashes and their random salts
+func TestCryptFormats(t *testing.T) {
+	cases := []struct {
+		algorithm pb.HashingAlgorithm
+		options   Options
+		format    *regexp.Regexp
+	}{
+		{pb.HashingAlgorithm_BLOWFISH, Options{}, regexp.MustCompile(`^\{CRYPT\}\$2b\$12\$[./A-Za-z0-9]{53}$`)},
+		{pb.HashingAlgorithm_BLOWFISH, Options{BcryptCost: 5}, regexp.MustCompile(`^\{CRYPT\}\$2b\$05\$[./A-Za-z0-9]{53}$`)},
+		{pb.HashingAlgorithm_CRYPT, Options{}, regexp.MustCompile(`^\{CRYPT\}[./A-Za-z0-9]{13}$`)},
+		{pb.HashingAlgorithm_EXTDES, Options{}, regexp.MustCompile(`^\{CRYPT\}_J9\.\.[./A-Za-z0-9]{15}$`)},
+		{pb.HashingAlgorithm_EXTDES, Options{EXTDESRounds: 1}, regexp.MustCompile(`^\{CRYPT\}_/\.\.\.[./A-Za-z0-9]{15}$`)},
+	}
+	for _, c := range cases {
+		first, err := PasswordWithOptions("secret", c.algorithm, c.options)
+		if err != nil {
+			t.Fatalf("failed to hash with %s: %v", c.algorithm, err)
+		}
+		second, _ := PasswordWithOptions("secret", c.algorithm, c.options)
+		if !c.format.MatchString(first) {
+			t.Errorf("expected %s hash %q to match %s", c.algorithm, first, c.format)
+		}
+		if first == second {
+			t.Errorf("expected %s hashes to use random salts but got %q twice", c.algorithm, first)
+		}
+	}
+	if _, err := PasswordWithOptions("secret", pb.HashingAlgorithm_BLOWFISH, Options{BcryptCost: 32}); err == nil {
+		t.Errorf("expected an invalid bcrypt cost to be rejected")
+	}
+}
+
+// TestVerifyPassword ...
+func TestVerifyPassword(t *testing.T) {
+	for _, algorithm := range append(LDAPPasswordHashingAlgorithms, ModulePasswordHashingAlgorithms...) {
+		for _, password := range []string{"Hallo Welt", "$ecret{}", "ä"} {
+			hashed, err := Password(password, algorithm)
+			if err != nil {
+				t.Fatalf("failed to hash password with %s: %v", algorithm, err)
+			}
+			matches, err := VerifyPassword(password, hashed)
+			if err != nil {
+				t.Errorf("failed to verify %q: %v", hashed, err)
+			}
+			if !matches {
+				t.Errorf("expected %q to match %s hash %q", password, algorithm, hashed)
+			}
+			// traditional DES only uses the first 8 characters, so the password is changed at the front
+			if matches, _ := VerifyPassword("x"+password, hashed); matches {
+				t.Errorf("expected %q not to match %s hash %q", "x"+password, algorithm, hashed)
+			}
+		}
+	}
+}
+
+// TestVerify checks reference values generated with slappasswd, crypt(3) and the scheme definitions
+func TestVerify(t *testing.T) {
+	cases := []struct {
+		stored  string
+		matches bool
+		invalid bool
+	}{
+		// slappasswd -h {SHA} -s secret
+		{stored: "{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=", matches: true},
+		{stored: "{sha}5en6G6MezRroT3XKqkdPOmY/BfQ=", matches: true},
+		{stored: "{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=", matches: false},
+		{stored: "{SSHA}uJDd0BIdJ9Z7yDCZNWdgYeb33+cBAgME", matches: true},
+		{stored: "{SSHA}1G904nLkTkGWjKNnQuB/hpWXC/hzYWx0c2FsdA==", matches: true},
+		{stored: "{SSHA}2G904nLkTkGWjKNnQuB/hpWXC/hzYWx0c2FsdA==", matches: false},
+		{stored: "{MD5}Xr4ilOzQ4PCOq3aQ0qbuaQ==", matches: true},
+		{stored: "{SMD5}LF/f6q9WuGXFii+gm3ssYQECAwQ=", matches: true},
+		{stored: "{CRYPT}$1$saltsalt$9xy1btjgzLYfb7hivXtC//", matches: true},
+		{stored: "{CRYPT}$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/", matches: false},
+		{stored: "{CRYPT}$5$saltsalt$0IyaXrmV7.sGNS6tirgqHLqX/G.FBvgkYA.lpPdS5sA", matches: true},
+		{stored: "{CRYPT}$5$rounds=10000$saltsalt$RUsnTSO2Cw.gkRW/RZSmG6BCeuh1a6eDbZfnX4oz1c5", matches: true},
+		{stored: "{CRYPT}$6$saltsalt$TVLlQcbpFVof5W3Yz4DTP6gRstiNuHwwTt6GLc1E5n0U0aDehy0S5knV8wiOQSpT0Y77vwPZN.Pq.H91p5hVO1", matches: true},
+		{stored: "{CRYPT}$2a$04$abcdefghijklmnopqrstuu2r9OfJnfCsdneAXAGHnS4UpFFP8WIrW", matches: true},
+		{stored: "{CRYPT}$2y$04$abcdefghijklmnopqrstuu2r9OfJnfCsdneAXAGHnS4UpFFP8WIrW", matches: true},
+		{stored: "{CRYPT}$2a$04$abcdefghijklmnopqrstuu2r9OfJnfCsdneAXAGHnS4UpFFP8WIrX", matches: false},
+		{stored: "{CRYPT}abNANd1rDfiNc", matches: true},
+		{stored: "{CRYPT}abNANd1rDfiNd", matches: false},
+		{stored: "{CRYPT}_J9..abcd/H6HLCcf7nw", matches: true},
+		{stored: "{CRYPT}abNANd1rDfi", invalid: true},
+		{stored: "{CRYPT}_J9..ab", invalid: true},
+		// hashlib.pbkdf2_hmac with the adapted base64 encoding of pw-pbkdf2
+		{stored: "{PBKDF2}10000$c2FsdHNhbHRzYWx0c2FsdA$T13Isf42NxuNoD5Chs2u8B13b7o", matches: true},
+		{stored: "{PBKDF2-SHA256}10000$c2FsdHNhbHRzYWx0c2FsdA$7JMc.Orakl8cI/LNC4qa3ZWWz8zE6mp9ZCpH6br9XuM", matches: true},
+		{stored: "{PBKDF2-SHA256}10001$c2FsdHNhbHRzYWx0c2FsdA$7JMc.Orakl8cI/LNC4qa3ZWWz8zE6mp9ZCpH6br9XuM", matches: false},
+		{stored: "{PBKDF2-SHA512}10000$c2FsdHNhbHRzYWx0c2FsdA$f.bBNpDm1dD76HDTg44Z2qO0KWw9xM1beWOT/VyOFnsrrTMYXRqDM2j3OuOtqE6QcICME/YUg8pPtTkNPmH1Yg", matches: true},
+		{stored: "{PBKDF2-SHA512}10000$c2FsdHNhbHRzYWx0c2FsdA$7JMc.Orakl8cI/LNC4qa3ZWWz8zE6mp9ZCpH6br9XuM", invalid: true},
+		{stored: "{ARGON2}$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", matches: false},
+		{stored: "{ARGON2}$argon2id$v=16$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", invalid: true},
+		{stored: "{ARGON2}$argon2d$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc", invalid: true},
+		{stored: "secret", matches: true},
+		{stored: "{CLEARTEXT}secret", matches: true},
+		{stored: "Secret", matches: false},
+		{stored: "{SSHA}not base64", invalid: true},
+		{stored: "{SSHA}c2hvcnQ=", invalid: true},
+		{stored: "{CRYPT}$2a$99$abcdefghijklmnopqrstuu2r9OfJnfCsdneAXAGHnS4UpFFP8WIrW", invalid: true},
+		{stored: "{CRYPT}$9$unknown", invalid: true},
+		{stored: "{UNKNOWN}secret", invalid: true},
+	}
+	for _, c := range cases {
+		matches, err := VerifyPassword("secret", c.stored)
+		if c.invalid {
+			if err == nil {
+				t.Errorf("expected an error when verifying against %q", c.stored)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("failed to verify against %q: %v", c.stored, err)
+		}
+		if matches != c.matches {
+			t.Errorf("expected VerifyPassword(%q, %q) == %t but got %t", "secret", c.stored, c.matches, matches)
+		}
+	}
+}
+
+// TestKDFFormats checks argon2id and PBKDF2 hashes against the formats of the OpenLDAP pw-argon2 and pw-pbkdf2 modules
+func TestKDFFormats(t *testing.T) {
+	// reference vector of the argon2 reference implementation (phc-winner-argon2)
+	reference := "{ARGON2}$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+	if matches, err := VerifyPassword("password", reference); err != nil || !matches {
+		t.Errorf("expected %q to match %q (err: %v)", "password", reference, err)
+	}
+
+	cases := []struct {
+		algorithm pb.HashingAlgorithm
+		options   Options
+		format    *regexp.Regexp
+	}{
+		{pb.HashingAlgorithm_ARGON2ID, Options{}, regexp.MustCompile(`^\{ARGON2\}\$argon2id\$v=19\$m=65536,t=2,p=1\$[A-Za-z0-9+/]{22}\$[A-Za-z0-9+/]{43}$`)},
+		{pb.HashingAlgorithm_ARGON2ID, Options{Argon2Time: 3, Argon2Memory: 1024, Argon2Threads: 2}, regexp.MustCompile(`^\{ARGON2\}\$argon2id\$v=19\$m=1024,t=3,p=2\$[A-Za-z0-9+/]{22}\$[A-Za-z0-9+/]{43}$`)},
+		{pb.HashingAlgorithm_PBKDF2_SHA256, Options{}, regexp.MustCompile(`^\{PBKDF2-SHA256\}10000\$[A-Za-z0-9./]{22}\$[A-Za-z0-9./]{43}$`)},
+		{pb.HashingAlgorithm_PBKDF2_SHA512, Options{PBKDF2Iterations: 1000}, regexp.MustCompile(`^\{PBKDF2-SHA512\}1000\$[A-Za-z0-9./]{22}\$[A-Za-z0-9./]{86}$`)},
+	}
+	for _, c := range cases {
+		hashed, err := PasswordWithOptions("secret", c.algorithm, c.options)
+		if err != nil {
+			t.Fatalf("failed to hash with %s: %v", c.algorithm, err)
+		}
+		if !c.format.MatchString(hashed) {
+			t.Errorf("expected %s hash %q to match %s", c.algorithm, hashed, c.format)
+		}
+		if matches, err := VerifyPassword("secret", hashed); err != nil || !matches {
+			t.Errorf("expected %q to match %q (err: %v)", "secret", hashed, err)
+		}
+	}
+	if _, err := PasswordWithOptions("secret", pb.HashingAlgorithm_ARGON2ID, Options{Argon2Memory: 4}); err == nil {
+		t.Errorf("expected too little argon2 memory to be rejected")
+	}
+}
+
+// TestScheme ...
+func TestScheme(t *testing.T) {
+	for _, algorithm := range append(LDAPPasswordHashingAlgorithms, ModulePasswordHashingAlgorithms...) {
+		hashed, err := PasswordWithOptions("secret", algorithm, Options{BcryptCost: MinBcryptCost, PBKDF2Iterations: 1, Argon2Memory: 64})
+		if err != nil {
+			t.Fatalf("failed to hash with %s: %v", algorithm, err)
+		}
+		if scheme := Scheme(hashed); scheme != AlgorithmScheme(algorithm) {
+			t.Errorf("expected %s hash %q to have scheme %s but got %q", algorithm, hashed, AlgorithmScheme(algorithm), scheme)
+		}
+	}
+	for stored, expected := range map[string]string{
+		"{cleartext}secret": SchemeCleartext,
+		"{PBKDF2}10000$c2FsdHNhbHRzYWx0c2FsdA$T13Isf42NxuNoD5Chs2u8B13b7o": SchemePBKDF2SHA1,
+		"{CRYPT}$9$unknown": "",
+		"{UNKNOWN}secret":   "",
+	} {
+		if scheme := Scheme(stored); scheme != expected {
+			t.Errorf("expected %q to have scheme %q but got %q", stored, expected, scheme)
+		}
+	}
 }
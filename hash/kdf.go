@@ -0,0 +1,131 @@
+package hash
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Argon2 and PBKDF2 hashes in the formats of the OpenLDAP pw-argon2 and pw-pbkdf2 modules:
+//
+//	{ARGON2}$argon2id$v=19$m=65536,t=2,p=1$<base64 salt>$<base64 hash>
+//	{PBKDF2-SHA512}10000$<adapted base64 salt>$<adapted base64 hash>
+//
+// The argon2 encoding uses standard base64 without padding,
+// the pbkdf2 encoding uses the adapted base64 alphabet of passlib ('.' instead of '+', no padding).
+
+const (
+	// DefaultArgon2Time is the default number of argon2 passes over the memory
+	DefaultArgon2Time = 2
+	// DefaultArgon2Memory is the default argon2 memory in KiB
+	DefaultArgon2Memory = 64 * 1024
+	// DefaultArgon2Threads is the default argon2 parallelism
+	DefaultArgon2Threads = 1
+	// DefaultPBKDF2Iterations is the default number of iterations used by pw-pbkdf2
+	DefaultPBKDF2Iterations = 10000
+
+	argon2SaltSize  = 16
+	argon2HashSize  = 32
+	argon2MinMemory = 8
+	pbkdf2SaltSize  = 16
+)
+
+var ab64Encoding = base64.NewEncoding("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789./").WithPadding(base64.NoPadding)
+
+type pbkdf2Scheme struct {
+	name string
+	hash func() hash.Hash
+	size int
+}
+
+var (
+	pbkdf2SHA1   = pbkdf2Scheme{"PBKDF2-SHA1", sha1.New, sha1.Size}
+	pbkdf2SHA256 = pbkdf2Scheme{"PBKDF2-SHA256", sha256.New, sha256.Size}
+	pbkdf2SHA512 = pbkdf2Scheme{"PBKDF2-SHA512", sha512.New, sha512.Size}
+)
+
+func encodeARGON2ID(pw string, time, memory uint32, threads uint8) string {
+	salt := generateSalt(argon2SaltSize)
+	hash := argon2.IDKey([]byte(pw), salt, time, memory, threads, argon2HashSize)
+	return fmt.Sprintf("{ARGON2}$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, memory, time, threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	)
+}
+
+func encodePBKDF2(pw string, scheme pbkdf2Scheme, iterations int) string {
+	salt := generateSalt(pbkdf2SaltSize)
+	hash := pbkdf2.Key([]byte(pw), salt, iterations, scheme.size, scheme.hash)
+	return fmt.Sprintf("{%s}%d$%s$%s", scheme.name, iterations, ab64Encoding.EncodeToString(salt), ab64Encoding.EncodeToString(hash))
+}
+
+// verifyArgon2 verifies hashes of the form $argon2id$v=19$m=65536,t=2,p=1$SALT$HASH (argon2i is also accepted)
+func verifyArgon2(password, hashed string) (bool, error) {
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 6 || parts[0] != "" {
+		return false, fmt.Errorf("invalid argon2 hash")
+	}
+	variant := parts[1]
+	if variant != "argon2id" && variant != "argon2i" {
+		return false, &UnsupportedSchemeError{Scheme: "ARGON2 $" + variant + "$"}
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, fmt.Errorf("unsupported argon2 version %q", parts[2])
+	}
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false, fmt.Errorf("invalid argon2 parameters %q", parts[3])
+	}
+	if time < 1 || threads < 1 || memory < argon2MinMemory*uint32(threads) {
+		return false, fmt.Errorf("invalid argon2 parameters %q", parts[3])
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("invalid argon2 salt: %v", err)
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(expected) == 0 {
+		return false, fmt.Errorf("invalid argon2 hash: %v", err)
+	}
+	var computed []byte
+	if variant == "argon2id" {
+		computed = argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(expected)))
+	} else {
+		computed = argon2.Key([]byte(password), salt, time, memory, threads, uint32(len(expected)))
+	}
+	return subtle.ConstantTimeCompare(computed, expected) == 1, nil
+}
+
+// verifyPBKDF2 verifies hashes of the form ITERATIONS$SALT$HASH
+func verifyPBKDF2(password, hashed string, scheme pbkdf2Scheme) (bool, error) {
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 3 {
+		return false, fmt.Errorf("invalid %s hash", scheme.name)
+	}
+	iterations, err := strconv.Atoi(parts[0])
+	if err != nil || iterations < 1 {
+		return false, fmt.Errorf("invalid %s iterations %q", scheme.name, parts[0])
+	}
+	salt, err := ab64Encoding.DecodeString(parts[1])
+	if err != nil {
+		return false, fmt.Errorf("invalid %s salt: %v", scheme.name, err)
+	}
+	expected, err := ab64Encoding.DecodeString(parts[2])
+	if err != nil || len(expected) != scheme.size {
+		return false, fmt.Errorf("invalid %s hash", scheme.name)
+	}
+	computed := pbkdf2.Key([]byte(password), salt, iterations, scheme.size, scheme.hash)
+	return subtle.ConstantTimeCompare(computed, expected) == 1, nil
+}
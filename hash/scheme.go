@@ -0,0 +1,111 @@
+package hash
+
+import (
+	"strings"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// Schemes identify the hash function of a stored password. {CRYPT} values are distinguished by their crypt(3) prefix.
+const (
+	SchemeCleartext    = "CLEARTEXT"
+	SchemeMD5          = "MD5"
+	SchemeSMD5         = "SMD5"
+	SchemeSHA          = "SHA"
+	SchemeSSHA         = "SSHA"
+	SchemeCryptDES     = "CRYPT-DES"
+	SchemeCryptEXTDES  = "CRYPT-EXTDES"
+	SchemeCryptMD5     = "CRYPT-MD5"
+	SchemeCryptSHA256  = "CRYPT-SHA256"
+	SchemeCryptSHA512  = "CRYPT-SHA512"
+	SchemeCryptBcrypt  = "CRYPT-BCRYPT"
+	SchemeArgon2       = "ARGON2"
+	SchemePBKDF2SHA1   = "PBKDF2-SHA1"
+	SchemePBKDF2SHA256 = "PBKDF2-SHA256"
+	SchemePBKDF2SHA512 = "PBKDF2-SHA512"
+)
+
+// DefaultSchemeStrength ranks the schemes, hashes of a lower rank are considered weaker
+var DefaultSchemeStrength = map[string]int{
+	SchemeCleartext:    0,
+	SchemeMD5:          1,
+	SchemeSHA:          1,
+	SchemeCryptDES:     1,
+	SchemeSMD5:         2,
+	SchemeSSHA:         2,
+	SchemeCryptEXTDES:  2,
+	SchemeCryptMD5:     3,
+	SchemePBKDF2SHA1:   3,
+	SchemeCryptSHA256:  4,
+	SchemeCryptSHA512:  4,
+	SchemePBKDF2SHA256: 4,
+	SchemePBKDF2SHA512: 4,
+	SchemeCryptBcrypt:  5,
+	SchemeArgon2:       6,
+}
+
+// Scheme returns the scheme of a stored userPassword value or an empty string if it is unknown
+func Scheme(stored string) string {
+	scheme, value := splitScheme(stored)
+	switch scheme {
+	case "":
+		return SchemeCleartext
+	case "PBKDF2":
+		return SchemePBKDF2SHA1
+	case "CRYPT":
+		switch {
+		case strings.HasPrefix(value, "$1$"):
+			return SchemeCryptMD5
+		case strings.HasPrefix(value, "$5$"):
+			return SchemeCryptSHA256
+		case strings.HasPrefix(value, "$6$"):
+			return SchemeCryptSHA512
+		case strings.HasPrefix(value, "$2"):
+			return SchemeCryptBcrypt
+		case strings.HasPrefix(value, "_"):
+			return SchemeCryptEXTDES
+		case len(value) == 13 && !strings.HasPrefix(value, "$"):
+			return SchemeCryptDES
+		}
+		return ""
+	case SchemeCleartext, SchemeMD5, SchemeSMD5, SchemeSHA, SchemeSSHA,
+		SchemeArgon2, SchemePBKDF2SHA1, SchemePBKDF2SHA256, SchemePBKDF2SHA512:
+		return scheme
+	}
+	return ""
+}
+
+// AlgorithmScheme returns the scheme of hashes generated by the algorithm
+func AlgorithmScheme(algorithm pb.HashingAlgorithm) string {
+	switch algorithm {
+	case pb.HashingAlgorithm_SSHA, pb.HashingAlgorithm_DEFAULT:
+		return SchemeSSHA
+	case pb.HashingAlgorithm_SHA256CRYPT:
+		return SchemeCryptSHA256
+	case pb.HashingAlgorithm_SHA512CRYPT:
+		return SchemeCryptSHA512
+	case pb.HashingAlgorithm_BLOWFISH:
+		return SchemeCryptBcrypt
+	case pb.HashingAlgorithm_MD5:
+		return SchemeMD5
+	case pb.HashingAlgorithm_MD5CRYPT:
+		return SchemeCryptMD5
+	case pb.HashingAlgorithm_SMD5:
+		return SchemeSMD5
+	case pb.HashingAlgorithm_SHA:
+		return SchemeSHA
+	case pb.HashingAlgorithm_CRYPT:
+		return SchemeCryptDES
+	case pb.HashingAlgorithm_EXTDES:
+		return SchemeCryptEXTDES
+	case pb.HashingAlgorithm_CLEAR:
+		return SchemeCleartext
+	case pb.HashingAlgorithm_ARGON2ID:
+		return SchemeArgon2
+	case pb.HashingAlgorithm_PBKDF2_SHA256:
+		return SchemePBKDF2SHA256
+	case pb.HashingAlgorithm_PBKDF2_SHA512:
+		return SchemePBKDF2SHA512
+	}
+	return SchemeSSHA
+}
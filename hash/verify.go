@@ -0,0 +1,155 @@
+package hash
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"github.com/GehirnInc/crypt"
+)
+
+// UnsupportedSchemeError is returned when a stored password uses an unknown scheme
+type UnsupportedSchemeError struct {
+	Scheme string
+}
+
+// Error ...
+func (e *UnsupportedSchemeError) Error() string {
+	return fmt.Sprintf("unsupported password scheme %q", e.Scheme)
+}
+
+// splitScheme splits a stored value such as {SSHA}abc into its scheme and value.
+// Values without a scheme are cleartext.
+func splitScheme(stored string) (string, string) {
+	if strings.HasPrefix(stored, "{") {
+		if end := strings.Index(stored, "}"); end > 0 {
+			return strings.ToUpper(stored[1:end]), stored[end+1:]
+		}
+	}
+	return "", stored
+}
+
+func verifyDigest(h hash.Hash, password, encoded string) (bool, error) {
+	digest, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return false, fmt.Errorf("invalid base64 digest: %v", err)
+	}
+	h.Write([]byte(password))
+	return subtle.ConstantTimeCompare(h.Sum(nil), digest) == 1, nil
+}
+
+func verifySaltedDigest(h hash.Hash, password, encoded string) (bool, error) {
+	digest, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return false, fmt.Errorf("invalid base64 digest: %v", err)
+	}
+	if len(digest) <= h.Size() {
+		return false, fmt.Errorf("salted digest is too short")
+	}
+	h.Write([]byte(password))
+	h.Write(digest[h.Size():])
+	return subtle.ConstantTimeCompare(h.Sum(nil), digest[:h.Size()]) == 1, nil
+}
+
+// verifyBcrypt verifies hashes of the form $2a$COST$SALTHASH with a 22 character salt and 31 character hash
+func verifyBcrypt(password, hashed string) (bool, error) {
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 4 || len(parts[1]) != 2 || len(parts[3]) != encodedSaltSize+encodedHashSize {
+		return false, fmt.Errorf("invalid bcrypt hash")
+	}
+	switch parts[1] {
+	case "2a", "2b", "2y":
+	default:
+		return false, &UnsupportedSchemeError{Scheme: "CRYPT $" + parts[1] + "$"}
+	}
+	cost, err := strconv.Atoi(parts[2])
+	if err != nil || cost < MinBcryptCost || cost > MaxBcryptCost {
+		return false, fmt.Errorf("invalid bcrypt cost %q", parts[2])
+	}
+	salt, expected := parts[3][:encodedSaltSize], parts[3][encodedSaltSize:]
+	computed, err := bcrypt([]byte(password), cost, []byte(salt))
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(computed, []byte(expected)) == 1, nil
+}
+
+// verifyDES verifies traditional DES (SSHHHHHHHHHHH) and BSDi extended DES (_CCCCSSSSHHHHHHHHHHH) hashes
+func verifyDES(password, hashed string) (bool, error) {
+	var computed string
+	var err error
+	if strings.HasPrefix(hashed, "_") {
+		if len(hashed) != 20 {
+			return false, fmt.Errorf("invalid extended DES hash")
+		}
+		computed, err = EXTDESCrypt(password, hashed[:9])
+	} else {
+		if len(hashed) != 13 {
+			return false, fmt.Errorf("invalid DES hash")
+		}
+		computed, err = DESCrypt(password, hashed[:2])
+	}
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(hashed)) == 1, nil
+}
+
+// verifyCrypt verifies crypt(3) hashes such as $1$ (MD5), $5$ (SHA-256), $6$ (SHA-512), $2b$ (bcrypt) and DES
+func verifyCrypt(password, hashed string) (bool, error) {
+	if strings.HasPrefix(hashed, "$2") {
+		return verifyBcrypt(password, hashed)
+	}
+	if !strings.HasPrefix(hashed, "$") {
+		return verifyDES(password, hashed)
+	}
+	if !crypt.IsHashSupported(hashed) {
+		return false, &UnsupportedSchemeError{Scheme: "CRYPT"}
+	}
+	// the salt including the rounds parameter is everything up to the last $
+	end := strings.LastIndex(hashed, "$")
+	if end < 0 {
+		return false, fmt.Errorf("invalid crypt hash")
+	}
+	computed, err := crypt.NewFromHash(hashed).Generate([]byte(password), []byte(hashed[:end]))
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(hashed)) == 1, nil
+}
+
+// VerifyPassword checks if the password matches a stored userPassword value in one of the formats supported by OpenLDAP:
+// {SSHA}, {SMD5}, {SHA}, {MD5}, {CRYPT} with $1$, $5$, $6$, $2a$/$2b$/$2y$, DES or extended DES hashes,
+// {ARGON2}, {PBKDF2}, {PBKDF2-SHA256}, {PBKDF2-SHA512} and cleartext.
+// All comparisons are done in constant time.
+func VerifyPassword(password, stored string) (bool, error) {
+	scheme, value := splitScheme(stored)
+	switch scheme {
+	case "", "CLEARTEXT":
+		return subtle.ConstantTimeCompare([]byte(password), []byte(value)) == 1, nil
+	case "SSHA":
+		return verifySaltedDigest(sha1.New(), password, value)
+	case "SMD5":
+		return verifySaltedDigest(md5.New(), password, value)
+	case "SHA":
+		return verifyDigest(sha1.New(), password, value)
+	case "MD5":
+		return verifyDigest(md5.New(), password, value)
+	case "CRYPT":
+		return verifyCrypt(password, value)
+	case "ARGON2":
+		return verifyArgon2(password, value)
+	case "PBKDF2", "PBKDF2-SHA1":
+		return verifyPBKDF2(password, value, pbkdf2SHA1)
+	case "PBKDF2-SHA256":
+		return verifyPBKDF2(password, value, pbkdf2SHA256)
+	case "PBKDF2-SHA512":
+		return verifyPBKDF2(password, value, pbkdf2SHA512)
+	}
+	return false, &UnsupportedSchemeError{Scheme: scheme}
+}
@@ -0,0 +1,99 @@
+package ldapmanager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap"
+	ldaphash "github.com/romnn/ldap-manager/hash"
+	log "github.com/sirupsen/logrus"
+)
+
+// AuditOperationUpgradeHash is recorded when a password hash is upgraded on login
+const AuditOperationUpgradeHash = "upgrade-hash"
+
+// ParseHashSchemeStrength parses overrides of the default scheme ranking of the form SCHEME=RANK (e.g. SSHA=3)
+func ParseHashSchemeStrength(specs []string) (map[string]int, error) {
+	strength := make(map[string]int)
+	for scheme, rank := range ldaphash.DefaultSchemeStrength {
+		strength[scheme] = rank
+	}
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid hash scheme strength %q: expected SCHEME=RANK", spec)
+		}
+		scheme := strings.ToUpper(strings.Trim(strings.TrimSpace(parts[0]), "{}"))
+		if _, known := ldaphash.DefaultSchemeStrength[scheme]; !known {
+			return nil, fmt.Errorf("invalid hash scheme strength %q: unknown scheme %q", spec, scheme)
+		}
+		rank, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hash scheme strength %q: %v", spec, err)
+		}
+		strength[scheme] = rank
+	}
+	return strength, nil
+}
+
+// weakerHash checks if the stored hash uses a weaker scheme than the configured hashing algorithm.
+// Hashes of unknown schemes are never considered weaker.
+func (m *LDAPManager) weakerHash(stored string) (bool, string, string) {
+	strength := m.HashSchemeStrength
+	if strength == nil {
+		strength = ldaphash.DefaultSchemeStrength
+	}
+	from, to := ldaphash.Scheme(stored), ldaphash.AlgorithmScheme(m.HashingAlgorithm)
+	fromRank, knownFrom := strength[from]
+	toRank, knownTo := strength[to]
+	return knownFrom && knownTo && fromRank < toRank, from, to
+}
+
+// getUserPasswords returns the stored userPassword values of an account
+func (m *LDAPManager) getUserPasswords(userDN string) ([]string, error) {
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		userDN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)",
+		[]string{"userPassword"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Entries) != 1 {
+		return nil, nil
+	}
+	return result.Entries[0].GetAttributeValues("userPassword"), nil
+}
+
+// upgradePasswordHash rehashes the password of a successfully authenticated user with the configured
+// hashing algorithm if the stored hash uses a weaker scheme
+func (m *LDAPManager) upgradePasswordHash(username, userDN, password string) error {
+	stored, err := m.getUserPasswords(userDN)
+	if err != nil {
+		return err
+	}
+	if len(stored) != 1 {
+		// accounts with multiple passwords are left untouched
+		return nil
+	}
+	weaker, from, to := m.weakerHash(stored[0])
+	if !weaker {
+		return nil
+	}
+	hashedPassword, err := ldaphash.PasswordWithOptions(password, m.HashingAlgorithm, m.HashingOptions)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %v", err)
+	}
+	// deleting the old value makes the upgrade fail if the password was changed in the meantime
+	modifyRequest := ldap.NewModifyRequest(userDN, []ldap.Control{})
+	modifyRequest.Delete("userPassword", stored)
+	modifyRequest.Add("userPassword", []string{hashedPassword})
+	if err := m.As(username).modifyWithOperation(AuditOperationUpgradeHash, modifyRequest); err != nil {
+		return err
+	}
+	log.Infof("upgraded the password hash of %q from %s to %s", username, from, to)
+	return nil
+}
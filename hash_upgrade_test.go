@@ -0,0 +1,106 @@
+package ldapmanager
+
+import (
+	"testing"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	ldaphash "github.com/romnn/ldap-manager/hash"
+)
+
+// TestWeakerHash ...
+func TestWeakerHash(t *testing.T) {
+	strength, err := ParseHashSchemeStrength([]string{"{ssha}=5"})
+	if err != nil {
+		t.Fatalf("failed to parse hash scheme strength: %v", err)
+	}
+	for _, invalid := range []string{"SSHA", "UNKNOWN=1", "SSHA=high"} {
+		if _, err := ParseHashSchemeStrength([]string{invalid}); err == nil {
+			t.Errorf("expected error when parsing invalid hash scheme strength %q", invalid)
+		}
+	}
+	cases := []struct {
+		algorithm pb.HashingAlgorithm
+		strength  map[string]int
+		stored    string
+		weaker    bool
+	}{
+		{pb.HashingAlgorithm_SSHA, nil, "{MD5}Xr4ilOzQ4PCOq3aQ0qbuaQ==", true},
+		{pb.HashingAlgorithm_SSHA, nil, "secret", true},
+		{pb.HashingAlgorithm_SSHA, nil, "{SSHA}uJDd0BIdJ9Z7yDCZNWdgYeb33+cBAgME", false},
+		{pb.HashingAlgorithm_SSHA, nil, "{CRYPT}$6$saltsalt$TVLlQcbpFVof5W3Yz4DTP6gRstiNuHwwTt6GLc1E5n0U0aDehy0S5knV8wiOQSpT0Y77vwPZN.Pq.H91p5hVO1", false},
+		{pb.HashingAlgorithm_ARGON2ID, nil, "{CRYPT}$6$saltsalt$TVLlQcbpFVof5W3Yz4DTP6gRstiNuHwwTt6GLc1E5n0U0aDehy0S5knV8wiOQSpT0Y77vwPZN.Pq.H91p5hVO1", true},
+		{pb.HashingAlgorithm_ARGON2ID, nil, "{UNKNOWN}abc", false},
+		{pb.HashingAlgorithm_SHA512CRYPT, nil, "{SSHA}uJDd0BIdJ9Z7yDCZNWdgYeb33+cBAgME", true},
+		// the ranking of SSHA is overridden to be stronger than SHA512CRYPT
+		{pb.HashingAlgorithm_SHA512CRYPT, strength, "{SSHA}uJDd0BIdJ9Z7yDCZNWdgYeb33+cBAgME", false},
+	}
+	for _, c := range cases {
+		m := &LDAPManager{HashingAlgorithm: c.algorithm, HashSchemeStrength: c.strength}
+		if weaker, from, to := m.weakerHash(c.stored); weaker != c.weaker {
+			t.Errorf("expected %q (%s) to be weaker than %s: %t but got %t", c.stored, from, to, c.weaker, weaker)
+		}
+	}
+}
+
+// TestUpgradeHashOnLogin ...
+func TestUpgradeHashOnLogin(t *testing.T) {
+	if skipHashUpgradeTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	password := "Hallo Welt"
+	if err := test.Manager.NewAccount(&pb.NewAccountRequest{
+		Account: &pb.Account{
+			Username:  "romnn",
+			Password:  password,
+			Email:     "a@b.de",
+			FirstName: "roman",
+			LastName:  "d",
+		},
+	}, pb.HashingAlgorithm_MD5); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+	storedScheme := func() string {
+		stored, err := test.Manager.getUserPasswords(test.Manager.AccountNamed("romnn"))
+		if err != nil || len(stored) != 1 {
+			t.Fatalf("failed to get the password of the user: %v", err)
+		}
+		return ldaphash.Scheme(stored[0])
+	}
+
+	test.Manager.HashingAlgorithm = pb.HashingAlgorithm_SHA512CRYPT
+	login := &pb.LoginRequest{Username: "romnn", Password: password}
+	if _, err := test.Manager.AuthenticateUser(login); err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	if scheme := storedScheme(); scheme != ldaphash.SchemeMD5 {
+		t.Errorf("expected the hash not to be upgraded when the feature is disabled but got %s", scheme)
+	}
+
+	test.Manager.UpgradeHashesOnLogin = true
+	if _, err := test.Manager.AuthenticateUser(&pb.LoginRequest{Username: "romnn", Password: "wrong"}); err == nil {
+		t.Fatal("expected authentication with a wrong password to fail")
+	}
+	if scheme := storedScheme(); scheme != ldaphash.SchemeMD5 {
+		t.Errorf("expected the hash not to be upgraded after a failed login but got %s", scheme)
+	}
+	if _, err := test.Manager.AuthenticateUser(login); err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	if scheme := storedScheme(); scheme != ldaphash.SchemeCryptSHA512 {
+		t.Errorf("expected the hash to be upgraded to %s but got %s", ldaphash.SchemeCryptSHA512, scheme)
+	}
+	if _, err := test.Manager.AuthenticateUser(login); err != nil {
+		t.Errorf("failed to authenticate with the upgraded hash: %v", err)
+	}
+
+	auditLog, err := test.Manager.GetAuditLog(&pb.GetAuditLogRequest{Operation: AuditOperationUpgradeHash})
+	if err != nil {
+		t.Fatalf("failed to get audit log: %v", err)
+	}
+	if len(auditLog.GetRecords()) != 1 || auditLog.GetRecords()[0].GetActor() != "romnn" {
+		t.Errorf("expected a single hash upgrade by romnn in the audit log but got %v", auditLog.GetRecords())
+	}
+}
@@ -0,0 +1,458 @@
+package ldapmanager
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// maxIDAllocationAttempts limits the number of compare-and-swap attempts when allocating a new ID
+const maxIDAllocationAttempts = 20
+
+// IDRange is an inclusive range of IDs that are allocated automatically. A zero Max means there is no upper bound.
+type IDRange struct {
+	Min int
+	Max int
+}
+
+// Contains checks if the ID is within the range
+func (r IDRange) Contains(id int) bool {
+	return id >= r.Min && (r.Max <= 0 || id <= r.Max)
+}
+
+// String ...
+func (r IDRange) String() string {
+	if r.Max <= 0 {
+		return fmt.Sprintf("%d-", r.Min)
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+// ParseIDRange parses a range such as "2000-9999" or "10000-" (no upper bound)
+func ParseIDRange(s string) (IDRange, error) {
+	var r IDRange
+	bounds := strings.SplitN(strings.TrimSpace(s), "-", 2)
+	if len(bounds) != 2 {
+		return r, fmt.Errorf("invalid id range %q: expected MIN-MAX", s)
+	}
+	var err error
+	if r.Min, err = strconv.Atoi(bounds[0]); err != nil || r.Min < 1 {
+		return r, fmt.Errorf("invalid id range %q: invalid lower bound", s)
+	}
+	if bounds[1] != "" {
+		if r.Max, err = strconv.Atoi(bounds[1]); err != nil || r.Max < r.Min {
+			return r, fmt.Errorf("invalid id range %q: invalid upper bound", s)
+		}
+	}
+	return r, nil
+}
+
+// ParseIDPools parses named pools such as "service=10000-19999"
+func ParseIDPools(pools []string) (map[string]IDRange, error) {
+	parsed := make(map[string]IDRange)
+	for _, pool := range pools {
+		pair := strings.SplitN(pool, "=", 2)
+		if len(pair) != 2 || pair[0] == "" {
+			return nil, fmt.Errorf("invalid id pool %q: expected NAME=MIN-MAX", pool)
+		}
+		r, err := ParseIDRange(pair[1])
+		if err != nil {
+			return nil, err
+		}
+		parsed[pair[0]] = r
+	}
+	return parsed, nil
+}
+
+// overlaps checks if the ranges share any ID
+func (r IDRange) overlaps(other IDRange) bool {
+	return (other.Max <= 0 || r.Min <= other.Max) && (r.Max <= 0 || other.Min <= r.Max)
+}
+
+// within checks if the range is completely inside the other range
+func (r IDRange) within(other IDRange) bool {
+	return r.Min >= other.Min && (other.Max <= 0 || (r.Max > 0 && r.Max <= other.Max))
+}
+
+// ValidateIDRanges checks that the default range is not empty and that the named pools neither overlap each other
+// nor partially overlap the default range. Pools can be placed inside the default range or outside of it,
+// the default range skips the IDs of pools inside it.
+func ValidateIDRanges(attribute string, defaultRange IDRange, pools map[string]IDRange) error {
+	if defaultRange.Max > 0 && defaultRange.Max < defaultRange.Min {
+		return fmt.Errorf("invalid %s range %s: the upper bound is below the lower bound", attribute, defaultRange)
+	}
+	names := sortedPoolNames(pools)
+	for i, name := range names {
+		r := pools[name]
+		if r.overlaps(defaultRange) && !r.within(defaultRange) {
+			return fmt.Errorf("%s pool %q (%s) partially overlaps the default range %s", attribute, name, r, defaultRange)
+		}
+		for _, other := range names[i+1:] {
+			if r.overlaps(pools[other]) {
+				return fmt.Errorf("%s pools %q (%s) and %q (%s) overlap", attribute, name, r, other, pools[other])
+			}
+		}
+	}
+	return nil
+}
+
+func sortedPoolNames(pools map[string]IDRange) []string {
+	names := make([]string, 0, len(pools))
+	for name := range pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// poolContaining returns the name of the first pool in sorted order that contains the ID, or an empty string
+func poolContaining(pools map[string]IDRange, id int) string {
+	for _, name := range sortedPoolNames(pools) {
+		if pools[name].Contains(id) {
+			return name
+		}
+	}
+	return ""
+}
+
+// IDRangeExhaustedError ...
+type IDRangeExhaustedError struct {
+	ApplicationError
+	Attribute string
+	Pool      string
+	Range     IDRange
+}
+
+// Error ...
+func (e *IDRangeExhaustedError) Error() string {
+	if e.Pool != "" {
+		return fmt.Sprintf("no %s left in pool %q (%s)", e.Attribute, e.Pool, e.Range)
+	}
+	return fmt.Sprintf("no %s left in range %s", e.Attribute, e.Range)
+}
+
+// Code ...
+func (e *IDRangeExhaustedError) Code() codes.Code {
+	return codes.ResourceExhausted
+}
+
+// idCounter describes a counter entry (such as cn=lastUID) that records the last allocated ID
+type idCounter struct {
+	cn          string
+	pool        string
+	desc        string
+	baseDN      string // subtree of the entries that use the IDs
+	filter      string
+	idAttribute string // uidNumber or gidNumber
+	idRange     IDRange
+	excluded    map[string]IDRange // pools nested inside the default range
+}
+
+// contains checks if the ID can be allocated by the counter
+func (c *idCounter) contains(id int) bool {
+	return c.idRange.Contains(id) && poolContaining(c.excluded, id) == ""
+}
+
+func withDefaultMin(r IDRange, min int) IDRange {
+	if r.Min < 1 {
+		r.Min = min + 1
+	}
+	return r
+}
+
+func (m *LDAPManager) uidCounter(pool string) (*idCounter, error) {
+	c := &idCounter{
+		cn:          "lastUID",
+		pool:        pool,
+		desc:        "Records the last UID used to create a Posix account. This prevents the re-use of a UID from a deleted account.",
+		baseDN:      m.UserGroupDN,
+		filter:      fmt.Sprintf("(%s=*)", m.AccountAttribute),
+		idAttribute: "uidNumber",
+		idRange:     withDefaultMin(m.UIDRange, MinUID),
+		excluded:    m.UIDPools,
+	}
+	if pool != "" {
+		r, ok := m.UIDPools[pool]
+		if !ok {
+			return nil, &ValidationError{Message: fmt.Sprintf("unknown uid pool %q", pool)}
+		}
+		c.cn = fmt.Sprintf("lastUID-%s", pool)
+		c.desc = fmt.Sprintf("Records the last UID of pool %q used to create a Posix account.", pool)
+		c.idRange = r
+		c.excluded = nil
+	}
+	return c, nil
+}
+
+func (m *LDAPManager) gidCounter(pool string) (*idCounter, error) {
+	c := &idCounter{
+		cn:          "lastGID",
+		pool:        pool,
+		desc:        "Records the last GID used to create a Posix group. This prevents the re-use of a GID from a deleted group.",
+		baseDN:      m.GroupsDN,
+		filter:      "(objectClass=posixGroup)",
+		idAttribute: "gidNumber",
+		idRange:     withDefaultMin(m.GIDRange, MinGID),
+		excluded:    m.GIDPools,
+	}
+	if pool != "" {
+		r, ok := m.GIDPools[pool]
+		if !ok {
+			return nil, &ValidationError{Message: fmt.Sprintf("unknown gid pool %q", pool)}
+		}
+		c.cn = fmt.Sprintf("lastGID-%s", pool)
+		c.desc = fmt.Sprintf("Records the last GID of pool %q used to create a Posix group.", pool)
+		c.idRange = r
+		c.excluded = nil
+	}
+	return c, nil
+}
+
+// getHighestID returns the highest ID in use within the range of the counter, or one below the range if there is none
+func (m *LDAPManager) getHighestID(c *idCounter) (int, error) {
+	highestID := c.idRange.Min - 1
+	result, err := m.search(ldap.NewSearchRequest(
+		c.baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		c.filter,
+		[]string{c.idAttribute},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return highestID, err
+	}
+	for _, entry := range result.Entries {
+		if id, err := strconv.Atoi(entry.GetAttributeValue(c.idAttribute)); err == nil {
+			if id > highestID && c.contains(id) {
+				highestID = id
+			}
+		}
+	}
+	return highestID, nil
+}
+
+func (m *LDAPManager) setupCounter(c *idCounter) error {
+	highestID, err := m.getHighestID(c)
+	if err != nil {
+		return err
+	}
+	addLastIDRequest := &ldap.AddRequest{
+		DN: fmt.Sprintf("cn=%s,%s", c.cn, m.BaseDN),
+		Attributes: []ldap.Attribute{
+			{Type: "objectClass", Vals: []string{"device", "top"}},
+			{Type: "serialNumber", Vals: []string{strconv.Itoa(highestID)}},
+			{Type: "description", Vals: []string{c.desc}},
+		},
+		Controls: []ldap.Control{},
+	}
+	log.Debugf("addLastIDRequest=%v", addLastIDRequest)
+	return m.add(addLastIDRequest)
+}
+
+// readLastID returns the raw serialNumber of the counter entry and its numeric value
+func (m *LDAPManager) readLastID(c *idCounter) (string, int, error) {
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		fmt.Sprintf("cn=%s,%s", c.cn, m.BaseDN),
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=device)",
+		[]string{"serialNumber"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return "", 0, err
+	}
+	if len(result.Entries) != 1 {
+		return "", 0, ldap.NewError(ldap.LDAPResultNoSuchObject, fmt.Errorf("missing counter cn=%s", c.cn))
+	}
+	raw := result.Entries[0].GetAttributeValue("serialNumber")
+	lastID, err := strconv.Atoi(raw)
+	if err != nil {
+		// the counter is corrupt, recover from the highest ID in use
+		if lastID, err = m.getHighestID(c); err != nil {
+			return "", 0, err
+		}
+	}
+	return raw, lastID, nil
+}
+
+// idInUse checks if any entry already uses the ID
+func (m *LDAPManager) idInUse(c *idCounter, id int) (bool, error) {
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		c.baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 1, 0, false,
+		fmt.Sprintf("(%s=%d)", c.idAttribute, id),
+		[]string{"1.1"},
+		[]ldap.Control{},
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return false, err
+	}
+	return err != nil || len(result.Entries) > 0, nil
+}
+
+// swapLastID atomically replaces the old counter value with the new value.
+// Deleting the old value acts as an assertion: when another writer changed
+// the counter in the meantime, the modify fails with noSuchAttribute.
+// A counter without a value is initialized by adding the value under the assertion
+// that there is still no value, which fails when another writer initialized it first.
+func (m *LDAPManager) swapLastID(c *idCounter, old string, newID int) error {
+	modifyRequest := ldap.NewModifyRequest(
+		fmt.Sprintf("cn=%s,%s", c.cn, m.BaseDN),
+		[]ldap.Control{},
+	)
+	if old != "" {
+		modifyRequest.Delete("serialNumber", []string{old})
+	} else {
+		assertion, err := newAssertionControl("(!(serialNumber=*))")
+		if err != nil {
+			return err
+		}
+		modifyRequest.Controls = append(modifyRequest.Controls, assertion)
+	}
+	modifyRequest.Add("serialNumber", []string{strconv.Itoa(newID)})
+	log.Debugf("modifyRequest=%v", modifyRequest)
+	return m.modify(modifyRequest)
+}
+
+func isIDConflict(err error) bool {
+	return ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) ||
+		ldap.IsErrorWithCode(err, ldap.LDAPResultAssertionFailed) ||
+		ldap.IsErrorWithCode(err, ldap.LDAPResultAttributeOrValueExists)
+}
+
+func waitBeforeRetry(attempt int) {
+	time.Sleep(time.Duration(rand.Intn(10*(attempt+1))) * time.Millisecond)
+}
+
+// allocateID reserves the next unused ID of the counter
+func (m *LDAPManager) allocateID(c *idCounter) (int, error) {
+	for attempt := 0; attempt < maxIDAllocationAttempts; attempt++ {
+		old, lastID, err := m.readLastID(c)
+		if err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+				// Try to recover by running the setup
+				if err := m.setupCounter(c); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) {
+					return 0, fmt.Errorf("failed to setup cn=%s: %v", c.cn, err)
+				}
+				continue
+			}
+			return 0, fmt.Errorf("failed to read cn=%s: %v", c.cn, err)
+		}
+		newID := lastID + 1
+		if newID < c.idRange.Min {
+			newID = c.idRange.Min
+		}
+		for {
+			if !c.idRange.Contains(newID) {
+				return 0, &IDRangeExhaustedError{Attribute: c.idAttribute, Pool: c.pool, Range: c.idRange}
+			}
+			if pool := poolContaining(c.excluded, newID); pool != "" {
+				// the IDs of pools are only allocated from the pool
+				if c.excluded[pool].Max <= 0 {
+					return 0, &IDRangeExhaustedError{Attribute: c.idAttribute, Pool: c.pool, Range: c.idRange}
+				}
+				newID = c.excluded[pool].Max + 1
+				continue
+			}
+			inUse, err := m.idInUse(c, newID)
+			if err != nil {
+				return 0, fmt.Errorf("failed to check if %s=%d is in use: %v", c.idAttribute, newID, err)
+			}
+			if !inUse {
+				break
+			}
+			log.Warnf("skipping %s=%d because it is already in use", c.idAttribute, newID)
+			newID++
+		}
+		if err := m.swapLastID(c, old, newID); err != nil {
+			if isIDConflict(err) {
+				log.Debugf("cn=%s was modified concurrently (attempt %d of %d)", c.cn, attempt+1, maxIDAllocationAttempts)
+				waitBeforeRetry(attempt)
+				continue
+			}
+			return 0, fmt.Errorf("failed to update cn=%s: %v", c.cn, err)
+		}
+		log.Debugf("allocated %s=%d", c.idAttribute, newID)
+		return newID, nil
+	}
+	return 0, fmt.Errorf("failed to allocate a new %s after %d attempts", c.idAttribute, maxIDAllocationAttempts)
+}
+
+// reserveID makes sure the counter is at least at the explicitly assigned ID, so it will not be allocated again
+func (m *LDAPManager) reserveID(c *idCounter, id int) error {
+	if !c.contains(id) {
+		return nil
+	}
+	for attempt := 0; attempt < maxIDAllocationAttempts; attempt++ {
+		old, lastID, err := m.readLastID(c)
+		if err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+				if err := m.setupCounter(c); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) {
+					return fmt.Errorf("failed to setup cn=%s: %v", c.cn, err)
+				}
+				continue
+			}
+			return fmt.Errorf("failed to read cn=%s: %v", c.cn, err)
+		}
+		if lastID >= id {
+			return nil
+		}
+		if err := m.swapLastID(c, old, id); err != nil {
+			if isIDConflict(err) {
+				waitBeforeRetry(attempt)
+				continue
+			}
+			return fmt.Errorf("failed to update cn=%s: %v", c.cn, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("failed to reserve %s=%d after %d attempts", c.idAttribute, id, maxIDAllocationAttempts)
+}
+
+// isConfiguredUID checks if the ID is within the default uid range or any uid pool
+func (m *LDAPManager) isConfiguredUID(id int) bool {
+	return withDefaultMin(m.UIDRange, MinUID).Contains(id) || poolContaining(m.UIDPools, id) != ""
+}
+
+// reserveUID reserves an explicitly assigned uidNumber in the pool or default range that contains it
+func (m *LDAPManager) reserveUID(id int) error {
+	c, err := m.uidCounter(poolContaining(m.UIDPools, id))
+	if err != nil {
+		return err
+	}
+	return m.reserveID(c, id)
+}
+
+// reserveGID reserves an explicitly assigned gidNumber in the pool or default range that contains it
+func (m *LDAPManager) reserveGID(id int) error {
+	c, err := m.gidCounter(poolContaining(m.GIDPools, id))
+	if err != nil {
+		return err
+	}
+	return m.reserveID(c, id)
+}
+
+// AllocateUID atomically reserves the next unused uidNumber from the named pool or the default range if pool is empty
+func (m *LDAPManager) AllocateUID(pool string) (int, error) {
+	c, err := m.uidCounter(pool)
+	if err != nil {
+		return 0, err
+	}
+	return m.allocateID(c)
+}
+
+// AllocateGID atomically reserves the next unused gidNumber from the named pool or the default range if pool is empty
+func (m *LDAPManager) AllocateGID(pool string) (int, error) {
+	c, err := m.gidCounter(pool)
+	if err != nil {
+		return 0, err
+	}
+	return m.allocateID(c)
+}
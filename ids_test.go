@@ -0,0 +1,371 @@
+package ldapmanager
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestConcurrentAccountCreation ...
+func TestConcurrentAccountCreation(t *testing.T) {
+	if skipIDTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	n := 30
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := test.Manager.NewAccount(&pb.NewAccountRequest{
+				Account: &pb.Account{
+					Username:  fmt.Sprintf("parallel%02d", i),
+					Password:  "Hallo Welt",
+					Email:     "a@b.de",
+					FirstName: "roman",
+					LastName:  "d",
+				},
+			}, pb.HashingAlgorithm_DEFAULT); err != nil {
+				errs <- fmt.Errorf("failed to add user %d: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	users, err := test.Manager.GetUserList(&pb.GetUserListRequest{})
+	if err != nil {
+		t.Fatalf("failed to get users list: %v", err)
+	}
+	seen := make(map[string]string)
+	for _, user := range users.GetUsers() {
+		username, uid := user.GetData()[test.Manager.AccountAttribute], user.GetData()["uidNumber"]
+		if other, ok := seen[uid]; ok {
+			t.Errorf("users %q and %q were both assigned uidNumber=%s", other, username, uid)
+		}
+		seen[uid] = username
+	}
+	if len(seen) != n+1 {
+		t.Errorf("expected %d distinct uidNumbers but got %d", n+1, len(seen))
+	}
+}
+
+// TestConcurrentGroupCreation ...
+func TestConcurrentGroupCreation(t *testing.T) {
+	if skipIDTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	n := 30
+	var wg sync.WaitGroup
+	gids := make(chan int32, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("parallel%02d", i)
+			if err := test.Manager.NewGroup(&pb.NewGroupRequest{Name: name, Members: []string{test.Manager.DefaultAdminUsername}}, false); err != nil {
+				t.Errorf("failed to add group %q: %v", name, err)
+				return
+			}
+			group, err := test.Manager.GetGroup(&pb.GetGroupRequest{Name: name})
+			if err != nil {
+				t.Errorf("failed to get group %q: %v", name, err)
+				return
+			}
+			gids <- group.GetGid()
+		}(i)
+	}
+	wg.Wait()
+	close(gids)
+	seen := make(map[int32]bool)
+	for gid := range gids {
+		if seen[gid] {
+			t.Errorf("gidNumber=%d was assigned to multiple groups", gid)
+		}
+		seen[gid] = true
+	}
+}
+
+// TestAllocateIDSkipsUsedIDs ...
+func TestAllocateIDSkipsUsedIDs(t *testing.T) {
+	if skipIDTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	next, err := test.Manager.AllocateUID("")
+	if err != nil {
+		t.Fatalf("failed to allocate uid: %v", err)
+	}
+	if err := test.Manager.NewAccount(&pb.NewAccountRequest{
+		Account: &pb.Account{
+			Username:  "external",
+			Password:  "Hallo Welt",
+			Email:     "a@b.de",
+			FirstName: "roman",
+			LastName:  "d",
+			Uid:       int32(next + 1),
+		},
+	}, pb.HashingAlgorithm_DEFAULT); err != nil {
+		t.Fatalf("failed to add user with explicit uid: %v", err)
+	}
+	// reset the counter, as if the entry was added by another tool
+	counter, _ := test.Manager.uidCounter("")
+	old, _, err := test.Manager.readLastID(counter)
+	if err != nil {
+		t.Fatalf("failed to read the counter: %v", err)
+	}
+	if err := test.Manager.swapLastID(counter, old, next); err != nil {
+		t.Fatalf("failed to reset the counter: %v", err)
+	}
+	allocated, err := test.Manager.AllocateUID("")
+	if err != nil {
+		t.Fatalf("failed to allocate uid: %v", err)
+	}
+	if allocated != next+2 {
+		t.Errorf("expected uid %d to be skipped and %d to be allocated but got %d", next+1, next+2, allocated)
+	}
+}
+
+// TestAllocateIDSkipsNestedPools ...
+func TestAllocateIDSkipsNestedPools(t *testing.T) {
+	if skipIDTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	last, err := test.Manager.AllocateUID("")
+	if err != nil {
+		t.Fatalf("failed to allocate uid: %v", err)
+	}
+	test.Manager.UIDPools = map[string]IDRange{"inside": {Min: last + 1, Max: last + 10}}
+	uid, err := test.Manager.AllocateUID("")
+	if err != nil {
+		t.Fatalf("failed to allocate uid: %v", err)
+	}
+	if uid != last+11 {
+		t.Errorf("expected the pool %s to be skipped and %d to be allocated but got %d", test.Manager.UIDPools["inside"], last+11, uid)
+	}
+	if uid, err = test.Manager.AllocateUID("inside"); err != nil {
+		t.Fatalf("failed to allocate uid from the pool: %v", err)
+	}
+	if uid != last+1 {
+		t.Errorf("expected uid %d from the pool but got %d", last+1, uid)
+	}
+
+	// an unbounded pool leaves no IDs for the default range after its lower bound
+	test.Manager.UIDPools = map[string]IDRange{"inside": {Min: uid + 20}}
+	if _, err := test.Manager.AllocateUID(""); err != nil {
+		t.Fatalf("failed to allocate uid below the unbounded pool: %v", err)
+	}
+	test.Manager.UIDPools = map[string]IDRange{"inside": {Min: last + 1}}
+	if _, err := test.Manager.AllocateUID(""); err == nil {
+		t.Error("expected error when the rest of the default range belongs to a pool")
+	} else if _, ok := err.(*IDRangeExhaustedError); !ok {
+		t.Errorf("expected IDRangeExhaustedError but got %v", err)
+	}
+}
+
+// TestExplicitUIDInPool ...
+func TestExplicitUIDInPool(t *testing.T) {
+	if skipIDTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	test.Manager.UIDPools = map[string]IDRange{"system": {Min: 100, Max: 999}}
+	newAccount := func(username string, uid int) error {
+		return test.Manager.NewAccount(&pb.NewAccountRequest{
+			Account: &pb.Account{
+				Username:  username,
+				Password:  "Hallo Welt",
+				Email:     "a@b.de",
+				FirstName: "system",
+				LastName:  "account",
+				Uid:       int32(uid),
+			},
+		}, pb.HashingAlgorithm_DEFAULT)
+	}
+	if err := newAccount("system", 500); err != nil {
+		t.Fatalf("failed to add account with explicit uid in a pool: %v", err)
+	}
+	account, err := test.Manager.GetAccount(&pb.GetAccountRequest{Username: "system"})
+	if err != nil {
+		t.Fatalf("failed to get account: %v", err)
+	}
+	if uid := account.GetData()["uidNumber"]; uid != "500" {
+		t.Errorf("expected the explicit uidNumber=500 but got %s", uid)
+	}
+	if err := newAccount("outside", 1500); err == nil {
+		t.Error("expected error when the explicit uid is outside of the configured ranges")
+	} else if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("expected ValidationError but got %v", err)
+	}
+}
+
+// TestSetupValidatesIDRanges ...
+func TestSetupValidatesIDRanges(t *testing.T) {
+	manager := &LDAPManager{
+		UIDRange: IDRange{Min: 2000, Max: 9999},
+		UIDPools: map[string]IDRange{"partial": {Min: 9000, Max: 10999}},
+	}
+	if err := manager.Setup(true); err == nil {
+		t.Error("expected setup to reject a pool that partially overlaps the default range")
+	}
+	manager = &LDAPManager{GIDRange: IDRange{Min: 2000, Max: 1000}}
+	if err := manager.Setup(true); err == nil {
+		t.Error("expected setup to reject an empty default range")
+	}
+}
+
+// TestSwapLastIDInitializesOnce ...
+func TestSwapLastIDInitializesOnce(t *testing.T) {
+	if skipIDTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	counter, _ := test.Manager.uidCounter("")
+	old, lastID, err := test.Manager.readLastID(counter)
+	if err != nil {
+		t.Fatalf("failed to read the counter: %v", err)
+	}
+	// remove the value, as if the counter was created without one
+	clearRequest := ldap.NewModifyRequest(fmt.Sprintf("cn=%s,%s", counter.cn, test.Manager.BaseDN), []ldap.Control{})
+	clearRequest.Delete("serialNumber", []string{old})
+	if err := test.Manager.modify(clearRequest); err != nil {
+		t.Fatalf("failed to clear the counter: %v", err)
+	}
+	if err := test.Manager.swapLastID(counter, "", lastID+1); err != nil {
+		t.Fatalf("failed to initialize the counter: %v", err)
+	}
+	if err := test.Manager.swapLastID(counter, "", lastID+2); !isIDConflict(err) {
+		t.Errorf("expected a conflict when initializing the counter twice but got %v", err)
+	}
+	if _, current, err := test.Manager.readLastID(counter); err != nil || current != lastID+1 {
+		t.Errorf("expected the counter to be %d but got %d (%v)", lastID+1, current, err)
+	}
+}
+
+// TestParseIDPools ...
+func TestParseIDPools(t *testing.T) {
+	pools, err := ParseIDPools([]string{"humans=2000-9999", "service=10000-"})
+	if err != nil {
+		t.Fatalf("failed to parse id pools: %v", err)
+	}
+	if expected := (IDRange{Min: 2000, Max: 9999}); pools["humans"] != expected {
+		t.Errorf("expected pool %v but got %v", expected, pools["humans"])
+	}
+	if expected := (IDRange{Min: 10000}); pools["service"] != expected {
+		t.Errorf("expected pool %v but got %v", expected, pools["service"])
+	}
+	if !pools["service"].Contains(1 << 30) {
+		t.Errorf("expected pool without upper bound to contain large ids")
+	}
+	for _, invalid := range []string{"service", "=1-2", "service=abc-", "service=10-5", "service=0-5"} {
+		if _, err := ParseIDPools([]string{invalid}); err == nil {
+			t.Errorf("expected error when parsing invalid pool %q", invalid)
+		}
+	}
+}
+
+// TestValidateIDRanges ...
+func TestValidateIDRanges(t *testing.T) {
+	defaultRange := IDRange{Min: 2000, Max: 9999}
+	valid := map[string]IDRange{"inside": {Min: 3000, Max: 3999}, "service": {Min: 10000}, "system": {Min: 100, Max: 999}}
+	if err := ValidateIDRanges("uid", defaultRange, valid); err != nil {
+		t.Errorf("expected valid pools but got %v", err)
+	}
+	if err := ValidateIDRanges("uid", IDRange{Min: 2000}, map[string]IDRange{"service": {Min: 10000}}); err != nil {
+		t.Errorf("expected a pool inside the unbounded default range to be valid but got %v", err)
+	}
+	if err := ValidateIDRanges("uid", IDRange{Min: 2000, Max: 1000}, nil); err == nil {
+		t.Error("expected an empty default range to be rejected")
+	}
+	for _, pools := range []map[string]IDRange{
+		{"partial": {Min: 9000, Max: 10999}},
+		{"unbounded": {Min: 5000}},
+		{"a": {Min: 10000, Max: 19999}, "b": {Min: 15000}},
+	} {
+		if err := ValidateIDRanges("uid", defaultRange, pools); err == nil {
+			t.Errorf("expected pools %v to be rejected", pools)
+		}
+	}
+	if pool := poolContaining(map[string]IDRange{"b": {Min: 10}, "a": {Min: 1}}, 20); pool != "a" {
+		t.Errorf("expected overlapping pools to be resolved in sorted order but got %q", pool)
+	}
+}
+
+// TestIDPoolsAndExhaustion ...
+func TestIDPoolsAndExhaustion(t *testing.T) {
+	if skipIDTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	test.Manager.UIDPools = map[string]IDRange{"service": {Min: 10000, Max: 10001}}
+	for i, expected := range []int{10000, 10001} {
+		username := fmt.Sprintf("service%d", i)
+		if err := test.Manager.NewAccount(&pb.NewAccountRequest{
+			Account: &pb.Account{
+				Username:  username,
+				Password:  "Hallo Welt",
+				Email:     "a@b.de",
+				FirstName: "service",
+				LastName:  "account",
+				IdPool:    "service",
+			},
+		}, pb.HashingAlgorithm_DEFAULT); err != nil {
+			t.Fatalf("failed to add service account %q: %v", username, err)
+		}
+		account, err := test.Manager.GetAccount(&pb.GetAccountRequest{Username: username})
+		if err != nil {
+			t.Fatalf("failed to get service account %q: %v", username, err)
+		}
+		if uid := account.GetData()["uidNumber"]; uid != strconv.Itoa(expected) {
+			t.Errorf("expected uidNumber=%d for %q but got %s", expected, username, uid)
+		}
+	}
+
+	// the pool is exhausted now
+	if _, err := test.Manager.AllocateUID("service"); err == nil {
+		t.Error("expected error when the pool is exhausted")
+	} else if _, ok := err.(*IDRangeExhaustedError); !ok {
+		t.Errorf("expected IDRangeExhaustedError but got %v", err)
+	}
+	if _, err := test.Manager.AllocateUID("unknown"); err == nil {
+		t.Error("expected error when using an unknown pool")
+	}
+
+	// the default range is not affected by the pool
+	uid, err := test.Manager.AllocateUID("")
+	if err != nil {
+		t.Fatalf("failed to allocate uid from the default range: %v", err)
+	}
+	if uid >= 10000 {
+		t.Errorf("expected uid from the default range but got %d", uid)
+	}
+	test.Manager.UIDRange.Max = uid
+	if _, err := test.Manager.AllocateUID(""); err == nil {
+		t.Error("expected error when the default range is exhausted")
+	}
+}
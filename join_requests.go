@@ -0,0 +1,303 @@
+package ldapmanager
+
+import (
+	"crypto/rand"
+	encodinghex "encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// Requests to join a group are kept as device entries in a dedicated subtree like sessions.
+// The owner is the requesting account, ou is the name of the group and the description is the expiration time.
+// Approving or denying a request deletes it and records the decision in the audit log.
+
+const (
+	// DefaultJoinRequestTTL is the default time after which pending join requests are discarded
+	DefaultJoinRequestTTL = 14 * 24 * time.Hour
+
+	// AuditOperationApprove is the audit operation of approved join requests
+	AuditOperationApprove = "approve"
+	// AuditOperationDeny is the audit operation of denied join requests
+	AuditOperationDeny = "deny"
+
+	joinRequestIDSize = 16
+)
+
+// JoinRequestAlreadyExistsError ...
+type JoinRequestAlreadyExistsError struct {
+	ApplicationError
+	Group, Username string
+}
+
+// Error ...
+func (e *JoinRequestAlreadyExistsError) Error() string {
+	return fmt.Sprintf("%q already requested to join group %q", e.Username, e.Group)
+}
+
+// Code ...
+func (e *JoinRequestAlreadyExistsError) Code() codes.Code {
+	return codes.AlreadyExists
+}
+
+// NoSuchJoinRequestError ...
+type NoSuchJoinRequestError struct {
+	ApplicationError
+	ID, Group string
+}
+
+// Error ...
+func (e *NoSuchJoinRequestError) Error() string {
+	return fmt.Sprintf("no pending request %q to join group %q", e.ID, e.Group)
+}
+
+// Code ...
+func (e *NoSuchJoinRequestError) Code() codes.Code {
+	return codes.NotFound
+}
+
+func (m *LDAPManager) joinRequestsEnabled() bool {
+	return m.JoinRequestDN != ""
+}
+
+func (m *LDAPManager) setupJoinRequestOU() error {
+	ou := strings.TrimPrefix(strings.SplitN(m.JoinRequestDN, ",", 2)[0], "ou=")
+	return m.setupOU(m.JoinRequestDN, ou)
+}
+
+func (m *LDAPManager) joinRequestNamed(id string) string {
+	return fmt.Sprintf("cn=%s,%s", escapeDN(id), m.JoinRequestDN)
+}
+
+func (m *LDAPManager) joinRequestTTL() time.Duration {
+	if m.JoinRequestTTL > 0 {
+		return m.JoinRequestTTL
+	}
+	return DefaultJoinRequestTTL
+}
+
+func (m *LDAPManager) checkJoinRequestsEnabled() error {
+	if !m.joinRequestsEnabled() {
+		return &ValidationError{Message: "group join requests are disabled"}
+	}
+	return nil
+}
+
+// findJoinRequests returns the join requests matching the filter and discards the expired ones
+func (m *LDAPManager) findJoinRequests(filter string) ([]*ldap.Entry, error) {
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.JoinRequestDN,
+		ldap.ScopeSingleLevel, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&(objectClass=device)%s)", filter),
+		[]string{"cn", "ou", "owner", "description"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find join requests: %v", err)
+	}
+	var pending []*ldap.Entry
+	for _, entry := range result.Entries {
+		if time.Now().Before(sessionExpires(entry)) {
+			pending = append(pending, entry)
+			continue
+		}
+		if err := m.del(ldap.NewDelRequest(entry.DN, []ldap.Control{})); err != nil &&
+			!ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			log.Warnf("failed to discard expired join request %q: %v", entry.DN, err)
+		}
+	}
+	return pending, nil
+}
+
+func (m *LDAPManager) joinRequestProto(entry *ldap.Entry) *pb.JoinRequest {
+	username, _ := extractAttribute(entry.GetAttributeValue("owner"), m.AccountAttribute)
+	return &pb.JoinRequest{
+		Id:       entry.GetAttributeValue("cn"),
+		Group:    entry.GetAttributeValue("ou"),
+		Username: username,
+		Expires:  sessionExpires(entry).Unix(),
+	}
+}
+
+// RequestGroupMembership asks the owners of a group to add the user to it
+func (m *LDAPManager) RequestGroupMembership(req *pb.GroupMember) (*pb.JoinRequest, error) {
+	if err := m.checkJoinRequestsEnabled(); err != nil {
+		return nil, err
+	}
+	if req.GetGroup() == "" {
+		return nil, &ValidationError{Message: "group name must not be empty"}
+	}
+	if req.GetUsername() == "" {
+		return nil, &ValidationError{Message: "username must not be empty"}
+	}
+	account, err := m.findAccount(req.GetUsername(), []string{"dn"})
+	if err != nil {
+		return nil, err
+	}
+	memberStatus, err := m.IsGroupMember(&pb.IsGroupMemberRequest{Username: req.GetUsername(), Group: req.GetGroup()})
+	if err != nil {
+		return nil, err
+	}
+	if memberStatus.GetIsMember() {
+		return nil, &MemberAlreadyExistsError{Member: req.GetUsername(), Group: req.GetGroup()}
+	}
+	pending, err := m.findJoinRequests(fmt.Sprintf("(ou=%s)(owner=%s)", escapeFilter(req.GetGroup()), escapeFilter(account.DN)))
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		return nil, &JoinRequestAlreadyExistsError{Group: req.GetGroup(), Username: req.GetUsername()}
+	}
+
+	id := make([]byte, joinRequestIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	request := &pb.JoinRequest{
+		Id:       encodinghex.EncodeToString(id),
+		Group:    req.GetGroup(),
+		Username: req.GetUsername(),
+		Expires:  time.Now().Add(m.joinRequestTTL()).Unix(),
+	}
+	if err := m.add(&ldap.AddRequest{
+		DN: m.joinRequestNamed(request.GetId()),
+		Attributes: []ldap.Attribute{
+			{Type: "objectClass", Vals: []string{"device", "top"}},
+			{Type: "cn", Vals: []string{request.GetId()}},
+			{Type: "ou", Vals: []string{request.GetGroup()}},
+			{Type: "owner", Vals: []string{account.DN}},
+			{Type: "description", Vals: []string{strconv.FormatInt(request.GetExpires(), 10)}},
+		},
+		Controls: []ldap.Control{},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to add request of %q to join group %q: %v", req.GetUsername(), req.GetGroup(), err)
+	}
+	log.Infof("%q requested to join group %q", req.GetUsername(), req.GetGroup())
+	return request, nil
+}
+
+// ListPendingRequests returns the pending join requests, optionally only for a group or user
+func (m *LDAPManager) ListPendingRequests(req *pb.ListPendingRequestsRequest) (*pb.JoinRequestList, error) {
+	if err := m.checkJoinRequestsEnabled(); err != nil {
+		return nil, err
+	}
+	filter := ""
+	if req.GetGroup() != "" {
+		filter += fmt.Sprintf("(ou=%s)", escapeFilter(req.GetGroup()))
+	}
+	if req.GetUsername() != "" {
+		filter += fmt.Sprintf("(owner=%s)", escapeFilter(m.AccountNamed(req.GetUsername())))
+	}
+	pending, err := m.findJoinRequests(filter)
+	if err != nil {
+		return nil, err
+	}
+	requests := &pb.JoinRequestList{Total: int64(len(pending))}
+	for _, entry := range pending {
+		requests.Requests = append(requests.Requests, m.joinRequestProto(entry))
+	}
+	sort.Slice(requests.Requests, func(i, j int) bool {
+		return requests.Requests[i].GetExpires() < requests.Requests[j].GetExpires()
+	})
+	return requests, nil
+}
+
+// getJoinRequest returns a pending request, which must be a request to join the group
+func (m *LDAPManager) getJoinRequest(req *pb.JoinRequestDecision) (*pb.JoinRequest, error) {
+	if err := m.checkJoinRequestsEnabled(); err != nil {
+		return nil, err
+	}
+	if _, err := encodinghex.DecodeString(req.GetId()); err != nil || req.GetId() == "" || req.GetGroup() == "" {
+		return nil, &NoSuchJoinRequestError{ID: req.GetId(), Group: req.GetGroup()}
+	}
+	pending, err := m.findJoinRequests(fmt.Sprintf("(cn=%s)(ou=%s)", escapeFilter(req.GetId()), escapeFilter(req.GetGroup())))
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) != 1 {
+		return nil, &NoSuchJoinRequestError{ID: req.GetId(), Group: req.GetGroup()}
+	}
+	return m.joinRequestProto(pending[0]), nil
+}
+
+// decideJoinRequest removes a pending request and records the decision
+func (m *LDAPManager) decideJoinRequest(request *pb.JoinRequest, operation string, err error) error {
+	if err == nil {
+		if err = m.del(ldap.NewDelRequest(m.joinRequestNamed(request.GetId()), []ldap.Control{})); err != nil &&
+			ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			err = &NoSuchJoinRequestError{ID: request.GetId(), Group: request.GetGroup()}
+		}
+	}
+	m.audit(operation, m.joinRequestNamed(request.GetId()), []string{"ou", "owner"}, err)
+	if err != nil {
+		return err
+	}
+	log.Infof("decided request of %q to join group %q: %s", request.GetUsername(), request.GetGroup(), operation)
+	return nil
+}
+
+// ApproveRequest adds the user of a pending join request to the group
+func (m *LDAPManager) ApproveRequest(req *pb.JoinRequestDecision) error {
+	request, err := m.getJoinRequest(req)
+	if err != nil {
+		return err
+	}
+	err = m.AddGroupMember(&pb.GroupMember{Group: request.GetGroup(), Username: request.GetUsername()}, false)
+	if _, ok := err.(*MemberAlreadyExistsError); ok {
+		err = nil
+	}
+	return m.decideJoinRequest(request, AuditOperationApprove, err)
+}
+
+// DenyRequest discards a pending join request
+func (m *LDAPManager) DenyRequest(req *pb.JoinRequestDecision) error {
+	request, err := m.getJoinRequest(req)
+	if err != nil {
+		return err
+	}
+	return m.decideJoinRequest(request, AuditOperationDeny, nil)
+}
+
+// deleteJoinRequests discards the pending join requests of a deleted account or group
+func (m *LDAPManager) deleteJoinRequests(filter string) error {
+	if !m.joinRequestsEnabled() {
+		return nil
+	}
+	pending, err := m.findJoinRequests(filter)
+	if err != nil {
+		return err
+	}
+	for _, entry := range pending {
+		if err := m.del(ldap.NewDelRequest(entry.DN, []ldap.Control{})); err != nil &&
+			!ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return fmt.Errorf("failed to delete join request %q: %v", entry.DN, err)
+		}
+	}
+	return nil
+}
+
+// moveJoinRequests keeps the pending join requests of a renamed account or group
+func (m *LDAPManager) moveJoinRequests(filter, attribute, value string) error {
+	if !m.joinRequestsEnabled() {
+		return nil
+	}
+	pending, err := m.findJoinRequests(filter)
+	if err != nil {
+		return err
+	}
+	for _, entry := range pending {
+		modifyRequest := ldap.NewModifyRequest(entry.DN, []ldap.Control{})
+		modifyRequest.Replace(attribute, []string{value})
+		if err := m.modify(modifyRequest); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return fmt.Errorf("failed to move join request %q: %v", entry.DN, err)
+		}
+	}
+	return nil
+}
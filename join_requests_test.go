@@ -0,0 +1,90 @@
+package ldapmanager
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestJoinRequests ...
+func TestJoinRequests(t *testing.T) {
+	if skipJoinRequestTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	for _, username := range []string{"lead", "applicant"} {
+		if err := test.Manager.NewAccount(&pb.NewAccountRequest{Account: &pb.Account{
+			Username:  username,
+			Password:  "Hallo Welt",
+			Email:     username + "@example.org",
+			FirstName: "roman",
+			LastName:  "d",
+		}}, pb.HashingAlgorithm_DEFAULT); err != nil {
+			t.Fatalf("failed to add user %q: %v", username, err)
+		}
+	}
+	if err := test.Manager.NewGroup(&pb.NewGroupRequest{Name: "team", Members: []string{"lead"}, Owners: []string{"lead"}}, false); err != nil {
+		t.Fatalf("failed to add group: %v", err)
+	}
+
+	if _, err := test.Manager.RequestGroupMembership(&pb.GroupMember{Group: "team", Username: "lead"}); err == nil {
+		t.Errorf("expected members to not request to join their group")
+	}
+	request, err := test.Manager.RequestGroupMembership(&pb.GroupMember{Group: "team", Username: "applicant"})
+	if err != nil {
+		t.Fatalf("failed to request to join group: %v", err)
+	}
+	if _, err := test.Manager.RequestGroupMembership(&pb.GroupMember{Group: "team", Username: "applicant"}); err == nil {
+		t.Errorf("expected a second request to join the same group to fail")
+	}
+	pending, err := test.Manager.ListPendingRequests(&pb.ListPendingRequestsRequest{Group: "team"})
+	if err != nil {
+		t.Fatalf("failed to list pending requests: %v", err)
+	}
+	if len(pending.GetRequests()) != 1 || pending.GetRequests()[0].GetUsername() != "applicant" {
+		t.Fatalf("expected one pending request of applicant but got %v", pending.GetRequests())
+	}
+
+	if err := test.Manager.ApproveRequest(&pb.JoinRequestDecision{Id: request.GetId(), Group: "other"}); err == nil {
+		t.Errorf("expected approving a request for another group to fail")
+	}
+	if err := test.Manager.As("lead").ApproveRequest(&pb.JoinRequestDecision{Id: request.GetId(), Group: "team"}); err != nil {
+		t.Fatalf("failed to approve request: %v", err)
+	}
+	memberStatus, err := test.Manager.IsGroupMember(&pb.IsGroupMemberRequest{Group: "team", Username: "applicant"})
+	if err != nil {
+		t.Fatalf("failed to check membership: %v", err)
+	}
+	if !memberStatus.GetIsMember() {
+		t.Errorf("expected applicant to be a member after the approval")
+	}
+	if err := test.Manager.DenyRequest(&pb.JoinRequestDecision{Id: request.GetId(), Group: "team"}); err == nil {
+		t.Errorf("expected deciding a request twice to fail")
+	}
+	auditLog, err := test.Manager.GetAuditLog(&pb.GetAuditLogRequest{Actor: "lead", Operation: AuditOperationApprove})
+	if err != nil {
+		t.Fatalf("failed to get audit log: %v", err)
+	}
+	if len(auditLog.GetRecords()) != 1 || auditLog.GetRecords()[0].GetResult() != AuditResultSuccess {
+		t.Errorf("expected the approval to be recorded but got %v", auditLog.GetRecords())
+	}
+
+	// expired requests are discarded
+	test.Manager.JoinRequestTTL = time.Second
+	if err := test.Manager.DeleteGroupMember(&pb.GroupMember{Group: "team", Username: "applicant"}, false); err != nil {
+		t.Fatalf("failed to remove member: %v", err)
+	}
+	if _, err := test.Manager.RequestGroupMembership(&pb.GroupMember{Group: "team", Username: "applicant"}); err != nil {
+		t.Fatalf("failed to request to join group: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+	if pending, err = test.Manager.ListPendingRequests(&pb.ListPendingRequestsRequest{Username: "applicant"}); err != nil {
+		t.Fatalf("failed to list pending requests: %v", err)
+	}
+	if len(pending.GetRequests()) != 0 {
+		t.Errorf("expected expired requests to be discarded but got %v", pending.GetRequests())
+	}
+}
@@ -3,10 +3,13 @@ package ldapmanager
 import (
 	"crypto/tls"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-ldap/ldap"
 	ldapconfig "github.com/romnn/ldap-manager/config"
 	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	ldaphash "github.com/romnn/ldap-manager/hash"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -16,7 +19,8 @@ const Version = "0.0.26"
 // LDAPManager ...
 type LDAPManager struct {
 	ldapconfig.OpenLDAPConfig
-	ldap *ldap.Conn // Client
+	*managerState
+	Pool ConnPoolConfig
 
 	GroupsDN    string
 	UserGroupDN string
@@ -24,7 +28,14 @@ type LDAPManager struct {
 	GroupsOU string
 	UsersOU  string
 
-	HashingAlgorithm  pb.HashingAlgorithm
+	HashingAlgorithm pb.HashingAlgorithm
+	// HashingOptions configures the cost of the hashing algorithms (e.g. the bcrypt cost)
+	HashingOptions ldaphash.Options
+	// UpgradeHashesOnLogin rehashes passwords with the HashingAlgorithm after a successful login
+	// if the stored hash uses a weaker scheme according to HashSchemeStrength
+	UpgradeHashesOnLogin bool
+	HashSchemeStrength   map[string]int
+
 	DefaultUserGroup  string
 	DefaultAdminGroup string
 	DefaultUserShell  string
@@ -38,14 +49,91 @@ type LDAPManager struct {
 	GroupAttribute           string
 
 	GroupMembershipUsesUID bool
+	// GroupMembershipSyncAttributes receive the same changes as the GroupMembershipAttribute
+	GroupMembershipSyncAttributes []string
+
+	// UIDRange and GIDRange limit the automatically allocated IDs, named pools can be selected per account or group
+	UIDRange IDRange
+	GIDRange IDRange
+	UIDPools map[string]IDRange
+	GIDPools map[string]IDRange
+
+	// PasswordPolicy is enforced whenever a password is set
+	PasswordPolicy PasswordPolicy
+	// PasswordHistorySize is the number of previous passwords per account that can not be reused (0 disables the history)
+	PasswordHistorySize int
+	PasswordHistoryDN   string
+
+	// MailSender delivers password reset tokens, password reset is disabled without a sender
+	MailSender MailSender
+	// PasswordResetURL is included in password reset mails with {token} replaced by the token
+	PasswordResetURL        string
+	PasswordResetDN         string
+	PasswordResetTTL        time.Duration
+	PasswordResetRateLimit  int
+	PasswordResetRateWindow time.Duration
+
+	// TwoFactorKey encrypts the TOTP secrets and signs login challenges, two-factor authentication is disabled without a key
+	TwoFactorKey string
+	// TwoFactorAttribute of the account entries stores the encrypted TOTP secret
+	TwoFactorAttribute string
+	TwoFactorIssuer    string
+	// RequireTwoFactorForAdmins only grants admin privileges to members of the DefaultAdminGroup with two-factor authentication
+	RequireTwoFactorForAdmins bool
+
+	// Roles are the permissions of each role, RoleGroups the LDAP groups whose members hold a role
+	Roles      map[string][]pb.Permission
+	RoleGroups map[string][]string
+
+	// PPolicyOverlay enables the password policy controls on login and the management of pwdPolicy entries below PPolicyDN
+	PPolicyOverlay bool
+	PPolicyDN      string
+
+	// SessionDN stores the sessions of issued tokens, tokens can not be refreshed or revoked without it
+	SessionDN string
+	// SessionTTL is the validity of refresh tokens, each refresh extends the session
+	SessionTTL time.Duration
+
+	// JoinRequestDN stores the pending requests of users to join groups, which are discarded after JoinRequestTTL
+	JoinRequestDN  string
+	JoinRequestTTL time.Duration
+
+	// Audit records all directory mutations
+	Audit *AuditLog
+	actor string
+}
+
+// managerState is the runtime state created by Setup. It is shared by the manager and all of its actor views (see As),
+// so synchronization primitives and other state that must not be copied belong here.
+type managerState struct {
+	ldap         *ConnPool // Client
+	resetLimiter *rateLimiter
+	otpLimiter   *rateLimiter
+	// background tracks work that outlives a request (e.g. sending password reset mails), Close waits for it
+	background sync.WaitGroup
 }
 
 // NewLDAPManager ...
 func NewLDAPManager(cfg ldapconfig.OpenLDAPConfig) *LDAPManager {
 	return &LDAPManager{
 		OpenLDAPConfig:           cfg,
+		Pool:                     DefaultConnPoolConfig(),
 		GroupsDN:                 "ou=groups," + cfg.BaseDN,
 		UserGroupDN:              "ou=users," + cfg.BaseDN,
+		PasswordHistoryDN:        "ou=password-history," + cfg.BaseDN,
+		PasswordResetDN:          "ou=password-resets," + cfg.BaseDN,
+		PPolicyDN:                "ou=policies," + cfg.BaseDN,
+		SessionDN:                "ou=sessions," + cfg.BaseDN,
+		SessionTTL:               DefaultSessionTTL,
+		JoinRequestDN:            "ou=requests," + cfg.BaseDN,
+		JoinRequestTTL:           DefaultJoinRequestTTL,
+		Roles:                    DefaultRoles(),
+		TwoFactorAttribute:       DefaultTwoFactorAttribute,
+		TwoFactorIssuer:          DefaultTwoFactorIssuer,
+		PasswordResetTTL:         DefaultPasswordResetTTL,
+		PasswordResetRateLimit:   DefaultPasswordResetRateLimit,
+		PasswordResetRateWindow:  DefaultPasswordResetRateWindow,
+		HashingOptions:           ldaphash.DefaultOptions(),
 		GroupsOU:                 "groups",
 		UsersOU:                  "users",
 		DefaultUserGroup:         "users",
@@ -58,39 +146,64 @@ func NewLDAPManager(cfg ldapconfig.OpenLDAPConfig) *LDAPManager {
 		DefaultAdminUsername:     "admin",
 		DefaultAdminPassword:     "admin",
 		ForceCreateAdmin:         false,
+		UIDRange:                 IDRange{Min: MinUID + 1},
+		GIDRange:                 IDRange{Min: MinGID + 1},
+		Audit:                    NewAuditLog(),
 	}
 }
 
 // Close ...
 func (m *LDAPManager) Close() {
-	if m.ldap != nil {
-		// FIXME: This will panic if the connection was not established
-		m.ldap.Close()
+	if m.managerState != nil {
+		m.background.Wait()
+		if m.ldap != nil {
+			m.ldap.Close()
+		}
+	}
+	if m.Audit != nil {
+		if err := m.Audit.Close(); err != nil {
+			log.Warnf("failed to close the audit log: %v", err)
+		}
 	}
 }
 
-// Setup ...
-func (m *LDAPManager) Setup(skipSetupLDAP bool) error {
-	var err error
+func (m *LDAPManager) dial() (*ldap.Conn, error) {
 	URI := m.OpenLDAPConfig.URI()
 	log.Debugf("connecting to OpenLDAP at %s", URI)
-	m.ldap, err = ldap.DialURL(URI)
+	conn, err := ldap.DialURL(URI)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Check for TLS
 	if strings.HasPrefix(URI, "ldaps:") || m.OpenLDAPConfig.TLS {
-		if err := m.ldap.StartTLS(&tls.Config{InsecureSkipVerify: true}); err != nil {
+		if err := conn.StartTLS(&tls.Config{InsecureSkipVerify: true}); err != nil {
 			log.Warnf("failed to connect via TLS: %v", err)
 			if m.OpenLDAPConfig.TLS {
-				return err
+				conn.Close()
+				return nil, err
 			}
 		}
 	}
+	return conn, nil
+}
+
+// Setup ...
+func (m *LDAPManager) Setup(skipSetupLDAP bool) error {
+	if err := ValidateIDRanges("uid", withDefaultMin(m.UIDRange, MinUID), m.UIDPools); err != nil {
+		return err
+	}
+	if err := ValidateIDRanges("gid", withDefaultMin(m.GIDRange, MinGID), m.GIDPools); err != nil {
+		return err
+	}
+	m.managerState = &managerState{
+		ldap:         NewConnPool(m.Pool, m.dial, m.BindAdmin),
+		resetLimiter: newPasswordResetLimiter(m.PasswordResetRateLimit, m.PasswordResetRateWindow),
+		otpLimiter:   newRateLimiter(otpAttemptLimit, otpAttemptLimitWindow),
+	}
 
-	// Bind as the admin user
-	if err := m.BindAdmin(); err != nil {
+	// Make sure we can connect and bind as the admin user
+	if err := m.ldap.Ping(); err != nil {
 		return err
 	}
 	if !skipSetupLDAP {
@@ -0,0 +1,551 @@
+package ldapmanager
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ldifLineWidth is the maximum length of a line before it is folded
+	ldifLineWidth = 76
+
+	// ImportActionAdd ...
+	ImportActionAdd = "add"
+	// ImportActionUpdate ...
+	ImportActionUpdate = "update"
+	// ImportActionSkip ...
+	ImportActionSkip = "skip"
+	// ImportActionInvalid ...
+	ImportActionInvalid = "invalid"
+)
+
+// isSafeLDIFString checks if a value can be written without base64 encoding (RFC 2849)
+func isSafeLDIFString(value string) bool {
+	if value == "" {
+		return true
+	}
+	switch value[0] {
+	case ' ', ':', '<':
+		return false
+	}
+	if value[len(value)-1] == ' ' {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c == 0 || c == '\n' || c == '\r' || c > 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLDIFLine(w *bufio.Writer, line string) error {
+	for len(line) > ldifLineWidth {
+		if _, err := w.WriteString(line[:ldifLineWidth] + "\n "); err != nil {
+			return err
+		}
+		line = line[ldifLineWidth:]
+	}
+	_, err := w.WriteString(line + "\n")
+	return err
+}
+
+func writeLDIFValue(w *bufio.Writer, name, value string) error {
+	if isSafeLDIFString(value) {
+		return writeLDIFLine(w, fmt.Sprintf("%s: %s", name, value))
+	}
+	return writeLDIFLine(w, fmt.Sprintf("%s:: %s", name, base64.StdEncoding.EncodeToString([]byte(value))))
+}
+
+// WriteLDIF writes the entries as LDIF content records
+func WriteLDIF(out io.Writer, entries []*ldap.Entry) error {
+	w := bufio.NewWriter(out)
+	if _, err := w.WriteString("version: 1\n"); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if _, err := w.WriteString("\n"); err != nil {
+			return err
+		}
+		if err := writeLDIFValue(w, "dn", entry.DN); err != nil {
+			return err
+		}
+		for _, attr := range entry.Attributes {
+			for _, value := range attr.Values {
+				if err := writeLDIFValue(w, attr.Name, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return w.Flush()
+}
+
+// ReadLDIF parses LDIF content records. Change records other than "changetype: add" are not supported.
+func ReadLDIF(in io.Reader) ([]*ldap.Entry, error) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	var entries []*ldap.Entry
+	var lines []string
+	var lineNumber, recordStart int
+
+	parseRecord := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		defer func() { lines = nil }()
+		var entry *ldap.Entry
+		attributes := make(map[string]*ldap.EntryAttribute)
+		for i, line := range lines {
+			sep := strings.Index(line, ":")
+			if sep < 1 {
+				return fmt.Errorf("line %d: invalid attribute %q", recordStart+i, line)
+			}
+			name, value := line[:sep], line[sep+1:]
+			switch {
+			case strings.HasPrefix(value, ":"):
+				decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value[1:]))
+				if err != nil {
+					return fmt.Errorf("line %d: invalid base64 value of %q: %v", recordStart+i, name, err)
+				}
+				value = string(decoded)
+			case strings.HasPrefix(value, "<"):
+				return fmt.Errorf("line %d: URL values are not supported", recordStart+i)
+			default:
+				value = strings.TrimLeft(value, " ")
+			}
+
+			if entry == nil {
+				if strings.ToLower(name) == "version" && len(entries) == 0 {
+					if value != "1" {
+						return fmt.Errorf("line %d: unsupported LDIF version %q", recordStart+i, value)
+					}
+					continue
+				}
+				if strings.ToLower(name) != "dn" {
+					return fmt.Errorf("line %d: expected dn but got %q", recordStart+i, name)
+				}
+				entry = &ldap.Entry{DN: value}
+				continue
+			}
+			if strings.ToLower(name) == "changetype" {
+				if strings.ToLower(value) != "add" {
+					return fmt.Errorf("line %d: unsupported changetype %q", recordStart+i, value)
+				}
+				continue
+			}
+			key := strings.ToLower(name)
+			attr, ok := attributes[key]
+			if !ok {
+				attr = &ldap.EntryAttribute{Name: name}
+				attributes[key] = attr
+				entry.Attributes = append(entry.Attributes, attr)
+			}
+			attr.Values = append(attr.Values, value)
+			attr.ByteValues = append(attr.ByteValues, []byte(value))
+		}
+		if entry != nil {
+			entries = append(entries, entry)
+		}
+		return nil
+	}
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case line == "":
+			if err := parseRecord(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, " "):
+			if len(lines) == 0 {
+				return nil, fmt.Errorf("line %d: unexpected continuation line", lineNumber)
+			}
+			lines[len(lines)-1] += line[1:]
+		default:
+			if len(lines) == 0 {
+				recordStart = lineNumber
+			}
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := parseRecord(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (m *LDAPManager) exportSubtree(baseDN string) ([]*ldap.Entry, error) {
+	result, err := m.search(ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)",
+		[]string{"*"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return nil, err
+	}
+	return result.Entries, nil
+}
+
+func dnDepth(dn string) int {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil {
+		return 0
+	}
+	return len(parsed.RDNs)
+}
+
+// ExportLDIF writes all users and groups including their organizational units as LDIF
+func (m *LDAPManager) ExportLDIF(w io.Writer) error {
+	var entries []*ldap.Entry
+	for _, baseDN := range []string{m.UserGroupDN, m.GroupsDN} {
+		subtree, err := m.exportSubtree(baseDN)
+		if err != nil {
+			return fmt.Errorf("failed to export %q: %v", baseDN, err)
+		}
+		// parents must be imported before their children
+		sort.SliceStable(subtree, func(i, j int) bool {
+			return dnDepth(subtree[i].DN) < dnDepth(subtree[j].DN)
+		})
+		entries = append(entries, subtree...)
+	}
+	return WriteLDIF(w, entries)
+}
+
+// ImportResult describes the outcome of importing a single entry
+type ImportResult struct {
+	DN      string `json:"dn"`
+	Action  string `json:"action"`
+	Message string `json:"message,omitempty"`
+}
+
+type ldifEntryKind int
+
+const (
+	ldifEntryOther ldifEntryKind = iota
+	ldifEntryOU
+	ldifEntryAccount
+	ldifEntryGroup
+)
+
+func sameDN(a, b string) bool {
+	dnA, errA := ldap.ParseDN(strings.ToLower(a))
+	dnB, errB := ldap.ParseDN(strings.ToLower(b))
+	return errA == nil && errB == nil && dnA.Equal(dnB)
+}
+
+func parentDN(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil || len(parsed.RDNs) < 1 {
+		return ""
+	}
+	var parts []string
+	for _, rdn := range parsed.RDNs[1:] {
+		var values []string
+		for _, attr := range rdn.Attributes {
+			values = append(values, fmt.Sprintf("%s=%s", attr.Type, escapeDN(attr.Value)))
+		}
+		parts = append(parts, strings.Join(values, "+"))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (m *LDAPManager) classifyLDIFEntry(entry *ldap.Entry) ldifEntryKind {
+	if sameDN(entry.DN, m.UserGroupDN) || sameDN(entry.DN, m.GroupsDN) {
+		return ldifEntryOU
+	}
+	parent := parentDN(entry.DN)
+	switch {
+	case sameDN(parent, m.UserGroupDN):
+		return ldifEntryAccount
+	case sameDN(parent, m.GroupsDN):
+		return ldifEntryGroup
+	}
+	return ldifEntryOther
+}
+
+func hasObjectClass(entry *ldap.Entry, objectClass string) bool {
+	for _, class := range entry.GetAttributeValues("objectClass") {
+		if strings.EqualFold(class, objectClass) {
+			return true
+		}
+	}
+	return false
+}
+
+func setEntryAttribute(entry *ldap.Entry, name string, values []string) {
+	for _, attr := range entry.Attributes {
+		if strings.EqualFold(attr.Name, name) {
+			attr.Values = values
+			return
+		}
+	}
+	entry.Attributes = append(entry.Attributes, ldap.NewEntryAttribute(name, values))
+}
+
+func rdnValue(dn, attribute string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil || len(parsed.RDNs) < 1 {
+		return ""
+	}
+	for _, attr := range parsed.RDNs[0].Attributes {
+		if strings.EqualFold(attr.Type, attribute) {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// validateImportedAccount checks the account against the conventions used by NewAccount and fills in defaults
+func (m *LDAPManager) validateImportedAccount(entry *ldap.Entry) (bool, error) {
+	username := rdnValue(entry.DN, m.AccountAttribute)
+	if username == "" {
+		return false, fmt.Errorf("account must be named by %s", m.AccountAttribute)
+	}
+	var invalid []string
+	for _, class := range []string{"inetOrgPerson", "posixAccount"} {
+		if !hasObjectClass(entry, class) {
+			invalid = append(invalid, fmt.Sprintf("objectClass %s", class))
+		}
+	}
+	if value := entry.GetAttributeValue(m.AccountAttribute); value != "" && value != username {
+		invalid = append(invalid, m.AccountAttribute)
+	}
+	for _, attr := range []string{"sn", "cn"} {
+		if entry.GetAttributeValue(attr) == "" {
+			invalid = append(invalid, attr)
+		}
+	}
+	if mail := entry.GetAttributeValue("mail"); mail != "" && !validEmail(mail) {
+		invalid = append(invalid, "mail")
+	}
+	for _, attr := range []string{"uidNumber", "gidNumber"} {
+		if value := entry.GetAttributeValue(attr); value != "" {
+			if _, err := strconv.Atoi(value); err != nil {
+				invalid = append(invalid, attr)
+			}
+		}
+	}
+	if len(invalid) > 0 {
+		return false, &AccountValidationError{Invalid: invalid}
+	}
+
+	setEntryAttribute(entry, m.AccountAttribute, []string{username})
+	if entry.GetAttributeValue("loginShell") == "" {
+		setEntryAttribute(entry, "loginShell", []string{m.DefaultUserShell})
+	}
+	if entry.GetAttributeValue("homeDirectory") == "" {
+		setEntryAttribute(entry, "homeDirectory", []string{fmt.Sprintf("/home/%s", username)})
+	}
+	if entry.GetAttributeValue("displayName") == "" {
+		setEntryAttribute(entry, "displayName", []string{entry.GetAttributeValue("cn")})
+	}
+	return entry.GetAttributeValue("uidNumber") == "", nil
+}
+
+// validateImportedGroup checks the group against the conventions used by NewGroup
+func (m *LDAPManager) validateImportedGroup(entry *ldap.Entry) (bool, error) {
+	name := rdnValue(entry.DN, "cn")
+	if name == "" {
+		return false, &ValidationError{Message: "group must be named by cn"}
+	}
+	if !hasObjectClass(entry, "posixGroup") {
+		return false, &ValidationError{Message: "group must have objectClass posixGroup"}
+	}
+	if m.UseRFC2307BISSchema {
+		if !hasObjectClass(entry, "groupOfUniqueNames") {
+			return false, &ValidationError{Message: "when using RFC2307BIS (not NIS), groups must have objectClass groupOfUniqueNames"}
+		}
+		if len(entry.GetAttributeValues(m.GroupMembershipAttribute)) < 1 {
+			return false, &ValidationError{Message: "when using RFC2307BIS (not NIS), groups must have at least one member"}
+		}
+	}
+	if value := entry.GetAttributeValue("gidNumber"); value != "" {
+		if _, err := strconv.Atoi(value); err != nil {
+			return false, &ValidationError{Message: "invalid gidNumber"}
+		}
+	}
+	setEntryAttribute(entry, "cn", []string{name})
+	return entry.GetAttributeValue("gidNumber") == "", nil
+}
+
+func (m *LDAPManager) entryExists(dn string) (bool, error) {
+	_, err := m.ldap.Search(ldap.NewSearchRequest(
+		dn,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)",
+		[]string{"1.1"},
+		[]ldap.Control{},
+	))
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// reserveImportedID makes sure an imported uidNumber or gidNumber will not be allocated again
+func (m *LDAPManager) reserveImportedID(kind ldifEntryKind, entry *ldap.Entry) error {
+	switch kind {
+	case ldifEntryAccount:
+		if uid, err := strconv.Atoi(entry.GetAttributeValue("uidNumber")); err == nil {
+			return m.reserveUID(uid)
+		}
+	case ldifEntryGroup:
+		if gid, err := strconv.Atoi(entry.GetAttributeValue("gidNumber")); err == nil {
+			return m.reserveGID(gid)
+		}
+	}
+	return nil
+}
+
+func (m *LDAPManager) importEntry(entry *ldap.Entry, exists bool) error {
+	if !exists {
+		addRequest := &ldap.AddRequest{DN: entry.DN, Controls: []ldap.Control{}}
+		for _, attr := range entry.Attributes {
+			addRequest.Attribute(attr.Name, attr.Values)
+		}
+		return m.add(addRequest)
+	}
+	rdnAttributes := make(map[string]bool)
+	if parsed, err := ldap.ParseDN(entry.DN); err == nil && len(parsed.RDNs) > 0 {
+		for _, attr := range parsed.RDNs[0].Attributes {
+			rdnAttributes[strings.ToLower(attr.Type)] = true
+		}
+	}
+	modifyRequest := ldap.NewModifyRequest(entry.DN, []ldap.Control{})
+	for _, attr := range entry.Attributes {
+		if !rdnAttributes[strings.ToLower(attr.Name)] {
+			modifyRequest.Replace(attr.Name, attr.Values)
+		}
+	}
+	return m.modify(modifyRequest)
+}
+
+// ImportLDIF applies the users and groups of an LDIF file.
+// Accounts and groups are checked against the conventions used by NewAccount and NewGroup
+// and missing uidNumber or gidNumber attributes are allocated.
+// Entries that already exist are updated. When dryRun is set, nothing is written.
+func (m *LDAPManager) ImportLDIF(r io.Reader, dryRun bool) ([]*ImportResult, error) {
+	entries, err := ReadLDIF(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse LDIF: %v", err)
+	}
+
+	// organizational units first, then accounts before the groups they are members of
+	sort.SliceStable(entries, func(i, j int) bool {
+		return m.classifyLDIFEntry(entries[i]) < m.classifyLDIFEntry(entries[j])
+	})
+
+	var results []*ImportResult
+	for _, entry := range entries {
+		result := &ImportResult{DN: entry.DN}
+		results = append(results, result)
+
+		var allocate bool
+		var err error
+		kind := m.classifyLDIFEntry(entry)
+		switch kind {
+		case ldifEntryOU:
+		case ldifEntryAccount:
+			allocate, err = m.validateImportedAccount(entry)
+		case ldifEntryGroup:
+			allocate, err = m.validateImportedGroup(entry)
+		default:
+			result.Action = ImportActionSkip
+			result.Message = fmt.Sprintf("not below %q or %q", m.UserGroupDN, m.GroupsDN)
+			continue
+		}
+		if err != nil {
+			result.Action = ImportActionInvalid
+			result.Message = err.Error()
+			continue
+		}
+
+		exists, err := m.entryExists(entry.DN)
+		if err != nil {
+			return results, fmt.Errorf("failed to check if %q exists: %v", entry.DN, err)
+		}
+		result.Action = ImportActionAdd
+		if exists {
+			result.Action = ImportActionUpdate
+		}
+		if kind == ldifEntryOU && exists {
+			result.Action = ImportActionSkip
+			result.Message = "organizational unit already exists"
+			continue
+		}
+
+		if allocate {
+			if exists {
+				// keep the existing id
+				allocate = false
+			} else if dryRun {
+				result.Message = "will allocate a new id"
+			} else {
+				var id int
+				var attribute string
+				if kind == ldifEntryAccount {
+					attribute = "uidNumber"
+					id, err = m.AllocateUID("")
+				} else {
+					attribute = "gidNumber"
+					id, err = m.AllocateGID("")
+				}
+				if err != nil {
+					return results, err
+				}
+				setEntryAttribute(entry, attribute, []string{strconv.Itoa(id)})
+				result.Message = fmt.Sprintf("allocated %s=%d", attribute, id)
+			}
+		}
+		var group string
+		if kind == ldifEntryAccount && entry.GetAttributeValue("gidNumber") == "" && !dryRun {
+			var gid int
+			if group, gid, err = m.getGroupForAccount(rdnValue(entry.DN, m.AccountAttribute)); err != nil {
+				return results, err
+			}
+			setEntryAttribute(entry, "gidNumber", []string{strconv.Itoa(gid)})
+		}
+		if dryRun {
+			continue
+		}
+		if err := m.importEntry(entry, exists); err != nil {
+			result.Action = ImportActionInvalid
+			result.Message = err.Error()
+			continue
+		}
+		if group != "" {
+			allowNonExistent := false
+			member := &pb.GroupMember{Group: group, Username: rdnValue(entry.DN, m.AccountAttribute)}
+			if err := m.AddGroupMember(member, allowNonExistent); err != nil {
+				if _, ok := err.(*MemberAlreadyExistsError); !ok {
+					log.Warnf("failed to add %q to group %q: %v", entry.DN, group, err)
+				}
+			}
+		}
+		if !allocate {
+			if err := m.reserveImportedID(kind, entry); err != nil {
+				log.Warnf("failed to reserve the id of %q: %v", entry.DN, err)
+			}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,173 @@
+package ldapmanager
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap"
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestLDIFRoundtrip ...
+func TestLDIFRoundtrip(t *testing.T) {
+	entries := []*ldap.Entry{
+		ldap.NewEntry("uid=romnn,ou=users,dc=example,dc=org", map[string][]string{
+			"objectClass": {"person", "inetOrgPerson", "posixAccount"},
+			"cn":          {"Roman Dahm"},
+			"description": {strings.Repeat("a very long description ", 10)},
+			"sn":          {"Dähm"},
+			"title":       {" leading space", ":colon", "<angle", "trailing space "},
+		}),
+		ldap.NewEntry("cn=users,ou=groups,dc=example,dc=org", map[string][]string{
+			"objectClass":  {"top", "posixGroup"},
+			"gidNumber":    {"2001"},
+			"uniqueMember": {"uid=romnn,ou=users,dc=example,dc=org"},
+		}),
+	}
+	var buf bytes.Buffer
+	if err := WriteLDIF(&buf, entries); err != nil {
+		t.Fatalf("failed to write LDIF: %v", err)
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if len(line) > ldifLineWidth+1 {
+			t.Errorf("expected lines to be folded but got %q", line)
+		}
+	}
+	if !strings.Contains(buf.String(), "sn:: ") {
+		t.Errorf("expected non ASCII value to be base64 encoded:\n%s", buf.String())
+	}
+
+	parsed, err := ReadLDIF(&buf)
+	if err != nil {
+		t.Fatalf("failed to read LDIF: %v", err)
+	}
+	if len(parsed) != len(entries) {
+		t.Fatalf("expected %d entries but got %d", len(entries), len(parsed))
+	}
+	for i := range entries {
+		if parsed[i].DN != entries[i].DN {
+			t.Errorf("expected dn %q but got %q", entries[i].DN, parsed[i].DN)
+		}
+		for _, attr := range entries[i].Attributes {
+			if diff := cmp.Diff(attr.Values, parsed[i].GetAttributeValues(attr.Name)); diff != "" {
+				t.Errorf("unexpected values of %q in %q: %s", attr.Name, entries[i].DN, diff)
+			}
+		}
+	}
+}
+
+// TestReadLDIF ...
+func TestReadLDIF(t *testing.T) {
+	input := `version: 1
+# comment
+dn: cn=devs,ou=groups,
+ dc=example,dc=org
+changetype: add
+objectClass: posixGroup
+cn:: ZGV2cw==
+
+dn: cn=ops,ou=groups,dc=example,dc=org
+cn: ops
+`
+	entries, err := ReadLDIF(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("failed to read LDIF: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries but got %d", len(entries))
+	}
+	if entries[0].DN != "cn=devs,ou=groups,dc=example,dc=org" {
+		t.Errorf("expected folded dn to be joined but got %q", entries[0].DN)
+	}
+	if cn := entries[0].GetAttributeValue("cn"); cn != "devs" {
+		t.Errorf("expected base64 value to be decoded but got %q", cn)
+	}
+
+	for _, invalid := range []string{
+		"dn: cn=a\nchangetype: delete\n",
+		"cn: a\n",
+		" continued\n",
+		"dn: cn=a\ncn:: %%%\n",
+		"dn: cn=a\njpegPhoto:< file:///photo.jpg\n",
+	} {
+		if _, err := ReadLDIF(strings.NewReader(invalid)); err == nil {
+			t.Errorf("expected error when reading invalid LDIF %q", invalid)
+		}
+	}
+}
+
+// TestExportImportLDIF ...
+func TestExportImportLDIF(t *testing.T) {
+	if skipLDIFTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	if err := test.Manager.NewAccount(&pb.NewAccountRequest{
+		Account: &pb.Account{
+			Username:  "romnn",
+			Password:  "Hallo Welt",
+			Email:     "a@b.de",
+			FirstName: "roman",
+			LastName:  "d",
+		},
+	}, pb.HashingAlgorithm_DEFAULT); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+	var exported bytes.Buffer
+	if err := test.Manager.ExportLDIF(&exported); err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	if !strings.Contains(exported.String(), "dn: "+test.Manager.AccountNamed("romnn")) {
+		t.Errorf("expected exported LDIF to contain the user:\n%s", exported.String())
+	}
+
+	// add a new account without a uidNumber to the export
+	input := exported.String() + `
+dn: ` + test.Manager.AccountNamed("imported") + `
+objectClass: person
+objectClass: inetOrgPerson
+objectClass: posixAccount
+cn: imported user
+sn: user
+mail: imported@example.com
+
+dn: ` + test.Manager.AccountNamed("broken") + `
+objectClass: person
+cn: broken
+`
+	results, err := test.Manager.ImportLDIF(strings.NewReader(input), true)
+	if err != nil {
+		t.Fatalf("failed to import (dry run): %v", err)
+	}
+	actions := make(map[string]string)
+	for _, result := range results {
+		actions[result.DN] = result.Action
+	}
+	if action := actions[test.Manager.AccountNamed("romnn")]; action != ImportActionUpdate {
+		t.Errorf("expected existing user to be updated but got %q", action)
+	}
+	if action := actions[test.Manager.AccountNamed("imported")]; action != ImportActionAdd {
+		t.Errorf("expected new user to be added but got %q", action)
+	}
+	if action := actions[test.Manager.AccountNamed("broken")]; action != ImportActionInvalid {
+		t.Errorf("expected invalid user to be rejected but got %q", action)
+	}
+	if _, err := test.Manager.GetAccount(&pb.GetAccountRequest{Username: "imported"}); err == nil {
+		t.Error("expected dry run to not add any users")
+	}
+
+	if _, err := test.Manager.ImportLDIF(strings.NewReader(input), false); err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+	imported, err := test.Manager.GetAccount(&pb.GetAccountRequest{Username: "imported"})
+	if err != nil {
+		t.Fatalf("failed to get imported user: %v", err)
+	}
+	if imported.GetData()["uidNumber"] == "" || imported.GetData()["gidNumber"] == "" {
+		t.Errorf("expected uidNumber and gidNumber to be allocated but got %v", imported.GetData())
+	}
+}
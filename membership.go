@@ -0,0 +1,286 @@
+package ldapmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+)
+
+// Groups can keep their members in several attributes at once, e.g. memberUid for sssd and uniqueMember for
+// web applications. Members are read from the GroupMembershipAttribute and every change is also applied to the
+// GroupMembershipSyncAttributes. memberUid holds usernames, uniqueMember holds DNs which can also be nested groups.
+
+var membershipAttributes = []string{"uniqueMember", "memberUID"}
+
+// ParseGroupMembershipSyncAttributes validates the attributes that are kept in sync with the membership attribute
+func ParseGroupMembershipSyncAttributes(attributes []string, membershipAttribute string, rfc2307bis bool) ([]string, error) {
+	var synced []string
+	for _, attribute := range attributes {
+		valid := false
+		for _, name := range membershipAttributes {
+			if strings.EqualFold(attribute, name) {
+				attribute, valid = name, true
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid group membership attribute %q: expected one of %s", attribute, strings.Join(membershipAttributes, ", "))
+		}
+		if strings.EqualFold(attribute, membershipAttribute) {
+			continue
+		}
+		if !strings.EqualFold(attribute, "memberUID") && !rfc2307bis {
+			return nil, fmt.Errorf("group membership attribute %q requires the RFC2307BIS schema", attribute)
+		}
+		synced = append(synced, attribute)
+	}
+	return synced, nil
+}
+
+// groupMembershipAttributes returns the membership attribute followed by the attributes kept in sync with it
+func (m *LDAPManager) groupMembershipAttributes() []string {
+	attributes := []string{m.GroupMembershipAttribute}
+	for _, attribute := range m.GroupMembershipSyncAttributes {
+		if !strings.EqualFold(attribute, m.GroupMembershipAttribute) {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
+
+// membershipUsesUID reports if the attribute holds usernames instead of DNs
+func (m *LDAPManager) membershipUsesUID(attribute string) bool {
+	if strings.EqualFold(attribute, m.GroupMembershipAttribute) {
+		return m.GroupMembershipUsesUID
+	}
+	return strings.EqualFold(attribute, "memberUID")
+}
+
+// memberValue returns the value of a user or nested group in the membership attribute
+func (m *LDAPManager) memberValue(attribute, name string, isGroup bool) (string, bool) {
+	if m.membershipUsesUID(attribute) {
+		return escapeDN(name), !isGroup
+	}
+	if isGroup {
+		return m.GroupNamed(name), true
+	}
+	return m.AccountNamed(name), true
+}
+
+// syncMembership adds the changes of a member to the sync attributes that are not in sync yet
+func (m *LDAPManager) syncMembership(modifyRequest *ldap.ModifyRequest, name string, isGroup, add bool) error {
+	if len(m.GroupMembershipSyncAttributes) < 1 {
+		return nil
+	}
+	attributes := m.groupMembershipAttributes()[1:]
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		modifyRequest.DN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=posixGroup)",
+		attributes,
+		[]ldap.Control{},
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			// the change of the membership attribute reports the missing group
+			return nil
+		}
+		return fmt.Errorf("failed to get members of group %q: %v", modifyRequest.DN, err)
+	}
+	if len(result.Entries) != 1 {
+		return nil
+	}
+	for _, attribute := range attributes {
+		value, ok := m.memberValue(attribute, name, isGroup)
+		if !ok {
+			continue
+		}
+		present := containsFold(result.Entries[0].GetAttributeValues(attribute), value)
+		if add && !present {
+			modifyRequest.Add(attribute, []string{value})
+		} else if !add && present {
+			modifyRequest.Delete(attribute, []string{value})
+		}
+	}
+	return nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+type groupMember struct {
+	name    string
+	isGroup bool
+}
+
+// parseMembers returns the members of the attribute by their original value, values of unknown DNs are skipped
+func (m *LDAPManager) parseMembers(attribute string, values []string) map[groupMember]string {
+	members := make(map[groupMember]string)
+	for _, value := range values {
+		member := groupMember{name: value}
+		if !m.membershipUsesUID(attribute) {
+			member.name, member.isGroup = m.memberName(value)
+		}
+		if member.name != "" {
+			members[member] = value
+		}
+	}
+	return members
+}
+
+// repairGroupMembership returns the changes that bring the membership attributes of the group in sync
+func (m *LDAPManager) repairGroupMembership(entry *ldap.Entry, authoritative string) (*ldap.ModifyRequest, []*pb.GroupMembershipRepair) {
+	attributes := m.groupMembershipAttributes()
+	members := make(map[string]map[groupMember]string)
+	wanted := make(map[groupMember]bool)
+	// memberUid can not hold nested groups, so an authoritative memberUid does not decide about them
+	groupsAuthoritative := authoritative == "" || !m.membershipUsesUID(authoritative)
+	for _, attribute := range attributes {
+		members[attribute] = m.parseMembers(attribute, entry.GetAttributeValues(attribute))
+		for member := range members[attribute] {
+			if authoritative == "" || attribute == authoritative || (member.isGroup && !groupsAuthoritative) {
+				wanted[member] = true
+			}
+		}
+	}
+
+	group := entry.GetAttributeValue("cn")
+	modifyRequest := ldap.NewModifyRequest(entry.DN, []ldap.Control{})
+	var repairs []*pb.GroupMembershipRepair
+	for _, attribute := range attributes {
+		repair := &pb.GroupMembershipRepair{Group: group, Attribute: attribute}
+		var add, remove []string
+		for member := range wanted {
+			if _, ok := members[attribute][member]; ok {
+				continue
+			}
+			if value, ok := m.memberValue(attribute, member.name, member.isGroup); ok {
+				add = append(add, value)
+				repair.Added = append(repair.Added, member.name)
+			}
+		}
+		for member, value := range members[attribute] {
+			if !wanted[member] {
+				remove = append(remove, value)
+				repair.Removed = append(repair.Removed, member.name)
+			}
+		}
+		if len(add) > 0 {
+			modifyRequest.Add(attribute, add)
+		}
+		if len(remove) > 0 {
+			modifyRequest.Delete(attribute, remove)
+		}
+		if len(add) > 0 || len(remove) > 0 {
+			sort.Strings(repair.Added)
+			sort.Strings(repair.Removed)
+			repairs = append(repairs, repair)
+		}
+	}
+	return modifyRequest, repairs
+}
+
+// RepairGroupMembership reconciles the membership attributes of groups that drifted apart.
+// Without an authoritative attribute, members missing in any of the attributes are added to the others.
+func (m *LDAPManager) RepairGroupMembership(req *pb.RepairGroupMembershipRequest) (*pb.GroupMembershipRepairs, error) {
+	authoritative := ""
+	if req.GetAuthoritative() != "" {
+		for _, attribute := range m.groupMembershipAttributes() {
+			if strings.EqualFold(attribute, req.GetAuthoritative()) {
+				authoritative = attribute
+			}
+		}
+		if authoritative == "" {
+			return nil, &ValidationError{Message: fmt.Sprintf("%q is not a group membership attribute", req.GetAuthoritative()), Field: "authoritative"}
+		}
+	}
+	filter := "(objectClass=posixGroup)"
+	if req.GetGroup() != "" {
+		filter = fmt.Sprintf("(&(objectClass=posixGroup)(cn=%s))", escapeFilter(req.GetGroup()))
+	}
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.GroupsDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		append([]string{"cn"}, m.groupMembershipAttributes()...),
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find groups: %v", err)
+	}
+	if req.GetGroup() != "" && len(result.Entries) != 1 {
+		return nil, &ZeroOrMultipleGroupsError{Group: req.GetGroup(), Count: len(result.Entries)}
+	}
+
+	repairs := &pb.GroupMembershipRepairs{}
+	for _, entry := range result.Entries {
+		modifyRequest, groupRepairs := m.repairGroupMembership(entry, authoritative)
+		if len(groupRepairs) < 1 {
+			continue
+		}
+		repairs.Repairs = append(repairs.Repairs, groupRepairs...)
+		if req.GetDryRun() {
+			continue
+		}
+		if err := m.modify(modifyRequest); err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultObjectClassViolation) {
+				return repairs, &RemoveLastGroupMemberError{Group: entry.GetAttributeValue("cn")}
+			}
+			return repairs, fmt.Errorf("failed to repair members of group %q: %v", entry.GetAttributeValue("cn"), err)
+		}
+		log.Infof("repaired the membership attributes of group %q", entry.GetAttributeValue("cn"))
+	}
+	sort.SliceStable(repairs.Repairs, func(i, j int) bool {
+		return repairs.Repairs[i].GetGroup() < repairs.Repairs[j].GetGroup()
+	})
+	return repairs, nil
+}
+
+// renameGroupMember replaces a renamed user in all membership attributes of its groups
+func (m *LDAPManager) renameGroupMember(username, newUsername string) error {
+	attributes := m.groupMembershipAttributes()
+	filter := ""
+	for _, attribute := range attributes {
+		value, _ := m.memberValue(attribute, username, false)
+		filter += fmt.Sprintf("(%s=%s)", attribute, escapeFilter(value))
+	}
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.GroupsDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&(objectClass=posixGroup)(|%s))", filter),
+		append([]string{"cn"}, attributes...),
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return fmt.Errorf("failed to get list of groups: %v", err)
+	}
+	for _, entry := range result.Entries {
+		modifyRequest := ldap.NewModifyRequest(entry.DN, []ldap.Control{})
+		for _, attribute := range attributes {
+			values := entry.GetAttributeValues(attribute)
+			value, _ := m.memberValue(attribute, username, false)
+			newValue, _ := m.memberValue(attribute, newUsername, false)
+			if !containsFold(values, value) {
+				continue
+			}
+			modifyRequest.Delete(attribute, []string{value})
+			if !containsFold(values, newValue) {
+				modifyRequest.Add(attribute, []string{newValue})
+			}
+		}
+		if err := m.modify(modifyRequest); err != nil {
+			return fmt.Errorf("failed to rename member %q to %q in group %q: %v", username, newUsername, entry.GetAttributeValue("cn"), err)
+		}
+		log.Infof("Switched member %q to %q in user group %q ", username, newUsername, entry.GetAttributeValue("cn"))
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package ldapmanager
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestParseGroupMembershipSyncAttributes ...
+func TestParseGroupMembershipSyncAttributes(t *testing.T) {
+	attributes, err := ParseGroupMembershipSyncAttributes([]string{"memberuid", "uniqueMember"}, "uniqueMember", true)
+	if err != nil {
+		t.Fatalf("failed to parse attributes: %v", err)
+	}
+	if !reflect.DeepEqual(attributes, []string{"memberUID"}) {
+		t.Errorf("expected [memberUID] but got %v", attributes)
+	}
+	if _, err := ParseGroupMembershipSyncAttributes([]string{"member"}, "uniqueMember", true); err == nil {
+		t.Errorf("expected unknown attributes to fail")
+	}
+	if _, err := ParseGroupMembershipSyncAttributes([]string{"uniqueMember"}, "memberUID", false); err == nil {
+		t.Errorf("expected uniqueMember to require the RFC2307BIS schema")
+	}
+}
+
+// TestRepairGroupMembership ...
+func TestRepairGroupMembership(t *testing.T) {
+	m := &LDAPManager{
+		GroupsDN:                      "ou=groups,dc=example,dc=org",
+		UserGroupDN:                   "ou=users,dc=example,dc=org",
+		AccountAttribute:              "uid",
+		GroupMembershipAttribute:      "uniqueMember",
+		GroupMembershipSyncAttributes: []string{"memberUID"},
+	}
+	entry := ldap.NewEntry("cn=team,ou=groups,dc=example,dc=org", map[string][]string{
+		"cn":           {"team"},
+		"uniqueMember": {m.AccountNamed("a"), m.AccountNamed("b"), m.GroupNamed("nested")},
+		"memberUID":    {"b", "c"},
+	})
+
+	modifyRequest, repairs := m.repairGroupMembership(entry, "")
+	expected := []*pb.GroupMembershipRepair{
+		{Group: "team", Attribute: "uniqueMember", Added: []string{"c"}},
+		{Group: "team", Attribute: "memberUID", Added: []string{"a"}},
+	}
+	if !reflect.DeepEqual(repairs, expected) {
+		t.Errorf("expected repairs %v but got %v", expected, repairs)
+	}
+	if len(modifyRequest.Changes) != 2 {
+		t.Errorf("expected two changes but got %v", modifyRequest.Changes)
+	}
+
+	_, repairs = m.repairGroupMembership(entry, "memberUID")
+	expected = []*pb.GroupMembershipRepair{
+		{Group: "team", Attribute: "uniqueMember", Added: []string{"c"}, Removed: []string{"a"}},
+	}
+	if !reflect.DeepEqual(repairs, expected) {
+		t.Errorf("expected repairs %v but got %v", expected, repairs)
+	}
+
+	// nested groups are kept because memberUID can not hold them
+	nestedOnly := ldap.NewEntry("cn=parent,ou=groups,dc=example,dc=org", map[string][]string{
+		"cn":           {"parent"},
+		"uniqueMember": {m.GroupNamed("nested")},
+	})
+	modifyRequest, repairs = m.repairGroupMembership(nestedOnly, "memberUID")
+	if len(repairs) != 0 || len(modifyRequest.Changes) != 0 {
+		t.Errorf("expected no repairs of a group with only nested groups but got %v", repairs)
+	}
+}
+
+// TestGroupMembershipSync ...
+func TestGroupMembershipSync(t *testing.T) {
+	if skipMembershipSyncTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	test.Manager.GroupMembershipSyncAttributes = []string{"memberUID"}
+	for _, username := range []string{"a", "b"} {
+		if err := test.Manager.NewAccount(&pb.NewAccountRequest{Account: &pb.Account{
+			Username:  username,
+			Password:  "Hallo Welt",
+			Email:     username + "@example.org",
+			FirstName: "roman",
+			LastName:  "d",
+		}}, pb.HashingAlgorithm_DEFAULT); err != nil {
+			t.Fatalf("failed to add user %q: %v", username, err)
+		}
+	}
+	memberUIDs := func() []string {
+		result, err := test.Manager.findGroup("team", []string{"memberUID"})
+		if err != nil || len(result.Entries) != 1 {
+			t.Fatalf("failed to get group (err=%v)", err)
+		}
+		return result.Entries[0].GetAttributeValues("memberUID")
+	}
+
+	if err := test.Manager.NewGroup(&pb.NewGroupRequest{Name: "team", Members: []string{"a"}}, false); err != nil {
+		t.Fatalf("failed to add group: %v", err)
+	}
+	if err := test.Manager.AddGroupMember(&pb.GroupMember{Group: "team", Username: "b"}, false); err != nil {
+		t.Fatalf("failed to add member: %v", err)
+	}
+	if uids := memberUIDs(); !reflect.DeepEqual(uids, []string{"a", "b"}) {
+		t.Errorf("expected memberUID [a b] but got %v", uids)
+	}
+	if _, _, err := test.Manager.UpdateAccount(&pb.UpdateAccountRequest{Username: "b", Update: &pb.Account{Username: "c"}}, pb.HashingAlgorithm_DEFAULT, true); err != nil {
+		t.Fatalf("failed to rename account: %v", err)
+	}
+	if uids := memberUIDs(); !reflect.DeepEqual(uids, []string{"a", "c"}) {
+		t.Errorf("expected memberUID [a c] after the rename but got %v", uids)
+	}
+	if err := test.Manager.DeleteGroupMember(&pb.GroupMember{Group: "team", Username: "a"}, false); err != nil {
+		t.Fatalf("failed to remove member: %v", err)
+	}
+	if uids := memberUIDs(); !reflect.DeepEqual(uids, []string{"c"}) {
+		t.Errorf("expected memberUID [c] but got %v", uids)
+	}
+
+	// drift the attributes apart and repair them
+	modifyRequest := ldap.NewModifyRequest(test.Manager.GroupNamed("team"), []ldap.Control{})
+	modifyRequest.Add("memberUID", []string{"a"})
+	if err := test.Manager.modify(modifyRequest); err != nil {
+		t.Fatalf("failed to modify group: %v", err)
+	}
+	repairs, err := test.Manager.RepairGroupMembership(&pb.RepairGroupMembershipRequest{Group: "team", DryRun: true})
+	if err != nil {
+		t.Fatalf("failed to repair group: %v", err)
+	}
+	if len(repairs.GetRepairs()) != 1 || !reflect.DeepEqual(repairs.GetRepairs()[0].GetAdded(), []string{"a"}) {
+		t.Fatalf("expected a to be added to uniqueMember but got %v", repairs.GetRepairs())
+	}
+	if _, err := test.Manager.RepairGroupMembership(&pb.RepairGroupMembershipRequest{Group: "team"}); err != nil {
+		t.Fatalf("failed to repair group: %v", err)
+	}
+	memberStatus, err := test.Manager.IsGroupMember(&pb.IsGroupMemberRequest{Group: "team", Username: "a"})
+	if err != nil {
+		t.Fatalf("failed to check membership: %v", err)
+	}
+	if !memberStatus.GetIsMember() {
+		t.Errorf("expected a to be a member after the repair")
+	}
+}
@@ -0,0 +1,265 @@
+package ldapmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// With the RFC2307BIS schema, the member attribute of a group can also hold the DN of another group.
+// The members of the nested group are effective members of the parent group. Nested groups are resolved
+// breadth first and every group is visited only once, so cycles in existing data do not loop forever.
+
+// NestedGroupCycleError is returned if adding a nested group would make a group a member of itself
+type NestedGroupCycleError struct {
+	ApplicationError
+	Group, MemberGroup string
+}
+
+// Error ...
+func (e *NestedGroupCycleError) Error() string {
+	return fmt.Sprintf("adding group %q to group %q would create a cycle", e.MemberGroup, e.Group)
+}
+
+// Code ...
+func (e *NestedGroupCycleError) Code() codes.Code {
+	return codes.FailedPrecondition
+}
+
+func (m *LDAPManager) nestedGroupsSupported() bool {
+	return m.UseRFC2307BISSchema && !m.GroupMembershipUsesUID
+}
+
+func (m *LDAPManager) isGroupDN(dn string) bool {
+	return strings.HasSuffix(strings.ToLower(dn), ","+strings.ToLower(m.GroupsDN))
+}
+
+// memberName returns the username or group name of a member value
+func (m *LDAPManager) memberName(member string) (string, bool) {
+	if m.GroupMembershipUsesUID {
+		return member, false
+	}
+	dn, err := ldap.ParseDN(member)
+	if err != nil || len(dn.RDNs) < 1 || len(dn.RDNs[0].Attributes) < 1 {
+		return "", false
+	}
+	rdn := dn.RDNs[0].Attributes[0]
+	if m.isGroupDN(member) {
+		if strings.EqualFold(rdn.Type, "cn") {
+			return rdn.Value, true
+		}
+		return "", true
+	}
+	if strings.EqualFold(rdn.Type, m.AccountAttribute) {
+		return rdn.Value, false
+	}
+	return "", false
+}
+
+// parentGroups returns the groups with any of the DNs as a direct member
+func (m *LDAPManager) parentGroups(memberDNs []string) ([]*ldap.Entry, error) {
+	filter := ""
+	for _, memberDN := range memberDNs {
+		filter += fmt.Sprintf("(%s=%s)", m.GroupMembershipAttribute, escapeFilter(memberDN))
+	}
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.GroupsDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&(objectClass=posixGroup)(|%s))", filter),
+		[]string{"cn"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return nil, err
+	}
+	return result.Entries, nil
+}
+
+// effectiveGroups returns the names of all groups the DN is a member of, directly or through nested groups
+func (m *LDAPManager) effectiveGroups(memberDN string) ([]string, error) {
+	var groups []string
+	visited := map[string]bool{strings.ToLower(memberDN): true}
+	for frontier := []string{memberDN}; len(frontier) > 0; {
+		parents, err := m.parentGroups(frontier)
+		if err != nil {
+			return nil, err
+		}
+		frontier = nil
+		for _, parent := range parents {
+			if key := strings.ToLower(parent.DN); !visited[key] {
+				visited[key] = true
+				groups = append(groups, parent.GetAttributeValue("cn"))
+				frontier = append(frontier, parent.DN)
+			}
+		}
+	}
+	return groups, nil
+}
+
+// effectiveMembers returns the usernames of all members of the group, directly or through nested groups
+func (m *LDAPManager) effectiveMembers(groupName string) ([]string, error) {
+	var members []string
+	seen := make(map[string]bool)
+	visited := map[string]bool{strings.ToLower(m.GroupNamed(groupName)): true}
+	for frontier := []string{m.GroupNamed(groupName)}; len(frontier) > 0; {
+		groupDN := frontier[0]
+		frontier = frontier[1:]
+		result, err := m.ldap.Search(ldap.NewSearchRequest(
+			groupDN,
+			ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+			"(objectClass=posixGroup)",
+			[]string{m.GroupMembershipAttribute},
+			[]ldap.Control{},
+		))
+		if err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+				// nested groups can be left over after a group was deleted
+				continue
+			}
+			return nil, err
+		}
+		for _, entry := range result.Entries {
+			for _, member := range entry.GetAttributeValues(m.GroupMembershipAttribute) {
+				name, isGroup := m.memberName(member)
+				if isGroup {
+					if key := strings.ToLower(member); !visited[key] {
+						visited[key] = true
+						frontier = append(frontier, member)
+					}
+				} else if name != "" && !seen[name] {
+					seen[name] = true
+					members = append(members, name)
+				}
+			}
+		}
+	}
+	return members, nil
+}
+
+func (m *LDAPManager) addNestedGroup(req *pb.GroupMember) error {
+	if !m.nestedGroupsSupported() {
+		return &ValidationError{Message: "nested groups require the RFC2307BIS schema with DN members", Field: "member_group"}
+	}
+	result, err := m.findGroup(req.GetMemberGroup(), []string{"dn"})
+	if err != nil {
+		return err
+	}
+	if len(result.Entries) != 1 {
+		return &ZeroOrMultipleGroupsError{Group: req.GetMemberGroup(), Count: len(result.Entries)}
+	}
+	// the new member must not already contain the group
+	parents, err := m.effectiveGroups(m.GroupNamed(req.GetGroup()))
+	if err != nil {
+		return fmt.Errorf("failed to check for nested group cycles: %v", err)
+	}
+	parents = append(parents, req.GetGroup())
+	for _, parent := range parents {
+		if strings.EqualFold(parent, req.GetMemberGroup()) {
+			return &NestedGroupCycleError{Group: req.GetGroup(), MemberGroup: req.GetMemberGroup()}
+		}
+	}
+
+	modifyRequest := ldap.NewModifyRequest(m.GroupNamed(req.GetGroup()), []ldap.Control{})
+	modifyRequest.Add(m.GroupMembershipAttribute, []string{m.GroupNamed(req.GetMemberGroup())})
+	if err := m.syncMembership(modifyRequest, req.GetMemberGroup(), true, true); err != nil {
+		return err
+	}
+	if err := m.modify(modifyRequest); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultAttributeOrValueExists) {
+			return &MemberAlreadyExistsError{Member: req.GetMemberGroup(), Group: req.GetGroup()}
+		}
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return &ZeroOrMultipleGroupsError{Group: req.GetGroup()}
+		}
+		return err
+	}
+	log.Infof("added group %q to group %q", req.GetMemberGroup(), req.GetGroup())
+	return nil
+}
+
+func (m *LDAPManager) deleteNestedGroup(req *pb.GroupMember) error {
+	modifyRequest := ldap.NewModifyRequest(m.GroupNamed(req.GetGroup()), []ldap.Control{})
+	modifyRequest.Delete(m.GroupMembershipAttribute, []string{m.GroupNamed(req.GetMemberGroup())})
+	if err := m.syncMembership(modifyRequest, req.GetMemberGroup(), true, false); err != nil {
+		return err
+	}
+	if err := m.modify(modifyRequest); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultObjectClassViolation) {
+			return &RemoveLastGroupMemberError{Group: req.GetGroup()}
+		}
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) || ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) {
+			return &NoSuchMemberError{Group: req.GetGroup(), Member: req.GetMemberGroup()}
+		}
+		return err
+	}
+	log.Infof("removed group %q from group %q", req.GetMemberGroup(), req.GetGroup())
+	return nil
+}
+
+// replaceNestedGroup replaces a nested group in all parent groups, the group is only removed if the new DN is empty
+func (m *LDAPManager) replaceNestedGroup(groupDN, newGroupDN string) error {
+	if !m.nestedGroupsSupported() {
+		return nil
+	}
+	parents, err := m.parentGroups([]string{groupDN})
+	if err != nil {
+		return fmt.Errorf("failed to find parent groups of %q: %v", groupDN, err)
+	}
+	for _, parent := range parents {
+		modifyRequest := ldap.NewModifyRequest(parent.DN, []ldap.Control{})
+		modifyRequest.Delete(m.GroupMembershipAttribute, []string{groupDN})
+		if newGroupDN != "" {
+			modifyRequest.Add(m.GroupMembershipAttribute, []string{newGroupDN})
+		}
+		if err := m.modify(modifyRequest); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) {
+			return fmt.Errorf("failed to update nested group %q in %q: %v", groupDN, parent.DN, err)
+		}
+	}
+	return nil
+}
+
+func sortedGroupList(groups []string) *pb.GroupList {
+	sort.Strings(groups)
+	return &pb.GroupList{Groups: groups, Total: int64(len(groups))}
+}
+
+// isPrivilegedGroup reports if the group is the default user or admin group or grants a role to its members
+func (m *LDAPManager) isPrivilegedGroup(group string) bool {
+	if m.IsProtectedGroup(group) {
+		return true
+	}
+	for _, roleGroups := range m.RoleGroups {
+		for _, roleGroup := range roleGroups {
+			if strings.EqualFold(roleGroup, group) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsNestedInProtectedGroup reports if the group is the default user or admin group, a group mapped to a role
+// or nested in one of them
+func (m *LDAPManager) IsNestedInProtectedGroup(group string) (bool, error) {
+	if m.isPrivilegedGroup(group) {
+		return true, nil
+	}
+	if !m.nestedGroupsSupported() {
+		return false, nil
+	}
+	parents, err := m.effectiveGroups(m.GroupNamed(group))
+	if err != nil {
+		return false, fmt.Errorf("failed to find parent groups of %q: %v", group, err)
+	}
+	for _, parent := range parents {
+		if m.isPrivilegedGroup(parent) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
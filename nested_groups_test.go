@@ -0,0 +1,134 @@
+package ldapmanager
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestMemberName ...
+func TestMemberName(t *testing.T) {
+	m := &LDAPManager{GroupsDN: "ou=groups,dc=example,dc=org", AccountAttribute: "uid"}
+	cases := []struct {
+		member  string
+		name    string
+		isGroup bool
+	}{
+		{"uid=romnn,ou=users,dc=example,dc=org", "romnn", false},
+		{"cn=team,ou=groups,dc=example,dc=org", "team", true},
+		{"CN=Team,OU=Groups,DC=example,DC=org", "Team", true},
+		{"uid=a\\,b,ou=users,dc=example,dc=org", "a,b", false},
+		{"cn=other,dc=example,dc=org", "", false},
+	}
+	for _, c := range cases {
+		name, isGroup := m.memberName(c.member)
+		if name != c.name || isGroup != c.isGroup {
+			t.Errorf("expected %q to be (%q, %t) but got (%q, %t)", c.member, c.name, c.isGroup, name, isGroup)
+		}
+	}
+	m.GroupMembershipUsesUID = true
+	if name, isGroup := m.memberName("romnn"); name != "romnn" || isGroup {
+		t.Errorf("expected uid members to be used as usernames but got (%q, %t)", name, isGroup)
+	}
+}
+
+// TestNestedGroups ...
+func TestNestedGroups(t *testing.T) {
+	if skipNestedGroupTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	for _, username := range []string{"lead", "dev"} {
+		if err := test.Manager.NewAccount(&pb.NewAccountRequest{Account: &pb.Account{
+			Username:  username,
+			Password:  "Hallo Welt",
+			Email:     username + "@example.org",
+			FirstName: "roman",
+			LastName:  "d",
+		}}, pb.HashingAlgorithm_DEFAULT); err != nil {
+			t.Fatalf("failed to add user %q: %v", username, err)
+		}
+	}
+	if err := test.Manager.NewGroup(&pb.NewGroupRequest{Name: "engineering", Members: []string{"lead"}}, false); err != nil {
+		t.Fatalf("failed to add group: %v", err)
+	}
+	if err := test.Manager.NewGroup(&pb.NewGroupRequest{Name: "backend", Members: []string{"dev"}}, false); err != nil {
+		t.Fatalf("failed to add group: %v", err)
+	}
+	if err := test.Manager.AddGroupMember(&pb.GroupMember{Group: "engineering", MemberGroup: "backend"}, false); err != nil {
+		t.Fatalf("failed to add nested group: %v", err)
+	}
+	if err := test.Manager.AddGroupMember(&pb.GroupMember{Group: "backend", MemberGroup: "engineering"}, false); err == nil {
+		t.Errorf("expected adding a nested group that creates a cycle to fail")
+	}
+	if err := test.Manager.AddGroupMember(&pb.GroupMember{Group: "backend", MemberGroup: "backend"}, false); err == nil {
+		t.Errorf("expected adding a group to itself to fail")
+	}
+	if err := test.Manager.DeleteGroupMember(&pb.GroupMember{Group: "engineering", Username: "lead", MemberGroup: "backend"}, false); err == nil {
+		t.Errorf("expected removing a user and a group at once to fail")
+	}
+
+	group, err := test.Manager.GetGroup(&pb.GetGroupRequest{Name: "engineering"})
+	if err != nil {
+		t.Fatalf("failed to get group: %v", err)
+	}
+	if !reflect.DeepEqual(group.GetMembers(), []string{"lead"}) || !reflect.DeepEqual(group.GetMemberGroups(), []string{"backend"}) {
+		t.Errorf("expected direct member lead and nested group backend but got %v and %v", group.GetMembers(), group.GetMemberGroups())
+	}
+	if group, err = test.Manager.GetGroup(&pb.GetGroupRequest{Name: "engineering", Effective: true}); err != nil {
+		t.Fatalf("failed to get group: %v", err)
+	}
+	if !reflect.DeepEqual(group.GetMembers(), []string{"dev", "lead"}) {
+		t.Errorf("expected effective members dev and lead but got %v", group.GetMembers())
+	}
+
+	memberStatus, err := test.Manager.IsGroupMember(&pb.IsGroupMemberRequest{Group: "engineering", Username: "dev"})
+	if err != nil {
+		t.Fatalf("failed to check membership: %v", err)
+	}
+	if memberStatus.GetIsMember() {
+		t.Errorf("expected dev to not be a direct member of engineering")
+	}
+	if memberStatus, err = test.Manager.IsGroupMember(&pb.IsGroupMemberRequest{Group: "engineering", Username: "dev", Effective: true}); err != nil {
+		t.Fatalf("failed to check membership: %v", err)
+	}
+	if !memberStatus.GetIsMember() {
+		t.Errorf("expected dev to be an effective member of engineering")
+	}
+	groups, err := test.Manager.GetUserGroups(&pb.GetUserGroupsRequest{Username: "dev", Effective: true})
+	if err != nil {
+		t.Fatalf("failed to get groups: %v", err)
+	}
+	if !reflect.DeepEqual(groups.GetGroups(), []string{"backend", "engineering", test.Manager.DefaultUserGroup}) {
+		t.Errorf("expected dev to be in backend, engineering and users but got %v", groups.GetGroups())
+	}
+
+	// members of groups nested in the admin group are admins
+	if err := test.Manager.AddGroupMember(&pb.GroupMember{Group: test.Manager.DefaultAdminGroup, MemberGroup: "backend"}, false); err != nil {
+		t.Fatalf("failed to add nested admin group: %v", err)
+	}
+	roles, err := test.Manager.GetRoles("dev")
+	if err != nil {
+		t.Fatalf("failed to get roles: %v", err)
+	}
+	if !reflect.DeepEqual(roles, []string{RoleAdmin}) {
+		t.Errorf("expected dev to be admin through the nested group but got %v", roles)
+	}
+	if protected, err := test.Manager.IsNestedInProtectedGroup("backend"); err != nil || !protected {
+		t.Errorf("expected backend to be protected when nested in the admin group (err=%v)", err)
+	}
+
+	// renaming a nested group keeps it nested
+	if err := test.Manager.UpdateGroup(&pb.UpdateGroupRequest{Name: "backend", NewName: "platform"}); err != nil {
+		t.Fatalf("failed to rename group: %v", err)
+	}
+	if group, err = test.Manager.GetGroup(&pb.GetGroupRequest{Name: "engineering"}); err != nil {
+		t.Fatalf("failed to get group: %v", err)
+	}
+	if !reflect.DeepEqual(group.GetMemberGroups(), []string{"platform"}) {
+		t.Errorf("expected nested group platform after the rename but got %v", group.GetMemberGroups())
+	}
+}
@@ -0,0 +1,335 @@
+package ldapmanager
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	ber "gopkg.in/asn1-ber.v1"
+)
+
+const (
+	// ControlTypeServerSideSort is the OID of the server side sort request control (RFC 2891)
+	ControlTypeServerSideSort = "1.2.840.113556.1.4.473"
+
+	// MaxPageSize limits the number of entries returned for a single page
+	MaxPageSize = 1000
+)
+
+// ControlServerSideSort implements the sort request control described in https://tools.ietf.org/html/rfc2891
+type ControlServerSideSort struct {
+	Criticality  bool
+	AttributeKey string
+	ReverseOrder bool
+}
+
+// GetControlType returns the OID
+func (c *ControlServerSideSort) GetControlType() string {
+	return ControlTypeServerSideSort
+}
+
+// Encode returns the ber packet representation
+func (c *ControlServerSideSort) Encode() *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Control")
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, ControlTypeServerSideSort, "Control Type (Server Side Sort)"))
+	if c.Criticality {
+		packet.AppendChild(ber.NewBoolean(ber.ClassUniversal, ber.TypePrimitive, ber.TagBoolean, c.Criticality, "Criticality"))
+	}
+
+	value := ber.Encode(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, nil, "Control Value (Server Side Sort)")
+	keys := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Sort Key List")
+	key := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Sort Key")
+	key.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, c.AttributeKey, "Attribute Type"))
+	if c.ReverseOrder {
+		key.AppendChild(ber.NewBoolean(ber.ClassContext, ber.TypePrimitive, 1, c.ReverseOrder, "Reverse Order"))
+	}
+	keys.AppendChild(key)
+	value.AppendChild(keys)
+
+	packet.AppendChild(value)
+	return packet
+}
+
+// String returns a human-readable description
+func (c *ControlServerSideSort) String() string {
+	return fmt.Sprintf(
+		"Control Type: Server Side Sort (%q)  Criticality: %t  AttributeKey: %s  ReverseOrder: %t",
+		ControlTypeServerSideSort,
+		c.Criticality,
+		c.AttributeKey,
+		c.ReverseOrder)
+}
+
+// pageToken is the opaque cursor handed out to clients in the next_page_token field
+type pageToken struct {
+	Offset  int    `json:"o"`
+	Total   int    `json:"t"`
+	SortKey string `json:"k"`
+	Order   int32  `json:"s"`
+	Filter  string `json:"f"`
+}
+
+func (t *pageToken) encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(token string) (*pageToken, error) {
+	var t pageToken
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, &ValidationError{Message: "invalid page token"}
+	}
+	if err := json.Unmarshal(data, &t); err != nil || t.Offset < 0 {
+		return nil, &ValidationError{Message: "invalid page token"}
+	}
+	return &t, nil
+}
+
+// pageRequest describes the requested page of a list request
+type pageRequest struct {
+	start, end int32
+	size       int32
+	token      string
+	sortKey    string
+	order      pb.SortOrder
+	filter     string
+	// match optionally restricts the entries to those that can not be selected by the filter alone
+	match func(*ldap.Entry) bool
+}
+
+func (r *pageRequest) usesToken() bool {
+	return r.size > 0 || r.token != ""
+}
+
+// cursor returns the current offset and known total (or -1) for a token based request
+func (r *pageRequest) cursor() (offset int, total int, err error) {
+	if r.token == "" {
+		return 0, -1, nil
+	}
+	t, err := decodePageToken(r.token)
+	if err != nil {
+		return 0, 0, err
+	}
+	if t.SortKey != r.sortKey || t.Order != int32(r.order) || t.Filter != r.filter {
+		return 0, 0, &ValidationError{Message: "page token does not match the request"}
+	}
+	return t.Offset, t.Total, nil
+}
+
+func (r *pageRequest) pageSize() int {
+	size := int(r.size)
+	if size < 1 || size > MaxPageSize {
+		size = MaxPageSize
+	}
+	return size
+}
+
+func (r *pageRequest) nextToken(offset, total int) string {
+	if offset >= total {
+		return ""
+	}
+	return (&pageToken{Offset: offset, Total: total, SortKey: r.sortKey, Order: int32(r.order), Filter: r.filter}).encode()
+}
+
+func (p *ConnPool) loadSupportedControls() {
+	p.supportedControls = make(map[string]bool)
+	result, err := p.Search(ldap.NewSearchRequest(
+		"",
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)",
+		[]string{"supportedControl"},
+		[]ldap.Control{},
+	))
+	if err != nil || len(result.Entries) != 1 {
+		log.Warnf("failed to query supported controls of the OpenLDAP server: %v", err)
+		return
+	}
+	for _, oid := range result.Entries[0].GetAttributeValues("supportedControl") {
+		p.supportedControls[oid] = true
+	}
+	log.Debugf("OpenLDAP server supports paging=%t sorting=%t",
+		p.supportedControls[ldap.ControlTypePaging], p.supportedControls[ControlTypeServerSideSort])
+}
+
+// SupportsControl checks if the OpenLDAP server announces support for the control with the given OID
+func (p *ConnPool) SupportsControl(oid string) bool {
+	p.controlsOnce.Do(p.loadSupportedControls)
+	return p.supportedControls[oid]
+}
+
+// SupportsControl checks if the OpenLDAP server announces support for the control with the given OID
+func (m *LDAPManager) SupportsControl(oid string) bool {
+	return m.ldap.SupportsControl(oid)
+}
+
+func (m *LDAPManager) canPageOnServer() bool {
+	return m.SupportsControl(ldap.ControlTypePaging) && m.SupportsControl(ControlTypeServerSideSort)
+}
+
+func isSortUnsupportedError(err error) bool {
+	return ldap.IsErrorWithCode(err, ldap.LDAPResultUnavailableCriticalExtension) ||
+		ldap.IsErrorWithCode(err, ldap.LDAPResultInappropriateMatching) ||
+		ldap.IsErrorWithCode(err, ldap.LDAPResultSortControlMissing)
+}
+
+// searchPage uses the paged results and server side sort controls to fetch
+// the entries in [offset, offset+limit) without loading the full result into memory
+func (m *LDAPManager) searchPage(searchRequest *ldap.SearchRequest, sortKey string, descending bool, offset, limit int) ([]*ldap.Entry, error) {
+	var entries []*ldap.Entry
+	pagingSize := limit
+	if offset > 0 {
+		// skip over the preceding entries with fewer round trips
+		if pagingSize = offset + limit; pagingSize > MaxPageSize {
+			pagingSize = MaxPageSize
+		}
+	}
+	err := m.ldap.do(func(pc *pooledConn) error {
+		entries = nil
+		paging := ldap.NewControlPaging(uint32(pagingSize))
+		searchRequest.Controls = []ldap.Control{
+			&ControlServerSideSort{Criticality: true, AttributeKey: sortKey, ReverseOrder: descending},
+			paging,
+		}
+		seen := 0
+		for {
+			result, err := pc.conn.Search(searchRequest)
+			if err != nil {
+				return err
+			}
+			for _, entry := range result.Entries {
+				if seen >= offset && len(entries) < limit {
+					entries = append(entries, entry)
+				}
+				seen++
+			}
+			var cookie []byte
+			if ctrl, ok := ldap.FindControl(result.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging); ok {
+				cookie = ctrl.Cookie
+			}
+			if len(cookie) == 0 {
+				return nil
+			}
+			paging.SetCookie(cookie)
+			if len(entries) >= limit {
+				// we have what we need, abandon the paged search
+				paging.PagingSize = 0
+				_, _ = pc.conn.Search(searchRequest)
+				return nil
+			}
+		}
+	})
+	return entries, err
+}
+
+// count returns the number of entries matching the filter without fetching any attributes
+func (m *LDAPManager) count(baseDN, filter string) (int, error) {
+	searchRequest := ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		[]string{"1.1"},
+		[]ldap.Control{},
+	)
+	var result *ldap.SearchResult
+	var err error
+	if m.SupportsControl(ldap.ControlTypePaging) {
+		result, err = m.ldap.SearchWithPaging(searchRequest, MaxPageSize)
+	} else {
+		result, err = m.ldap.Search(searchRequest)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(result.Entries), nil
+}
+
+// search fetches all entries, using the paged results control if available to avoid hitting the size limit
+func (m *LDAPManager) search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	if m.SupportsControl(ldap.ControlTypePaging) {
+		return m.ldap.SearchWithPaging(searchRequest, MaxPageSize)
+	}
+	return m.ldap.Search(searchRequest)
+}
+
+// listPage returns the requested page of entries matching the filter, sorted by the sort key, and the total
+// number of matching entries. Server side paging and sorting is used when supported by the server,
+// otherwise all entries are fetched and sorted in memory.
+func (m *LDAPManager) listPage(baseDN, filter string, attributes []string, req *pageRequest) ([]*ldap.Entry, int, string, error) {
+	offset, total, err := req.cursor()
+	if err != nil {
+		return nil, 0, "", err
+	}
+	descending := req.order == pb.SortOrder_DESCENDING
+	// entries without a sort key are skipped by both the server side and the in memory sorting
+	filter = fmt.Sprintf("(&%s(%s=*))", filter, req.sortKey)
+	newSearchRequest := func(attributes []string) *ldap.SearchRequest {
+		return ldap.NewSearchRequest(
+			baseDN,
+			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+			filter,
+			attributes,
+			[]ldap.Control{},
+		)
+	}
+
+	if req.usesToken() && req.match == nil && m.canPageOnServer() {
+		entries, err := m.searchPage(newSearchRequest(attributes), req.sortKey, descending, offset, req.pageSize())
+		if err == nil {
+			if total < 0 {
+				if total, err = m.count(baseDN, filter); err != nil {
+					return nil, 0, "", err
+				}
+			}
+			return entries, total, req.nextToken(offset+len(entries), total), nil
+		}
+		if !isSortUnsupportedError(err) {
+			return nil, 0, "", err
+		}
+		log.Debugf("server side sorting by %q failed, falling back to sorting in memory: %v", req.sortKey, err)
+	}
+
+	// Fall back to fetching and sorting all entries in memory
+	result, err := m.search(newSearchRequest(attributes))
+	if err != nil {
+		return nil, 0, "", err
+	}
+	entries := make([]*ldap.Entry, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		if req.match == nil || req.match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a := strings.ToLower(entries[i].GetAttributeValue(req.sortKey))
+		b := strings.ToLower(entries[j].GetAttributeValue(req.sortKey))
+		if descending {
+			return a > b
+		}
+		return a < b
+	})
+
+	// the total is counted from the same search, including the entries that only match the match function
+	total = len(entries)
+	if req.usesToken() {
+		if offset > total {
+			offset = total
+		}
+		end := offset + req.pageSize()
+		if end > total {
+			end = total
+		}
+		return entries[offset:end], total, req.nextToken(end, total), nil
+	}
+
+	// Clip
+	if req.start >= 0 && req.end < int32(len(entries)) && req.start < req.end {
+		entries = entries[req.start:req.end]
+	}
+	return entries, total, "", nil
+}
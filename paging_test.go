@@ -0,0 +1,128 @@
+package ldapmanager
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestGetUserListSortKey ...
+func TestGetUserListSortKey(t *testing.T) {
+	manager := &LDAPManager{AccountAttribute: "uid"}
+	for _, sortKey := range []string{"userPassword", "uid)(objectClass=*", "unknown"} {
+		_, err := manager.GetUserList(&pb.GetUserListRequest{SortKey: sortKey})
+		if validationErr, ok := err.(*ValidationError); !ok || validationErr.Field != "sort_key" {
+			t.Errorf("expected sort key %q to be rejected but got %v", sortKey, err)
+		}
+	}
+}
+
+// TestPageToken ...
+func TestPageToken(t *testing.T) {
+	req := &pageRequest{size: 10, sortKey: "uid", order: pb.SortOrder_DESCENDING, filter: "(uid=*a*)"}
+	token := req.nextToken(20, 42)
+	if token == "" {
+		t.Fatal("expected a page token when there are more entries")
+	}
+	req.token = token
+	offset, total, err := req.cursor()
+	if err != nil {
+		t.Fatalf("failed to decode page token %q: %v", token, err)
+	}
+	if offset != 20 || total != 42 {
+		t.Errorf("expected offset=20 and total=42 but got offset=%d and total=%d", offset, total)
+	}
+	if next := req.nextToken(42, 42); next != "" {
+		t.Errorf("expected no page token for the last page but got %q", next)
+	}
+
+	// the token must not be used with a different sort order
+	req.order = pb.SortOrder_ASCENDING
+	if _, _, err := req.cursor(); err == nil {
+		t.Error("expected error when using a page token with a different sort order")
+	}
+	req.token = "not-a-token"
+	if _, _, err := req.cursor(); err == nil {
+		t.Error("expected error when using an invalid page token")
+	}
+}
+
+// TestServerSideSortControl ...
+func TestServerSideSortControl(t *testing.T) {
+	control := &ControlServerSideSort{AttributeKey: "uid", ReverseOrder: true}
+	packet := control.Encode()
+	if len(packet.Children) != 2 {
+		t.Fatalf("expected control type and value but got %d children", len(packet.Children))
+	}
+	if oid := packet.Children[0].Value.(string); oid != ControlTypeServerSideSort {
+		t.Errorf("expected control type %q but got %q", ControlTypeServerSideSort, oid)
+	}
+	sortKey := packet.Children[1].Children[0].Children[0]
+	if attr := sortKey.Children[0].Value.(string); attr != "uid" {
+		t.Errorf("expected sort key attribute %q but got %q", "uid", attr)
+	}
+	if len(sortKey.Children) != 2 {
+		t.Errorf("expected the reverse order flag to be encoded")
+	}
+	if _, ok := interface{}(control).(ldap.Control); !ok {
+		t.Errorf("expected ControlServerSideSort to implement ldap.Control")
+	}
+}
+
+// TestGetUserListPaging ...
+func TestGetUserListPaging(t *testing.T) {
+	if skipAccountTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	var expected []string
+	for i := 0; i < 12; i++ {
+		username := fmt.Sprintf("user%02d", i)
+		expected = append(expected, username)
+		if err := test.Manager.NewAccount(&pb.NewAccountRequest{
+			Account: &pb.Account{
+				Username:  username,
+				Password:  "Hallo Welt",
+				Email:     "a@b.de",
+				FirstName: "roman",
+				LastName:  "d",
+			},
+		}, pb.HashingAlgorithm_DEFAULT); err != nil {
+			t.Fatalf("failed to add user %q: %v", username, err)
+		}
+	}
+
+	var seen []string
+	req := &pb.GetUserListRequest{PageSize: 5, Filter: []string{"uid=user"}}
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatalf("expected 3 pages but got more")
+		}
+		page, err := test.Manager.GetUserList(req)
+		if err != nil {
+			t.Fatalf("failed to get page %d: %v", pages, err)
+		}
+		if page.GetTotal() != int64(len(expected)) {
+			t.Errorf("expected total of %d but got %d", len(expected), page.GetTotal())
+		}
+		for _, user := range page.GetUsers() {
+			seen = append(seen, user.GetData()[test.Manager.AccountAttribute])
+		}
+		if page.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = page.GetNextPageToken()
+	}
+	if len(seen) != len(expected) {
+		t.Fatalf("expected %d users but got %d: %v", len(expected), len(seen), seen)
+	}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Errorf("expected user %q at position %d but got %q", expected[i], i, seen[i])
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package ldapmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	ldaphash "github.com/romnn/ldap-manager/hash"
+	log "github.com/sirupsen/logrus"
+)
+
+// Password history entries are kept in a dedicated subtree with one device entry per account.
+// Each description value holds the time a password was set and its hash, e.g. "00001602759478000000000 {SSHA}...".
+
+func (m *LDAPManager) passwordHistoryEnabled() bool {
+	return m.PasswordHistorySize > 0 && m.PasswordHistoryDN != ""
+}
+
+func (m *LDAPManager) passwordHistoryNamed(username string) string {
+	return fmt.Sprintf("cn=%s,%s", escapeDN(username), m.PasswordHistoryDN)
+}
+
+func (m *LDAPManager) setupPasswordHistoryOU() error {
+	ou := strings.TrimPrefix(strings.SplitN(m.PasswordHistoryDN, ",", 2)[0], "ou=")
+	return m.setupOU(m.PasswordHistoryDN, ou)
+}
+
+type passwordHistoryValue struct {
+	raw  string
+	hash string
+}
+
+// getPasswordHistory returns the recorded password hashes of the account, most recent first
+func (m *LDAPManager) getPasswordHistory(username string) ([]passwordHistoryValue, bool, error) {
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.passwordHistoryNamed(username),
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=device)",
+		[]string{"description"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get password history of %q: %v", username, err)
+	}
+	if len(result.Entries) != 1 {
+		return nil, false, nil
+	}
+	var history []passwordHistoryValue
+	for _, raw := range result.Entries[0].GetAttributeValues("description") {
+		parts := strings.SplitN(raw, " ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		history = append(history, passwordHistoryValue{raw: raw, hash: parts[1]})
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].raw > history[j].raw
+	})
+	return history, true, nil
+}
+
+// checkPasswordHistory rejects passwords that match the current or any of the recorded passwords of the account
+func (m *LDAPManager) checkPasswordHistory(username, currentHash, password string) error {
+	if !m.passwordHistoryEnabled() {
+		return nil
+	}
+	history, _, err := m.getPasswordHistory(username)
+	if err != nil {
+		return err
+	}
+	hashes := []string{currentHash}
+	for i := 0; i < len(history) && i < m.PasswordHistorySize; i++ {
+		hashes = append(hashes, history[i].hash)
+	}
+	for _, hashed := range hashes {
+		if hashed == "" {
+			continue
+		}
+		matches, err := ldaphash.VerifyPassword(password, hashed)
+		if err != nil {
+			log.Warnf("failed to verify password history of %q: %v", username, err)
+			continue
+		}
+		if matches {
+			return &ValidationError{
+				Message:    "password does not meet the password policy",
+				Field:      "password",
+				Violations: []string{fmt.Sprintf("password must not match any of the last %d passwords", m.PasswordHistorySize)},
+			}
+		}
+	}
+	return nil
+}
+
+// recordPasswordHistory adds the hash of a new password to the history of the account and drops the oldest ones
+func (m *LDAPManager) recordPasswordHistory(username, hashedPassword string) error {
+	if !m.passwordHistoryEnabled() {
+		return nil
+	}
+	history, exists, err := m.getPasswordHistory(username)
+	if err != nil {
+		return err
+	}
+	value := fmt.Sprintf("%023d %s", time.Now().UnixNano(), hashedPassword)
+	if !exists {
+		addRequest := &ldap.AddRequest{
+			DN: m.passwordHistoryNamed(username),
+			Attributes: []ldap.Attribute{
+				{Type: "objectClass", Vals: []string{"device", "top"}},
+				{Type: "cn", Vals: []string{username}},
+				{Type: "description", Vals: []string{value}},
+			},
+			Controls: []ldap.Control{},
+		}
+		if err := m.add(addRequest); err != nil {
+			return fmt.Errorf("failed to add password history of %q: %v", username, err)
+		}
+		return nil
+	}
+	modifyRequest := ldap.NewModifyRequest(m.passwordHistoryNamed(username), []ldap.Control{})
+	modifyRequest.Add("description", []string{value})
+	var expired []string
+	for i := m.PasswordHistorySize - 1; i < len(history); i++ {
+		expired = append(expired, history[i].raw)
+	}
+	if len(expired) > 0 {
+		modifyRequest.Delete("description", expired)
+	}
+	if err := m.modify(modifyRequest); err != nil {
+		return fmt.Errorf("failed to update password history of %q: %v", username, err)
+	}
+	return nil
+}
+
+// renamePasswordHistory moves the history of a renamed account
+func (m *LDAPManager) renamePasswordHistory(username, newUsername string) error {
+	if !m.passwordHistoryEnabled() {
+		return nil
+	}
+	if err := m.modifyDN(&ldap.ModifyDNRequest{
+		DN:           m.passwordHistoryNamed(username),
+		NewRDN:       fmt.Sprintf("cn=%s", escapeDN(newUsername)),
+		DeleteOldRDN: true,
+	}); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return fmt.Errorf("failed to rename password history of %q: %v", username, err)
+	}
+	return nil
+}
+
+// deletePasswordHistory removes the history of a deleted account
+func (m *LDAPManager) deletePasswordHistory(username string) error {
+	if !m.passwordHistoryEnabled() {
+		return nil
+	}
+	if err := m.del(ldap.NewDelRequest(m.passwordHistoryNamed(username), []ldap.Control{})); err != nil &&
+		!ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return fmt.Errorf("failed to delete password history of %q: %v", username, err)
+	}
+	return nil
+}
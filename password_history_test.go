@@ -0,0 +1,78 @@
+package ldapmanager
+
+import (
+	"testing"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestPasswordHistory ...
+func TestPasswordHistory(t *testing.T) {
+	if skipPasswordHistoryTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	test.Manager.PasswordHistorySize = 2
+	if err := test.Manager.setupPasswordHistoryOU(); err != nil {
+		t.Fatalf("failed to setup password history: %v", err)
+	}
+	if err := test.Manager.NewAccount(&pb.NewAccountRequest{
+		Account: &pb.Account{
+			Username:  "romnn",
+			Password:  "first",
+			Email:     "a@b.de",
+			FirstName: "roman",
+			LastName:  "d",
+		},
+	}, pb.HashingAlgorithm_DEFAULT); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+
+	changePassword := func(password string, algorithm pb.HashingAlgorithm) error {
+		return test.Manager.ChangePassword(&pb.ChangePasswordRequest{
+			Username:         "romnn",
+			Password:         password,
+			HashingAlgorithm: algorithm,
+		}, false)
+	}
+	if err := changePassword("first", pb.HashingAlgorithm_DEFAULT); err == nil {
+		t.Errorf("expected reusing the current password to fail")
+	}
+	if err := changePassword("second", pb.HashingAlgorithm_SHA512CRYPT); err != nil {
+		t.Fatalf("failed to change password: %v", err)
+	}
+	if err := changePassword("first", pb.HashingAlgorithm_MD5); err == nil {
+		t.Errorf("expected reusing the previous password to fail")
+	}
+	if err := changePassword("third", pb.HashingAlgorithm_SMD5); err != nil {
+		t.Fatalf("failed to change password: %v", err)
+	}
+	isAdmin := true
+	if _, _, err := test.Manager.UpdateAccount(&pb.UpdateAccountRequest{
+		Username: "romnn",
+		Update:   &pb.Account{Password: "second"},
+	}, pb.HashingAlgorithm_DEFAULT, isAdmin); err == nil {
+		t.Errorf("expected updating to a previous password to fail")
+	}
+	// only the last two passwords are kept
+	if err := changePassword("first", pb.HashingAlgorithm_DEFAULT); err != nil {
+		t.Errorf("expected reusing the oldest password to succeed but got: %v", err)
+	}
+	history, _, err := test.Manager.getPasswordHistory("romnn")
+	if err != nil {
+		t.Fatalf("failed to get password history: %v", err)
+	}
+	if len(history) != 2 {
+		t.Errorf("expected 2 recorded passwords but got %d", len(history))
+	}
+
+	keepGroups := false
+	if err := test.Manager.DeleteAccount(&pb.DeleteAccountRequest{Username: "romnn"}, keepGroups); err != nil {
+		t.Fatalf("failed to delete account: %v", err)
+	}
+	if _, exists, _ := test.Manager.getPasswordHistory("romnn"); exists {
+		t.Errorf("expected the password history to be deleted with the account")
+	}
+}
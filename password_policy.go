@@ -0,0 +1,139 @@
+package ldapmanager
+
+import (
+	"bufio"
+	"crypto/sha1"
+	encodinghex "encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// minPersonalInfoLength is the minimum length of a username or name part to be rejected as a password substring
+const minPersonalInfoLength = 3
+
+// PasswordPolicy is enforced whenever a password is set. The zero value accepts any non empty password.
+type PasswordPolicy struct {
+	MinLength        int
+	RequireLowercase bool
+	RequireUppercase bool
+	RequireDigit     bool
+	RequireSpecial   bool
+	// RejectPersonalInfo rejects passwords containing the username, first or last name
+	RejectPersonalInfo bool
+	// Breached contains the SHA-1 hashes of known breached passwords
+	Breached map[[sha1.Size]byte]bool
+}
+
+// LoadBreachedPasswords reads a list of breached passwords with one password per line.
+// Lines may also contain hex encoded SHA-1 hashes (in upper or lower case) optionally followed by a count (e.g. HASH:42).
+func LoadBreachedPasswords(path string) (map[[sha1.Size]byte]bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password list: %v", err)
+	}
+	defer file.Close()
+
+	breached := make(map[[sha1.Size]byte]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if hash, ok := parseSHA1Hex(line); ok {
+			breached[hash] = true
+			continue
+		}
+		breached[sha1.Sum([]byte(line))] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breached password list: %v", err)
+	}
+	return breached, nil
+}
+
+func parseSHA1Hex(line string) ([sha1.Size]byte, bool) {
+	var hash [sha1.Size]byte
+	if i := strings.Index(line, ":"); i == 2*sha1.Size {
+		line = line[:i]
+	}
+	if len(line) != 2*sha1.Size {
+		return hash, false
+	}
+	decoded, err := encodinghex.DecodeString(strings.ToLower(line))
+	if err != nil {
+		return hash, false
+	}
+	copy(hash[:], decoded)
+	return hash, true
+}
+
+// Violations returns all rules of the policy the password violates
+func (p *PasswordPolicy) Violations(password string, account *pb.Account) []string {
+	var violations []string
+	if password == "" {
+		return append(violations, "password must not be empty")
+	}
+	if p.MinLength > 0 && utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, fmt.Sprintf("password must be at least %d characters long", p.MinLength))
+	}
+	var lower, upper, digit, special bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			special = true
+		}
+	}
+	if p.RequireLowercase && !lower {
+		violations = append(violations, "password must contain a lowercase letter")
+	}
+	if p.RequireUppercase && !upper {
+		violations = append(violations, "password must contain an uppercase letter")
+	}
+	if p.RequireDigit && !digit {
+		violations = append(violations, "password must contain a digit")
+	}
+	if p.RequireSpecial && !special {
+		violations = append(violations, "password must contain a special character")
+	}
+	if p.RejectPersonalInfo {
+		lowerPassword := strings.ToLower(password)
+		for _, info := range []struct{ name, value string }{
+			{"username", account.GetUsername()},
+			{"first name", account.GetFirstName()},
+			{"last name", account.GetLastName()},
+		} {
+			value := strings.ToLower(strings.TrimSpace(info.value))
+			if utf8.RuneCountInString(value) >= minPersonalInfoLength && strings.Contains(lowerPassword, value) {
+				violations = append(violations, fmt.Sprintf("password must not contain the %s", info.name))
+			}
+		}
+	}
+	if len(p.Breached) > 0 && p.Breached[sha1.Sum([]byte(password))] {
+		violations = append(violations, "password is known to be breached")
+	}
+	return violations
+}
+
+// validPassword checks the password of the account against the password policy
+func (m *LDAPManager) validPassword(password string, account *pb.Account) error {
+	if violations := m.PasswordPolicy.Violations(password, account); len(violations) > 0 {
+		return &ValidationError{
+			Message:    "password does not meet the password policy",
+			Field:      "password",
+			Violations: violations,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package ldapmanager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestPasswordPolicyViolations ...
+func TestPasswordPolicyViolations(t *testing.T) {
+	account := &pb.Account{Username: "romnn", FirstName: "Roman", LastName: "D"}
+	strict := &PasswordPolicy{
+		MinLength:          10,
+		RequireLowercase:   true,
+		RequireUppercase:   true,
+		RequireDigit:       true,
+		RequireSpecial:     true,
+		RejectPersonalInfo: true,
+	}
+	cases := []struct {
+		policy   *PasswordPolicy
+		password string
+		expected []string
+	}{
+		{&PasswordPolicy{}, "a", nil},
+		{&PasswordPolicy{}, "", []string{"password must not be empty"}},
+		{strict, "Correct-Horse-42", nil},
+		// a last name shorter than three characters is not rejected
+		{strict, "Dd-Horse-42", nil},
+		{strict, "roman", []string{
+			"password must be at least 10 characters long",
+			"password must contain an uppercase letter",
+			"password must contain a digit",
+			"password must contain a special character",
+			"password must not contain the first name",
+		}},
+		{strict, "My-ROMNN-Password-1", []string{"password must not contain the username"}},
+		{&PasswordPolicy{MinLength: 4}, "äöü", []string{"password must be at least 4 characters long"}},
+	}
+	for _, c := range cases {
+		if diff := cmp.Diff(c.expected, c.policy.Violations(c.password, account)); diff != "" {
+			t.Errorf("unexpected violations for %q: %s", c.password, diff)
+		}
+	}
+}
+
+// TestLoadBreachedPasswords ...
+func TestLoadBreachedPasswords(t *testing.T) {
+	dir, err := ioutil.TempDir("", "breached")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "breached.txt")
+	// the second line is the SHA-1 hash of "password" with a count, the third the lower case SHA-1 hash of "qwerty"
+	list := "123456\n5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3730471\nb1b3773a05c0ed0176787a4f1574ff0075f7521e\n"
+	if err := ioutil.WriteFile(path, []byte(list), 0600); err != nil {
+		t.Fatal(err)
+	}
+	breached, err := LoadBreachedPasswords(path)
+	if err != nil {
+		t.Fatalf("failed to load breached passwords: %v", err)
+	}
+	policy := &PasswordPolicy{Breached: breached}
+	for _, password := range []string{"123456", "password", "qwerty"} {
+		if len(policy.Violations(password, nil)) != 1 {
+			t.Errorf("expected %q to be rejected as breached", password)
+		}
+	}
+	if violations := policy.Violations("not breached", nil); len(violations) != 0 {
+		t.Errorf("expected no violations but got %v", violations)
+	}
+}
+
+// TestPasswordPolicyEnforcement ...
+func TestPasswordPolicyEnforcement(t *testing.T) {
+	if skipPasswordPolicyTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	test.Manager.PasswordPolicy = PasswordPolicy{MinLength: 8, RequireDigit: true, RejectPersonalInfo: true}
+	account := &pb.Account{
+		Username:  "romnn",
+		Password:  "romnn",
+		Email:     "a@b.de",
+		FirstName: "roman",
+		LastName:  "d",
+	}
+	err := test.Manager.NewAccount(&pb.NewAccountRequest{Account: account}, pb.HashingAlgorithm_DEFAULT)
+	validationErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("expected a validation error but got %v", err)
+	}
+	if len(validationErr.Violations) != 3 {
+		t.Errorf("expected 3 violations but got %v", validationErr.Violations)
+	}
+
+	account.Password = "Hallo Welt 1"
+	if err := test.Manager.NewAccount(&pb.NewAccountRequest{Account: account}, pb.HashingAlgorithm_DEFAULT); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+	if err := test.Manager.ChangePassword(&pb.ChangePasswordRequest{Username: "romnn", Password: "short"}, false); err == nil {
+		t.Errorf("expected changing the password to a weak password to fail")
+	}
+	isAdmin := true
+	if _, _, err := test.Manager.UpdateAccount(&pb.UpdateAccountRequest{
+		Username: "romnn",
+		Update:   &pb.Account{Password: "i am roman 2"},
+	}, pb.HashingAlgorithm_DEFAULT, isAdmin); err == nil {
+		t.Errorf("expected updating the password to contain the first name to fail")
+	}
+	if err := test.Manager.ChangePassword(&pb.ChangePasswordRequest{Username: "romnn", Password: "Hallo Welt 2"}, false); err != nil {
+		t.Errorf("failed to change password: %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+package ldapmanager
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	encodinghex "encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// Password reset tokens are kept in a dedicated subtree with one device entry per token.
+// Only the SHA-256 hash of a token is stored, the entry's owner is the account and its description the expiration time.
+
+const (
+	// DefaultPasswordResetTTL is the default validity of a password reset token
+	DefaultPasswordResetTTL = time.Hour
+	// DefaultPasswordResetRateLimit is the default number of password resets that can be requested per account and window
+	DefaultPasswordResetRateLimit = 3
+	// DefaultPasswordResetRateWindow ...
+	DefaultPasswordResetRateWindow = time.Hour
+
+	passwordResetTokenSize = 32
+)
+
+// PasswordResetDisabledError is returned when no mail sender is configured
+type PasswordResetDisabledError struct {
+	ApplicationError
+}
+
+// Error ...
+func (e *PasswordResetDisabledError) Error() string {
+	return "password reset is not enabled"
+}
+
+// Code ...
+func (e *PasswordResetDisabledError) Code() codes.Code {
+	return codes.FailedPrecondition
+}
+
+// rateLimiter allows a limited number of events per key within a sliding window
+type rateLimiter struct {
+	mux    sync.Mutex
+	limit  int
+	window time.Duration
+	events map[string][]time.Time
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{limit: limit, window: window, events: make(map[string][]time.Time)}
+}
+
+// Allow records an event for the key if the limit is not yet reached
+func (l *rateLimiter) Allow(key string) bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	now := time.Now()
+	var recent []time.Time
+	for _, t := range l.events[key] {
+		if now.Sub(t) < l.window {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= l.limit {
+		l.events[key] = recent
+		return false
+	}
+	l.events[key] = append(recent, now)
+	return true
+}
+
+func newPasswordResetLimiter(limit int, window time.Duration) *rateLimiter {
+	if limit < 1 {
+		limit = DefaultPasswordResetRateLimit
+	}
+	if window <= 0 {
+		window = DefaultPasswordResetRateWindow
+	}
+	return newRateLimiter(limit, window)
+}
+
+func (m *LDAPManager) passwordResetEnabled() bool {
+	return m.MailSender != nil && m.PasswordResetDN != ""
+}
+
+func (m *LDAPManager) setupPasswordResetOU() error {
+	ou := strings.TrimPrefix(strings.SplitN(m.PasswordResetDN, ",", 2)[0], "ou=")
+	return m.setupOU(m.PasswordResetDN, ou)
+}
+
+func (m *LDAPManager) passwordResetTokenNamed(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return fmt.Sprintf("cn=%s,%s", encodinghex.EncodeToString(hash[:]), m.PasswordResetDN)
+}
+
+func (m *LDAPManager) passwordResetTTL() time.Duration {
+	if m.PasswordResetTTL > 0 {
+		return m.PasswordResetTTL
+	}
+	return DefaultPasswordResetTTL
+}
+
+func (m *LDAPManager) addPasswordResetToken(token, userDN string, expires time.Time) error {
+	tokenDN := m.passwordResetTokenNamed(token)
+	return m.add(&ldap.AddRequest{
+		DN: tokenDN,
+		Attributes: []ldap.Attribute{
+			{Type: "objectClass", Vals: []string{"device", "top"}},
+			{Type: "cn", Vals: []string{strings.TrimPrefix(strings.SplitN(tokenDN, ",", 2)[0], "cn=")}},
+			{Type: "owner", Vals: []string{userDN}},
+			{Type: "description", Vals: []string{strconv.FormatInt(expires.Unix(), 10)}},
+		},
+		Controls: []ldap.Control{},
+	})
+}
+
+// deletePasswordResetTokens removes the tokens of an account, optionally only the expired ones
+func (m *LDAPManager) deletePasswordResetTokens(userDN string, onlyExpired bool) error {
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.PasswordResetDN,
+		ldap.ScopeSingleLevel, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&(objectClass=device)(owner=%s))", escapeFilter(userDN)),
+		[]string{"description"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return err
+	}
+	for _, entry := range result.Entries {
+		if onlyExpired && !passwordResetTokenExpired(entry) {
+			continue
+		}
+		if err := m.del(ldap.NewDelRequest(entry.DN, []ldap.Control{})); err != nil &&
+			!ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return err
+		}
+	}
+	return nil
+}
+
+func passwordResetTokenExpires(entry *ldap.Entry) time.Time {
+	expires, err := strconv.ParseInt(entry.GetAttributeValue("description"), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(expires, 0)
+}
+
+func passwordResetTokenExpired(entry *ldap.Entry) bool {
+	return !time.Now().Before(passwordResetTokenExpires(entry))
+}
+
+func (m *LDAPManager) passwordResetMail(to, username, token string) *Mail {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hello %s,\n\n", username)
+	body.WriteString("a password reset was requested for your account. ")
+	if m.PasswordResetURL != "" {
+		fmt.Fprintf(&body, "Open the following link to choose a new password:\n\n%s\n\n",
+			strings.ReplaceAll(m.PasswordResetURL, "{token}", token))
+	} else {
+		fmt.Fprintf(&body, "Use the following token to choose a new password:\n\n%s\n\n", token)
+	}
+	fmt.Fprintf(&body, "The token expires in %s and can only be used once. ", m.passwordResetTTL())
+	body.WriteString("If you did not request a password reset, you can ignore this mail.\n")
+	return &Mail{To: to, Subject: "Password reset", Body: body.String()}
+}
+
+// RequestPasswordReset mails a single-use password reset token to the account with the given username or email.
+// To not reveal if an account exists, the token is created and sent in the background and errors are only logged.
+// Close waits for the mails that are still being sent.
+func (m *LDAPManager) RequestPasswordReset(req *pb.PasswordResetRequest) error {
+	if !m.passwordResetEnabled() {
+		return &PasswordResetDisabledError{}
+	}
+	if req.GetUsername() == "" && req.GetEmail() == "" {
+		return &ValidationError{Message: "must provide a username or email"}
+	}
+	m.background.Add(1)
+	go func() {
+		defer m.background.Done()
+		if err := m.sendPasswordReset(req); err != nil {
+			log.Errorf("failed to send password reset: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (m *LDAPManager) sendPasswordReset(req *pb.PasswordResetRequest) error {
+	var filters []string
+	if req.GetUsername() != "" {
+		filters = append(filters, fmt.Sprintf("(%s=%s)", m.AccountAttribute, escapeFilter(req.GetUsername())))
+	}
+	if req.GetEmail() != "" {
+		filters = append(filters, fmt.Sprintf("(mail=%s)", escapeFilter(req.GetEmail())))
+	}
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.UserGroupDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&(objectClass=posixAccount)%s)", strings.Join(filters, "")),
+		[]string{m.AccountAttribute, "mail"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return err
+	}
+	if len(result.Entries) != 1 {
+		log.Debugf("password reset requested for %d matching accounts", len(result.Entries))
+		return nil
+	}
+	entry := result.Entries[0]
+	username, mail := entry.GetAttributeValue(m.AccountAttribute), entry.GetAttributeValue("mail")
+	if mail == "" {
+		log.Warnf("password reset requested for %q without a mail address", username)
+		return nil
+	}
+	if !m.resetLimiter.Allow(username) {
+		log.Warnf("password reset rate limit exceeded for %q", username)
+		return nil
+	}
+	if err := m.deletePasswordResetTokens(entry.DN, true); err != nil {
+		log.Warnf("failed to delete expired password reset tokens of %q: %v", username, err)
+	}
+
+	tokenBytes := make([]byte, passwordResetTokenSize)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return err
+	}
+	token := base64.RawURLEncoding.EncodeToString(tokenBytes)
+	if err := m.As(username).addPasswordResetToken(token, entry.DN, time.Now().Add(m.passwordResetTTL())); err != nil {
+		return fmt.Errorf("failed to add password reset token for %q: %v", username, err)
+	}
+	if err := m.MailSender.Send(m.passwordResetMail(mail, username, token)); err != nil {
+		return fmt.Errorf("failed to mail password reset token to %q: %v", username, err)
+	}
+	log.Infof("sent password reset token to %q", username)
+	return nil
+}
+
+// ResetPassword sets a new password using a password reset token. Each token can only be used once.
+func (m *LDAPManager) ResetPassword(req *pb.ResetPasswordRequest) error {
+	if !m.passwordResetEnabled() {
+		return &PasswordResetDisabledError{}
+	}
+	if req.GetToken() == "" || req.GetPassword() == "" {
+		return &ValidationError{Message: "must provide a token and password"}
+	}
+	invalidToken := &ValidationError{Message: "invalid or expired password reset token", Field: "token"}
+	tokenDN := m.passwordResetTokenNamed(req.GetToken())
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		tokenDN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=device)",
+		[]string{"owner", "description"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return invalidToken
+		}
+		return fmt.Errorf("failed to find password reset token: %v", err)
+	}
+	if len(result.Entries) != 1 {
+		return invalidToken
+	}
+	userDN := result.Entries[0].GetAttributeValue("owner")
+	expires := passwordResetTokenExpires(result.Entries[0])
+
+	// deleting the token first guarantees that it can only be used once, even for concurrent requests
+	if err := m.del(ldap.NewDelRequest(tokenDN, []ldap.Control{})); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return invalidToken
+		}
+		return fmt.Errorf("failed to delete password reset token: %v", err)
+	}
+	if !time.Now().Before(expires) {
+		return invalidToken
+	}
+
+	user, err := m.ldap.Search(ldap.NewSearchRequest(
+		userDN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=posixAccount)",
+		[]string{m.AccountAttribute},
+		[]ldap.Control{},
+	))
+	if err != nil || len(user.Entries) != 1 {
+		// the account was deleted or renamed after the token was issued
+		return invalidToken
+	}
+	username := user.Entries[0].GetAttributeValue(m.AccountAttribute)
+	if err := m.As(username).ChangePassword(&pb.ChangePasswordRequest{Username: username, Password: req.GetPassword()}, true); err != nil {
+		if _, invalidPassword := err.(*ValidationError); invalidPassword {
+			// keep the token so that the user can choose another password
+			if err := m.As(username).addPasswordResetToken(req.GetToken(), userDN, expires); err != nil {
+				log.Warnf("failed to restore password reset token of %q: %v", username, err)
+			}
+		}
+		return err
+	}
+	if err := m.As(username).deletePasswordResetTokens(userDN, false); err != nil {
+		log.Warnf("failed to delete password reset tokens of %q: %v", username, err)
+	}
+	return nil
+}
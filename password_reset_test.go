@@ -0,0 +1,120 @@
+package ldapmanager
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+type chanMailSender chan *Mail
+
+func (c chanMailSender) Send(mail *Mail) error {
+	c <- mail
+	return nil
+}
+
+var passwordResetTokenPattern = regexp.MustCompile(`(?m)^[A-Za-z0-9_-]{43}$`)
+
+// TestPasswordReset ...
+func TestPasswordReset(t *testing.T) {
+	if skipPasswordResetTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	mails := make(chanMailSender, 10)
+	test.Manager.MailSender = mails
+	test.Manager.resetLimiter = newPasswordResetLimiter(2, time.Hour)
+	if err := test.Manager.setupPasswordResetOU(); err != nil {
+		t.Fatalf("failed to setup password reset: %v", err)
+	}
+	if err := test.Manager.NewAccount(&pb.NewAccountRequest{
+		Account: &pb.Account{
+			Username:  "romnn",
+			Password:  "Hallo Welt",
+			Email:     "romnn@example.org",
+			FirstName: "roman",
+			LastName:  "d",
+		},
+	}, pb.HashingAlgorithm_DEFAULT); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+	receiveToken := func() string {
+		select {
+		case mail := <-mails:
+			if mail.To != "romnn@example.org" {
+				t.Errorf("expected mail to romnn@example.org but got %q", mail.To)
+			}
+			return passwordResetTokenPattern.FindString(mail.Body)
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected a password reset mail")
+		}
+		return ""
+	}
+	expectNoMail := func() {
+		select {
+		case mail := <-mails:
+			t.Errorf("expected no mail but got %+v", mail)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+
+	// requests for unknown accounts succeed but do not send a mail
+	if err := test.Manager.RequestPasswordReset(&pb.PasswordResetRequest{Username: "unknown"}); err != nil {
+		t.Errorf("expected the request for an unknown account to succeed but got %v", err)
+	}
+	expectNoMail()
+
+	if err := test.Manager.RequestPasswordReset(&pb.PasswordResetRequest{Email: "romnn@example.org"}); err != nil {
+		t.Fatalf("failed to request password reset: %v", err)
+	}
+	first := receiveToken()
+	if err := test.Manager.RequestPasswordReset(&pb.PasswordResetRequest{Username: "romnn"}); err != nil {
+		t.Fatalf("failed to request password reset: %v", err)
+	}
+	second := receiveToken()
+	if first == "" || first == second {
+		t.Fatalf("expected two distinct tokens but got %q and %q", first, second)
+	}
+	// the rate limit is reached silently
+	if err := test.Manager.RequestPasswordReset(&pb.PasswordResetRequest{Username: "romnn"}); err != nil {
+		t.Errorf("expected the rate limited request to succeed but got %v", err)
+	}
+	expectNoMail()
+
+	if err := test.Manager.ResetPassword(&pb.ResetPasswordRequest{Token: "invalid", Password: "Neues Passwort"}); err == nil {
+		t.Error("expected reset with an invalid token to fail")
+	}
+	// a rejected password does not consume the token
+	test.Manager.PasswordPolicy = PasswordPolicy{MinLength: 8}
+	if err := test.Manager.ResetPassword(&pb.ResetPasswordRequest{Token: first, Password: "short"}); err == nil {
+		t.Error("expected reset with a weak password to fail")
+	}
+	if err := test.Manager.ResetPassword(&pb.ResetPasswordRequest{Token: first, Password: "Neues Passwort"}); err != nil {
+		t.Fatalf("failed to reset password: %v", err)
+	}
+	if _, err := test.Manager.AuthenticateUser(&pb.LoginRequest{Username: "romnn", Password: "Neues Passwort"}); err != nil {
+		t.Errorf("failed to authenticate with the new password: %v", err)
+	}
+	// tokens are single-use and all other tokens of the account are revoked
+	for _, token := range []string{first, second} {
+		if err := test.Manager.ResetPassword(&pb.ResetPasswordRequest{Token: token, Password: "Anderes Passwort"}); err == nil {
+			t.Errorf("expected token %q to be invalid after the reset", token)
+		}
+	}
+
+	// expired tokens are rejected
+	test.Manager.resetLimiter = newPasswordResetLimiter(2, time.Hour)
+	test.Manager.PasswordResetTTL = time.Second
+	if err := test.Manager.RequestPasswordReset(&pb.PasswordResetRequest{Username: "romnn"}); err != nil {
+		t.Fatalf("failed to request password reset: %v", err)
+	}
+	expired := receiveToken()
+	time.Sleep(2 * time.Second)
+	if err := test.Manager.ResetPassword(&pb.ResetPasswordRequest{Token: expired, Password: "Anderes Passwort"}); err == nil {
+		t.Error("expected reset with an expired token to fail")
+	}
+}
@@ -0,0 +1,295 @@
+package ldapmanager
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrPoolClosed is returned when a connection is requested from a closed pool
+var ErrPoolClosed = errors.New("ldap connection pool is closed")
+
+// ErrPoolTimeout is returned when no connection became available within the AcquireTimeout
+var ErrPoolTimeout = errors.New("timed out waiting for an ldap connection")
+
+// ConnPoolConfig ...
+type ConnPoolConfig struct {
+	// Size is the maximum number of concurrently open connections
+	Size int
+	// MaxRetries is the number of reconnect attempts before giving up
+	MaxRetries int
+	// InitialBackoff is the delay before the first reconnect attempt and doubles on every failed attempt
+	InitialBackoff time.Duration
+	// MaxBackoff limits the delay between reconnect attempts
+	MaxBackoff time.Duration
+	// HealthCheckInterval is the idle time after which a connection is probed before it is reused
+	HealthCheckInterval time.Duration
+	// AcquireTimeout limits the time to wait for a connection when all connections are in use
+	AcquireTimeout time.Duration
+}
+
+// DefaultConnPoolConfig ...
+func DefaultConnPoolConfig() ConnPoolConfig {
+	return ConnPoolConfig{
+		Size:                10,
+		MaxRetries:          5,
+		InitialBackoff:      100 * time.Millisecond,
+		MaxBackoff:          5 * time.Second,
+		HealthCheckInterval: 30 * time.Second,
+		AcquireTimeout:      30 * time.Second,
+	}
+}
+
+type pooledConn struct {
+	conn     *ldap.Conn
+	lastUsed time.Time
+}
+
+// ConnPool manages a set of LDAP connections bound as the admin user.
+// Every operation checks out a connection, makes sure it is alive and
+// transparently reconnects with exponential backoff when the connection was dropped.
+// Pooled connections never change their identity, use a separate connection to bind as a user.
+type ConnPool struct {
+	ConnPoolConfig
+	dial func() (*ldap.Conn, error)
+	bind func(*ldap.Conn) error
+
+	slots chan struct{}
+	idle  chan *pooledConn
+	done  chan struct{} // closed when the pool is closed
+
+	mux    sync.Mutex
+	closed bool
+
+	controlsOnce      sync.Once
+	supportedControls map[string]bool
+}
+
+// NewConnPool ...
+func NewConnPool(cfg ConnPoolConfig, dial func() (*ldap.Conn, error), bind func(*ldap.Conn) error) *ConnPool {
+	defaults := DefaultConnPoolConfig()
+	if cfg.Size < 1 {
+		cfg.Size = defaults.Size
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = defaults.InitialBackoff
+	}
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		cfg.MaxBackoff = cfg.InitialBackoff
+	}
+	if cfg.AcquireTimeout <= 0 {
+		cfg.AcquireTimeout = defaults.AcquireTimeout
+	}
+	return &ConnPool{
+		ConnPoolConfig: cfg,
+		dial:           dial,
+		bind:           bind,
+		slots:          make(chan struct{}, cfg.Size),
+		idle:           make(chan *pooledConn, cfg.Size),
+		done:           make(chan struct{}),
+	}
+}
+
+func (p *ConnPool) isClosed() bool {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return p.closed
+}
+
+// Close closes all idle connections. Connections that are checked out are closed when they are returned.
+func (p *ConnPool) Close() {
+	p.mux.Lock()
+	if p.closed {
+		p.mux.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.done)
+	p.mux.Unlock()
+	for {
+		select {
+		case pc := <-p.idle:
+			pc.conn.Close()
+		default:
+			return
+		}
+	}
+}
+
+func (p *ConnPool) connect() (*pooledConn, error) {
+	backoff := p.InitialBackoff
+	var err error
+	for attempt := 0; attempt <= p.MaxRetries; attempt++ {
+		if attempt > 0 {
+			log.Warnf("failed to connect to OpenLDAP (attempt %d of %d): %v", attempt, p.MaxRetries+1, err)
+			time.Sleep(backoff)
+			if backoff *= 2; backoff > p.MaxBackoff {
+				backoff = p.MaxBackoff
+			}
+		}
+		if p.isClosed() {
+			return nil, ErrPoolClosed
+		}
+		var conn *ldap.Conn
+		if conn, err = p.dial(); err != nil {
+			continue
+		}
+		if err = p.bind(conn); err != nil {
+			conn.Close()
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+				// retrying will not help with invalid credentials
+				return nil, err
+			}
+			continue
+		}
+		return &pooledConn{conn: conn, lastUsed: time.Now()}, nil
+	}
+	return nil, fmt.Errorf("failed to connect to OpenLDAP after %d attempts: %v", p.MaxRetries+1, err)
+}
+
+func (p *ConnPool) healthy(pc *pooledConn) bool {
+	if pc.conn.IsClosing() {
+		return false
+	}
+	if time.Since(pc.lastUsed) < p.HealthCheckInterval {
+		return true
+	}
+	// probe the root DSE, which is cheap and readable by anyone
+	_, err := pc.conn.Search(ldap.NewSearchRequest(
+		"",
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, 0, false,
+		"(objectClass=*)",
+		[]string{"1.1"},
+		[]ldap.Control{},
+	))
+	return err == nil
+}
+
+func (p *ConnPool) get() (*pooledConn, error) {
+	if p.isClosed() {
+		return nil, ErrPoolClosed
+	}
+	timeout := time.NewTimer(p.AcquireTimeout)
+	defer timeout.Stop()
+	select {
+	case p.slots <- struct{}{}:
+	case <-timeout.C:
+		return nil, ErrPoolTimeout
+	case <-p.done:
+		return nil, ErrPoolClosed
+	}
+	for {
+		select {
+		case pc := <-p.idle:
+			if !p.healthy(pc) {
+				log.Debug("discarding unhealthy OpenLDAP connection")
+				pc.conn.Close()
+				continue
+			}
+			return pc, nil
+		default:
+			pc, err := p.connect()
+			if err != nil {
+				<-p.slots
+				return nil, err
+			}
+			return pc, nil
+		}
+	}
+}
+
+func (p *ConnPool) put(pc *pooledConn, broken bool) {
+	defer func() { <-p.slots }()
+	if broken || p.isClosed() {
+		pc.conn.Close()
+		return
+	}
+	pc.lastUsed = time.Now()
+	select {
+	case p.idle <- pc:
+	default:
+		pc.conn.Close()
+	}
+}
+
+func isNetworkError(err error) bool {
+	return ldap.IsErrorWithCode(err, ldap.ErrorNetwork) || ldap.IsErrorWithCode(err, ldap.LDAPResultServerDown)
+}
+
+// do runs op on a pooled connection and retries once on a fresh connection if the connection was lost
+func (p *ConnPool) do(op func(conn *pooledConn) error) error {
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		var pc *pooledConn
+		if pc, err = p.get(); err != nil {
+			return err
+		}
+		err = op(pc)
+		broken := isNetworkError(err)
+		p.put(pc, broken)
+		if !broken {
+			return err
+		}
+		log.Warnf("lost connection to OpenLDAP: %v", err)
+	}
+	return err
+}
+
+// Ping checks that a connection bound as the admin can be established
+func (p *ConnPool) Ping() error {
+	return p.do(func(pc *pooledConn) error { return nil })
+}
+
+// Search ...
+func (p *ConnPool) Search(searchRequest *ldap.SearchRequest) (result *ldap.SearchResult, err error) {
+	err = p.do(func(pc *pooledConn) error {
+		result, err = pc.conn.Search(searchRequest)
+		return err
+	})
+	return result, err
+}
+
+// SearchWithPaging ...
+func (p *ConnPool) SearchWithPaging(searchRequest *ldap.SearchRequest, pagingSize uint32) (result *ldap.SearchResult, err error) {
+	err = p.do(func(pc *pooledConn) error {
+		result, err = pc.conn.SearchWithPaging(searchRequest, pagingSize)
+		return err
+	})
+	return result, err
+}
+
+// Add ...
+func (p *ConnPool) Add(addRequest *ldap.AddRequest) error {
+	return p.do(func(pc *pooledConn) error { return pc.conn.Add(addRequest) })
+}
+
+// Del ...
+func (p *ConnPool) Del(delRequest *ldap.DelRequest) error {
+	return p.do(func(pc *pooledConn) error { return pc.conn.Del(delRequest) })
+}
+
+// Modify ...
+func (p *ConnPool) Modify(modifyRequest *ldap.ModifyRequest) error {
+	return p.do(func(pc *pooledConn) error { return pc.conn.Modify(modifyRequest) })
+}
+
+// ModifyDN ...
+func (p *ConnPool) ModifyDN(modifyDNRequest *ldap.ModifyDNRequest) error {
+	return p.do(func(pc *pooledConn) error { return pc.conn.ModifyDN(modifyDNRequest) })
+}
+
+// Compare ...
+func (p *ConnPool) Compare(dn, attribute, value string) (matches bool, err error) {
+	err = p.do(func(pc *pooledConn) error {
+		matches, err = pc.conn.Compare(dn, attribute, value)
+		return err
+	})
+	return matches, err
+}
@@ -0,0 +1,96 @@
+package ldapmanager
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestConcurrentRequests ...
+func TestConcurrentRequests(t *testing.T) {
+	if skipPoolTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := test.Manager.GetUserList(&pb.GetUserListRequest{}); err != nil {
+				errs <- fmt.Errorf("request %d failed: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// TestReconnect ...
+func TestReconnect(t *testing.T) {
+	if skipPoolTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	// simulate dropped connections by closing all idle connections behind the pool's back
+	pool := test.Manager.ldap
+	for i := 0; i < len(pool.idle); i++ {
+		pc := <-pool.idle
+		pc.conn.Close()
+		pool.idle <- pc
+	}
+	if _, err := test.Manager.GetGroup(&pb.GetGroupRequest{Name: test.Manager.DefaultUserGroup}); err != nil {
+		t.Fatalf("expected the pool to reconnect after the connection was dropped: %v", err)
+	}
+
+	// authenticating a user must not change the identity of the pooled connections
+	if _, err := test.Manager.AuthenticateUser(&pb.LoginRequest{Username: test.Manager.DefaultAdminUsername, Password: test.Manager.DefaultAdminPassword}); err != nil {
+		t.Fatalf("failed to authenticate as %q: %v", test.Manager.DefaultAdminUsername, err)
+	}
+	if err := test.Manager.NewGroup(&pb.NewGroupRequest{Name: "after-bind", Members: []string{test.Manager.DefaultAdminUsername}}, false); err != nil {
+		t.Fatalf("expected pooled connection to still be bound as admin: %v", err)
+	}
+}
+
+// TestAcquireTimeout ...
+func TestAcquireTimeout(t *testing.T) {
+	dial := func() (*ldap.Conn, error) { return nil, errors.New("unreachable") }
+	bind := func(*ldap.Conn) error { return nil }
+	pool := NewConnPool(ConnPoolConfig{Size: 1, AcquireTimeout: 50 * time.Millisecond}, dial, bind)
+	// all connections are in use
+	pool.slots <- struct{}{}
+	if _, err := pool.get(); err != ErrPoolTimeout {
+		t.Errorf("expected %v when the pool is exhausted but got %v", ErrPoolTimeout, err)
+	}
+
+	// waiting requests fail when the pool is closed
+	pool.AcquireTimeout = time.Minute
+	errs := make(chan error)
+	go func() {
+		_, err := pool.get()
+		errs <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	pool.Close()
+	select {
+	case err := <-errs:
+		if err != ErrPoolClosed {
+			t.Errorf("expected %v after closing the pool but got %v", ErrPoolClosed, err)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the waiting request to fail after closing the pool")
+	}
+}
@@ -0,0 +1,542 @@
+package ldapmanager
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	ber "gopkg.in/asn1-ber.v1"
+)
+
+// The password policy overlay (ppolicy) of OpenLDAP locks accounts after failed binds, ages passwords and
+// allows a number of grace logins with an expired password. The state of the password is reported in the
+// response control of a bind that included the password policy request control.
+// Users are bound on a raw connection because the ldap library fails to decode the controls sent by OpenLDAP.
+
+const (
+	startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+	pwdPolicyObjectClass = "pwdPolicy"
+)
+
+// pwdPolicyAttributes are the attributes of a pwdPolicy entry managed by the PwdPolicy messages
+var pwdPolicyAttributes = []string{
+	"pwdMaxAge", "pwdMinAge", "pwdInHistory", "pwdCheckQuality", "pwdMinLength", "pwdExpireWarning",
+	"pwdGraceAuthNLimit", "pwdLockout", "pwdLockoutDuration", "pwdMaxFailure", "pwdFailureCountInterval",
+	"pwdMustChange", "pwdAllowUserChange", "pwdSafeModify",
+}
+
+// PasswordExpiredError is returned when the password of an account expired and no grace logins remain
+type PasswordExpiredError struct {
+	ApplicationError
+	Username string
+}
+
+// Error ...
+func (e *PasswordExpiredError) Error() string {
+	return fmt.Sprintf("the password of account %q expired and must be reset", e.Username)
+}
+
+// Code ...
+func (e *PasswordExpiredError) Code() codes.Code {
+	return codes.FailedPrecondition
+}
+
+// PwdPolicyDisabledError is returned when password policies are managed without the ppolicy overlay being enabled
+type PwdPolicyDisabledError struct {
+	ApplicationError
+}
+
+// Error ...
+func (e *PwdPolicyDisabledError) Error() string {
+	return "password policy overlay is not enabled"
+}
+
+// Code ...
+func (e *PwdPolicyDisabledError) Code() codes.Code {
+	return codes.FailedPrecondition
+}
+
+// PwdPolicyAlreadyExistsError ...
+type PwdPolicyAlreadyExistsError struct {
+	ApplicationError
+	Name string
+}
+
+// Error ...
+func (e *PwdPolicyAlreadyExistsError) Error() string {
+	return fmt.Sprintf("password policy %q already exists", e.Name)
+}
+
+// Code ...
+func (e *PwdPolicyAlreadyExistsError) Code() codes.Code {
+	return codes.AlreadyExists
+}
+
+// NoSuchPwdPolicyError ...
+type NoSuchPwdPolicyError struct {
+	ApplicationError
+	Name string
+}
+
+// Error ...
+func (e *NoSuchPwdPolicyError) Error() string {
+	return fmt.Sprintf("no password policy %q", e.Name)
+}
+
+// Code ...
+func (e *NoSuchPwdPolicyError) Code() codes.Code {
+	return codes.NotFound
+}
+
+// PasswordPolicyStatus is the state of the password reported by the password policy overlay on login
+type PasswordPolicyStatus struct {
+	// ExpiresIn is the time until the password expires if the overlay warns about it
+	ExpiresIn time.Duration
+	// Expired is set if the password expired and one of the remaining grace logins was used
+	Expired              bool
+	GraceLoginsRemaining int
+	// MustChange is set if the password was reset and must be changed
+	MustChange bool
+}
+
+func newPasswordPolicyStatus(control *ldap.ControlBeheraPasswordPolicy) *PasswordPolicyStatus {
+	status := &PasswordPolicyStatus{}
+	if control == nil {
+		return status
+	}
+	if control.Expire >= 0 {
+		status.ExpiresIn = time.Duration(control.Expire) * time.Second
+	}
+	if control.Grace >= 0 {
+		status.Expired = true
+		status.GraceLoginsRemaining = int(control.Grace)
+	}
+	status.MustChange = control.Error == ldap.BeheraChangeAfterReset
+	return status
+}
+
+// passwordPolicyError returns the error of a failed bind reported by the password policy overlay
+func passwordPolicyError(username string, control *ldap.ControlBeheraPasswordPolicy) error {
+	if control == nil {
+		return nil
+	}
+	switch control.Error {
+	case ldap.BeheraAccountLocked:
+		return &AccountLockedError{Username: username}
+	case ldap.BeheraPasswordExpired:
+		return &PasswordExpiredError{Username: username}
+	}
+	return nil
+}
+
+// decodePasswordPolicyControl decodes the value of a password policy response control
+func decodePasswordPolicyControl(value []byte) (*ldap.ControlBeheraPasswordPolicy, error) {
+	control := ldap.NewControlBeheraPasswordPolicy()
+	if len(value) == 0 {
+		return control, nil
+	}
+	sequence, err := ber.DecodePacketErr(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode password policy control: %v", err)
+	}
+	for _, child := range sequence.Children {
+		if child.ClassType != ber.ClassContext {
+			continue
+		}
+		switch child.Tag {
+		case 0:
+			// warning is a choice of timeBeforeExpiration [0] and graceAuthNsRemaining [1]
+			if len(child.Children) != 1 {
+				return nil, errors.New("invalid password policy warning")
+			}
+			warning := child.Children[0]
+			n, err := ber.ParseInt64(warning.Data.Bytes())
+			if err != nil {
+				return nil, fmt.Errorf("invalid password policy warning: %v", err)
+			}
+			switch warning.Tag {
+			case 0:
+				control.Expire = n
+			case 1:
+				control.Grace = n
+			}
+		case 1:
+			n, err := ber.ParseInt64(child.Data.Bytes())
+			if err != nil {
+				return nil, fmt.Errorf("invalid password policy error: %v", err)
+			}
+			control.Error = int8(n)
+			control.ErrorString = ldap.BeheraPasswordPolicyErrorMap[control.Error]
+		}
+	}
+	return control, nil
+}
+
+// findPasswordPolicyControl returns the password policy response control of an LDAP message, if any
+func findPasswordPolicyControl(packet *ber.Packet) (*ldap.ControlBeheraPasswordPolicy, error) {
+	if len(packet.Children) < 3 {
+		return nil, nil
+	}
+	for _, control := range packet.Children[2].Children {
+		if len(control.Children) < 2 {
+			continue
+		}
+		if oid, _ := control.Children[0].Value.(string); oid != ldap.ControlTypeBeheraPasswordPolicy {
+			continue
+		}
+		return decodePasswordPolicyControl(control.Children[len(control.Children)-1].Data.Bytes())
+	}
+	return nil, nil
+}
+
+// exchange sends a single request and reads the response on a raw connection
+func exchange(conn net.Conn, request *ber.Packet) (*ber.Packet, error) {
+	if _, err := conn.Write(request.Bytes()); err != nil {
+		return nil, ldap.NewError(ldap.ErrorNetwork, err)
+	}
+	response, err := ber.ReadPacket(conn)
+	if err != nil {
+		return nil, ldap.NewError(ldap.ErrorNetwork, err)
+	}
+	if len(response.Children) < 2 {
+		return nil, ldap.NewError(ldap.ErrorUnexpectedResponse, errors.New("invalid response"))
+	}
+	return response, nil
+}
+
+// dialRaw connects to the OpenLDAP server with the same TLS settings as dial
+func (m *LDAPManager) dialRaw() (net.Conn, error) {
+	addr := net.JoinHostPort(m.OpenLDAPConfig.Host, strconv.Itoa(m.OpenLDAPConfig.Port))
+	dialer := &net.Dialer{Timeout: ldap.DefaultTimeout}
+	if m.OpenLDAPConfig.Protocol == "ldaps" {
+		return tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: m.OpenLDAPConfig.Host})
+	}
+	conn, err := dialer.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if !m.OpenLDAPConfig.TLS {
+		return conn, nil
+	}
+	conn.SetDeadline(time.Now().Add(ldap.DefaultTimeout))
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Request")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, int64(1), "MessageID"))
+	request := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationExtendedRequest, nil, "Start TLS")
+	request.AppendChild(ber.NewString(ber.ClassContext, ber.TypePrimitive, 0, startTLSOID, "TLS Extended Command"))
+	packet.AppendChild(request)
+	response, err := exchange(conn, packet)
+	if err == nil {
+		err = ldap.GetLDAPError(response)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	tlsConn := tls.Client(conn, &tls.Config{InsecureSkipVerify: true})
+	if err := tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, ldap.NewError(ldap.ErrorNetwork, fmt.Errorf("TLS handshake failed (%v)", err))
+	}
+	return tlsConn, nil
+}
+
+// passwordPolicyBind verifies the credentials of a user with the password policy request control.
+// The returned response control is nil if the server does not use the password policy overlay.
+func (m *LDAPManager) passwordPolicyBind(userDN, password string) (*ldap.ControlBeheraPasswordPolicy, error) {
+	if password == "" {
+		return nil, ldap.NewError(ldap.ErrorEmptyPassword, errors.New("ldap: empty password not allowed by the client"))
+	}
+	conn, err := m.dialRaw()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(ldap.DefaultTimeout))
+
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Request")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, int64(2), "MessageID"))
+	request := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationBindRequest, nil, "Bind Request")
+	request.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, 3, "Version"))
+	request.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, userDN, "User Name"))
+	request.AppendChild(ber.NewString(ber.ClassContext, ber.TypePrimitive, 0, password, "Password"))
+	packet.AppendChild(request)
+	controls := ber.Encode(ber.ClassContext, ber.TypeConstructed, 0, nil, "Controls")
+	controls.AppendChild(ldap.NewControlBeheraPasswordPolicy().Encode())
+	packet.AppendChild(controls)
+
+	response, err := exchange(conn, packet)
+	if err != nil {
+		return nil, err
+	}
+	control, err := findPasswordPolicyControl(response)
+	if err != nil {
+		log.Warn(err)
+	}
+	return control, ldap.GetLDAPError(response)
+}
+
+// clearPasswordReset removes the reset flag the overlay sets when a password is changed by the admin,
+// so that users changing their own password are not required to change it again
+func (m *LDAPManager) clearPasswordReset(userDN string) error {
+	// only accounts with the flag are modified, so the audit log is not cluttered with failed changes
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		userDN,
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(pwdReset=TRUE)",
+		[]string{"1.1"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return fmt.Errorf("failed to check the password reset flag of %q: %v", userDN, err)
+	}
+	if len(result.Entries) < 1 {
+		return nil
+	}
+	modifyRequest := ldap.NewModifyRequest(userDN, []ldap.Control{})
+	modifyRequest.Delete("pwdReset", []string{})
+	if err := m.modify(modifyRequest); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) {
+		return fmt.Errorf("failed to clear the password reset flag of %q: %v", userDN, err)
+	}
+	return nil
+}
+
+func (m *LDAPManager) pwdPolicyEnabled() bool {
+	return m.PPolicyOverlay && m.PPolicyDN != ""
+}
+
+func (m *LDAPManager) setupPwdPolicyOU() error {
+	ou := strings.TrimPrefix(strings.SplitN(m.PPolicyDN, ",", 2)[0], "ou=")
+	return m.setupOU(m.PPolicyDN, ou)
+}
+
+// PwdPolicyNamed ...
+func (m *LDAPManager) PwdPolicyNamed(name string) string {
+	return fmt.Sprintf("cn=%s,%s", escapeDN(name), m.PPolicyDN)
+}
+
+func validPwdPolicy(policy *pb.PwdPolicy) error {
+	if policy.GetName() == "" {
+		return &ValidationError{Message: "password policy name must not be empty", Field: "name"}
+	}
+	var violations []string
+	for field, value := range map[string]int64{
+		"max_age":                policy.GetMaxAge(),
+		"min_age":                policy.GetMinAge(),
+		"in_history":             int64(policy.GetInHistory()),
+		"min_length":             int64(policy.GetMinLength()),
+		"expire_warning":         policy.GetExpireWarning(),
+		"grace_authn_limit":      int64(policy.GetGraceAuthnLimit()),
+		"lockout_duration":       policy.GetLockoutDuration(),
+		"max_failure":            int64(policy.GetMaxFailure()),
+		"failure_count_interval": policy.GetFailureCountInterval(),
+	} {
+		if value < 0 {
+			violations = append(violations, fmt.Sprintf("%s must not be negative", field))
+		}
+	}
+	if quality := policy.GetCheckQuality(); quality < 0 || quality > 2 {
+		violations = append(violations, "check_quality must be 0, 1 or 2")
+	}
+	if len(violations) > 0 {
+		sort.Strings(violations)
+		return &ValidationError{Message: "invalid password policy", Violations: violations}
+	}
+	return nil
+}
+
+// pwdPolicyValues returns the attribute values of a policy, zero values are omitted to use the defaults of the overlay
+func pwdPolicyValues(policy *pb.PwdPolicy) map[string]string {
+	values := make(map[string]string)
+	integer := func(attribute string, value int64) {
+		if value > 0 {
+			values[attribute] = strconv.FormatInt(value, 10)
+		}
+	}
+	boolean := func(attribute string, value bool) {
+		if value {
+			values[attribute] = "TRUE"
+		}
+	}
+	integer("pwdMaxAge", policy.GetMaxAge())
+	integer("pwdMinAge", policy.GetMinAge())
+	integer("pwdInHistory", int64(policy.GetInHistory()))
+	integer("pwdCheckQuality", int64(policy.GetCheckQuality()))
+	integer("pwdMinLength", int64(policy.GetMinLength()))
+	integer("pwdExpireWarning", policy.GetExpireWarning())
+	integer("pwdGraceAuthNLimit", int64(policy.GetGraceAuthnLimit()))
+	boolean("pwdLockout", policy.GetLockout())
+	integer("pwdLockoutDuration", policy.GetLockoutDuration())
+	integer("pwdMaxFailure", int64(policy.GetMaxFailure()))
+	integer("pwdFailureCountInterval", policy.GetFailureCountInterval())
+	boolean("pwdMustChange", policy.GetMustChange())
+	boolean("pwdSafeModify", policy.GetSafeModify())
+	if policy.GetDenyUserChange() {
+		values["pwdAllowUserChange"] = "FALSE"
+	}
+	return values
+}
+
+func parsePwdPolicy(entry *ldap.Entry) *pb.PwdPolicy {
+	integer := func(attribute string) int64 {
+		value, _ := strconv.ParseInt(entry.GetAttributeValue(attribute), 10, 64)
+		return value
+	}
+	boolean := func(attribute string) bool {
+		return strings.EqualFold(entry.GetAttributeValue(attribute), "TRUE")
+	}
+	return &pb.PwdPolicy{
+		Name:                 entry.GetAttributeValue("cn"),
+		MaxAge:               integer("pwdMaxAge"),
+		MinAge:               integer("pwdMinAge"),
+		InHistory:            int32(integer("pwdInHistory")),
+		CheckQuality:         int32(integer("pwdCheckQuality")),
+		MinLength:            int32(integer("pwdMinLength")),
+		ExpireWarning:        integer("pwdExpireWarning"),
+		GraceAuthnLimit:      int32(integer("pwdGraceAuthNLimit")),
+		Lockout:              boolean("pwdLockout"),
+		LockoutDuration:      integer("pwdLockoutDuration"),
+		MaxFailure:           int32(integer("pwdMaxFailure")),
+		FailureCountInterval: integer("pwdFailureCountInterval"),
+		MustChange:           boolean("pwdMustChange"),
+		DenyUserChange:       strings.EqualFold(entry.GetAttributeValue("pwdAllowUserChange"), "FALSE"),
+		SafeModify:           boolean("pwdSafeModify"),
+	}
+}
+
+// GetPwdPolicyList ...
+func (m *LDAPManager) GetPwdPolicyList(req *pb.GetPwdPolicyListRequest) (*pb.PwdPolicyList, error) {
+	if !m.pwdPolicyEnabled() {
+		return nil, &PwdPolicyDisabledError{}
+	}
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.PPolicyDN,
+		ldap.ScopeSingleLevel, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(objectClass=%s)", pwdPolicyObjectClass),
+		append([]string{"cn"}, pwdPolicyAttributes...),
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password policies: %v", err)
+	}
+	policies := &pb.PwdPolicyList{}
+	for _, entry := range result.Entries {
+		policies.Policies = append(policies.Policies, parsePwdPolicy(entry))
+	}
+	sort.Slice(policies.Policies, func(i, j int) bool {
+		return policies.Policies[i].GetName() < policies.Policies[j].GetName()
+	})
+	return policies, nil
+}
+
+// GetPwdPolicy ...
+func (m *LDAPManager) GetPwdPolicy(req *pb.GetPwdPolicyRequest) (*pb.PwdPolicy, error) {
+	if !m.pwdPolicyEnabled() {
+		return nil, &PwdPolicyDisabledError{}
+	}
+	if req.GetName() == "" {
+		return nil, &ValidationError{Message: "password policy name must not be empty", Field: "name"}
+	}
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.PwdPolicyNamed(req.GetName()),
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(objectClass=%s)", pwdPolicyObjectClass),
+		append([]string{"cn"}, pwdPolicyAttributes...),
+		[]ldap.Control{},
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return nil, &NoSuchPwdPolicyError{Name: req.GetName()}
+		}
+		return nil, fmt.Errorf("failed to get password policy %q: %v", req.GetName(), err)
+	}
+	if len(result.Entries) != 1 {
+		return nil, &NoSuchPwdPolicyError{Name: req.GetName()}
+	}
+	return parsePwdPolicy(result.Entries[0]), nil
+}
+
+// NewPwdPolicy ...
+func (m *LDAPManager) NewPwdPolicy(req *pb.PwdPolicy) error {
+	if !m.pwdPolicyEnabled() {
+		return &PwdPolicyDisabledError{}
+	}
+	if err := validPwdPolicy(req); err != nil {
+		return err
+	}
+	attributes := []ldap.Attribute{
+		{Type: "objectClass", Vals: []string{"device", pwdPolicyObjectClass, "top"}},
+		{Type: "cn", Vals: []string{req.GetName()}},
+		{Type: "pwdAttribute", Vals: []string{"userPassword"}},
+	}
+	values := pwdPolicyValues(req)
+	for _, attribute := range pwdPolicyAttributes {
+		if value, ok := values[attribute]; ok {
+			attributes = append(attributes, ldap.Attribute{Type: attribute, Vals: []string{value}})
+		}
+	}
+	if err := m.add(&ldap.AddRequest{
+		DN:         m.PwdPolicyNamed(req.GetName()),
+		Attributes: attributes,
+		Controls:   []ldap.Control{},
+	}); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) {
+			return &PwdPolicyAlreadyExistsError{Name: req.GetName()}
+		}
+		return fmt.Errorf("failed to add password policy %q: %v", req.GetName(), err)
+	}
+	log.Infof("added password policy %q", req.GetName())
+	return nil
+}
+
+// UpdatePwdPolicy replaces all settings of an existing password policy
+func (m *LDAPManager) UpdatePwdPolicy(req *pb.PwdPolicy) error {
+	if err := validPwdPolicy(req); err != nil {
+		return err
+	}
+	if _, err := m.GetPwdPolicy(&pb.GetPwdPolicyRequest{Name: req.GetName()}); err != nil {
+		return err
+	}
+	modifyRequest := ldap.NewModifyRequest(m.PwdPolicyNamed(req.GetName()), []ldap.Control{})
+	values := pwdPolicyValues(req)
+	for _, attribute := range pwdPolicyAttributes {
+		if value, ok := values[attribute]; ok {
+			modifyRequest.Replace(attribute, []string{value})
+		} else {
+			// replacing without values removes the attribute if it exists
+			modifyRequest.Replace(attribute, []string{})
+		}
+	}
+	if err := m.modify(modifyRequest); err != nil {
+		return fmt.Errorf("failed to update password policy %q: %v", req.GetName(), err)
+	}
+	log.Infof("updated password policy %q", req.GetName())
+	return nil
+}
+
+// DeletePwdPolicy ...
+func (m *LDAPManager) DeletePwdPolicy(req *pb.DeletePwdPolicyRequest) error {
+	if !m.pwdPolicyEnabled() {
+		return &PwdPolicyDisabledError{}
+	}
+	if req.GetName() == "" {
+		return &ValidationError{Message: "password policy name must not be empty", Field: "name"}
+	}
+	if err := m.del(ldap.NewDelRequest(m.PwdPolicyNamed(req.GetName()), []ldap.Control{})); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return &NoSuchPwdPolicyError{Name: req.GetName()}
+		}
+		return fmt.Errorf("failed to delete password policy %q: %v", req.GetName(), err)
+	}
+	log.Infof("deleted password policy %q", req.GetName())
+	return nil
+}
@@ -0,0 +1,182 @@
+package ldapmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestDecodePasswordPolicyControl ...
+func TestDecodePasswordPolicyControl(t *testing.T) {
+	cases := []struct {
+		value  []byte
+		expire int64
+		grace  int64
+		err    int8
+	}{
+		{value: []byte{}, expire: -1, grace: -1, err: -1},
+		{value: []byte{0x30, 0x00}, expire: -1, grace: -1, err: -1},
+		// timeBeforeExpiration of 3600 seconds
+		{value: []byte{0x30, 0x06, 0xa0, 0x04, 0x80, 0x02, 0x0e, 0x10}, expire: 3600, grace: -1, err: -1},
+		// two remaining grace logins
+		{value: []byte{0x30, 0x05, 0xa0, 0x03, 0x81, 0x01, 0x02}, expire: -1, grace: 2, err: -1},
+		{value: []byte{0x30, 0x03, 0x81, 0x01, 0x00}, expire: -1, grace: -1, err: ldap.BeheraPasswordExpired},
+		{value: []byte{0x30, 0x03, 0x81, 0x01, 0x01}, expire: -1, grace: -1, err: ldap.BeheraAccountLocked},
+		{value: []byte{0x30, 0x03, 0x81, 0x01, 0x02}, expire: -1, grace: -1, err: ldap.BeheraChangeAfterReset},
+	}
+	for _, c := range cases {
+		control, err := decodePasswordPolicyControl(c.value)
+		if err != nil {
+			t.Fatalf("failed to decode % x: %v", c.value, err)
+		}
+		if control.Expire != c.expire || control.Grace != c.grace || control.Error != c.err {
+			t.Errorf("decoding % x: expected expire=%d grace=%d error=%d but got expire=%d grace=%d error=%d",
+				c.value, c.expire, c.grace, c.err, control.Expire, control.Grace, control.Error)
+		}
+	}
+	if _, err := decodePasswordPolicyControl([]byte{0x30, 0x05, 0xa0}); err == nil {
+		t.Error("expected truncated control to fail")
+	}
+}
+
+// TestPasswordPolicyStatus ...
+func TestPasswordPolicyStatus(t *testing.T) {
+	control := ldap.NewControlBeheraPasswordPolicy()
+	control.Expire = 60
+	if status := newPasswordPolicyStatus(control); status.ExpiresIn != time.Minute || status.Expired {
+		t.Errorf("expected password to expire in a minute but got %+v", status)
+	}
+	control = ldap.NewControlBeheraPasswordPolicy()
+	control.Grace = 0
+	if status := newPasswordPolicyStatus(control); !status.Expired || status.GraceLoginsRemaining != 0 {
+		t.Errorf("expected expired password without grace logins but got %+v", status)
+	}
+	control = ldap.NewControlBeheraPasswordPolicy()
+	control.Error = ldap.BeheraChangeAfterReset
+	if status := newPasswordPolicyStatus(control); !status.MustChange {
+		t.Errorf("expected password to require a change but got %+v", status)
+	}
+
+	control = ldap.NewControlBeheraPasswordPolicy()
+	control.Error = ldap.BeheraAccountLocked
+	if _, ok := passwordPolicyError("a", control).(*AccountLockedError); !ok {
+		t.Error("expected locked account error")
+	}
+	control.Error = ldap.BeheraPasswordExpired
+	if _, ok := passwordPolicyError("a", control).(*PasswordExpiredError); !ok {
+		t.Error("expected password expired error")
+	}
+	if (&PasswordExpiredError{}).Code() == (&AccountLockedError{}).Code() {
+		t.Error("expected distinct error codes for expired passwords and locked accounts")
+	}
+}
+
+// TestPwdPolicyAttributes ...
+func TestPwdPolicyAttributes(t *testing.T) {
+	policy := &pb.PwdPolicy{
+		Name:                 "default",
+		MaxAge:               90 * secondsPerDay,
+		InHistory:            5,
+		CheckQuality:         2,
+		MinLength:            12,
+		ExpireWarning:        7 * secondsPerDay,
+		GraceAuthnLimit:      3,
+		Lockout:              true,
+		LockoutDuration:      900,
+		MaxFailure:           5,
+		FailureCountInterval: 300,
+		MustChange:           true,
+		DenyUserChange:       true,
+	}
+	if err := validPwdPolicy(policy); err != nil {
+		t.Fatalf("expected valid policy but got %v", err)
+	}
+	attributes := map[string][]string{"cn": {policy.GetName()}}
+	for attribute, value := range pwdPolicyValues(policy) {
+		attributes[attribute] = []string{value}
+	}
+	if _, ok := attributes["pwdMinAge"]; ok {
+		t.Error("expected zero values to be omitted")
+	}
+	parsed := parsePwdPolicy(ldap.NewEntry("cn=default,ou=policies,dc=example,dc=org", attributes))
+	if !proto.Equal(parsed, policy) {
+		t.Errorf("expected %v but got %v", policy, parsed)
+	}
+
+	if err := validPwdPolicy(&pb.PwdPolicy{Name: "invalid", MaxFailure: -1, CheckQuality: 3}); err == nil {
+		t.Error("expected invalid policy to be rejected")
+	} else if violations := err.(*ValidationError).Violations; len(violations) != 2 {
+		t.Errorf("expected two violations but got %v", violations)
+	}
+}
+
+// TestPwdPolicies ...
+func TestPwdPolicies(t *testing.T) {
+	if skipPPolicyTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	if _, err := test.Manager.GetPwdPolicyList(&pb.GetPwdPolicyListRequest{}); err == nil {
+		t.Fatal("expected password policies to require the overlay to be enabled")
+	}
+	test.Manager.PPolicyOverlay = true
+	if err := test.Manager.setupPwdPolicyOU(); err != nil {
+		t.Fatalf("failed to setup password policy OU: %v", err)
+	}
+
+	policy := &pb.PwdPolicy{Name: "default", MaxAge: 3600, MinLength: 8, Lockout: true, MaxFailure: 3}
+	if err := test.Manager.NewPwdPolicy(policy); err != nil {
+		t.Fatalf("failed to add password policy: %v", err)
+	}
+	if _, ok := test.Manager.NewPwdPolicy(policy).(*PwdPolicyAlreadyExistsError); !ok {
+		t.Error("expected adding the policy again to fail")
+	}
+	policy.MaxFailure = 0
+	policy.GraceAuthnLimit = 2
+	if err := test.Manager.UpdatePwdPolicy(policy); err != nil {
+		t.Fatalf("failed to update password policy: %v", err)
+	}
+	updated, err := test.Manager.GetPwdPolicy(&pb.GetPwdPolicyRequest{Name: "default"})
+	if err != nil {
+		t.Fatalf("failed to get password policy: %v", err)
+	}
+	if !proto.Equal(updated, policy) {
+		t.Errorf("expected %v but got %v", policy, updated)
+	}
+	policies, err := test.Manager.GetPwdPolicyList(&pb.GetPwdPolicyListRequest{})
+	if err != nil {
+		t.Fatalf("failed to list password policies: %v", err)
+	}
+	if len(policies.GetPolicies()) != 1 {
+		t.Errorf("expected one password policy but got %v", policies.GetPolicies())
+	}
+
+	// users bind with the password policy control
+	if err := test.Manager.NewAccount(&pb.NewAccountRequest{Account: &pb.Account{
+		Username:  "ppolicy",
+		Password:  "Hallo Welt",
+		Email:     "ppolicy@example.org",
+		FirstName: "roman",
+		LastName:  "d",
+	}}, pb.HashingAlgorithm_DEFAULT); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+	if _, _, err := test.Manager.AuthenticateUserWithPolicy(&pb.LoginRequest{Username: "ppolicy", Password: "Hallo Welt"}); err != nil {
+		t.Errorf("expected user to authenticate but got %v", err)
+	}
+	if _, _, err := test.Manager.AuthenticateUserWithPolicy(&pb.LoginRequest{Username: "ppolicy", Password: "wrong"}); err == nil {
+		t.Error("expected wrong password to be rejected")
+	}
+
+	if err := test.Manager.DeletePwdPolicy(&pb.DeletePwdPolicyRequest{Name: "default"}); err != nil {
+		t.Fatalf("failed to delete password policy: %v", err)
+	}
+	if _, ok := test.Manager.DeletePwdPolicy(&pb.DeletePwdPolicyRequest{Name: "default"}).(*NoSuchPwdPolicyError); !ok {
+		t.Error("expected deleting a missing policy to fail")
+	}
+}
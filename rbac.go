@@ -0,0 +1,227 @@
+package ldapmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// Users hold a role if they are a member of one of the LDAP groups the role is mapped to.
+// Members of the DefaultAdminGroup always hold the admin role and owners of a group the group-owner role.
+
+const (
+	// RoleAdmin is granted all permissions
+	RoleAdmin = "admin"
+	// RoleHelpdesk can reset passwords and unlock accounts
+	RoleHelpdesk = "helpdesk"
+	// RoleGroupOwner can manage the members of the groups the user owns
+	RoleGroupOwner = "group-owner"
+	// RoleAuditor has read-only access
+	RoleAuditor = "auditor"
+)
+
+// DefaultRoles returns the permissions of the built-in roles
+func DefaultRoles() map[string][]pb.Permission {
+	var all []pb.Permission
+	for value := range pb.Permission_name {
+		if permission := pb.Permission(value); permission != pb.Permission_AUTHENTICATED {
+			all = append(all, permission)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	return map[string][]pb.Permission{
+		RoleAdmin: all,
+		RoleHelpdesk: {
+			pb.Permission_READ_ACCOUNTS,
+			pb.Permission_RESET_PASSWORDS,
+			pb.Permission_UNLOCK_ACCOUNTS,
+			pb.Permission_MANAGE_SESSIONS,
+			pb.Permission_READ_GROUPS,
+		},
+		RoleGroupOwner: {
+			pb.Permission_READ_GROUPS,
+			pb.Permission_MANAGE_OWNED_GROUP_MEMBERS,
+		},
+		RoleAuditor: {
+			pb.Permission_READ_ACCOUNTS,
+			pb.Permission_READ_GROUPS,
+			pb.Permission_READ_AUDIT_LOG,
+			pb.Permission_READ_PASSWORD_POLICIES,
+		},
+	}
+}
+
+// splitRoleSpecs splits specs of the form NAME=VALUE[,VALUE...] into the values of each name.
+// Values without a name belong to the previous spec because lists from environment variables are split at commas.
+func splitRoleSpecs(specs []string, format string) ([]string, map[string][]string, error) {
+	var names []string
+	values := make(map[string][]string)
+	name := ""
+	for _, spec := range specs {
+		value := spec
+		if pair := strings.SplitN(spec, "=", 2); len(pair) == 2 {
+			if pair[0] == "" {
+				return nil, nil, fmt.Errorf("invalid role %q: expected %s", spec, format)
+			}
+			name, value = pair[0], pair[1]
+			if _, ok := values[name]; !ok {
+				names = append(names, name)
+			}
+		} else if name == "" {
+			return nil, nil, fmt.Errorf("invalid role %q: expected %s", spec, format)
+		}
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values[name] = append(values[name], v)
+			}
+		}
+		if len(values[name]) == 0 {
+			return nil, nil, fmt.Errorf("invalid role %q: expected %s", spec, format)
+		}
+	}
+	return names, values, nil
+}
+
+// ParseRoles parses role definitions of the form NAME=PERMISSION[,PERMISSION...] and adds them to the default roles
+func ParseRoles(specs []string) (map[string][]pb.Permission, error) {
+	roles := DefaultRoles()
+	names, values, err := splitRoleSpecs(specs, "NAME=PERMISSION[,PERMISSION...]")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		var permissions []pb.Permission
+		for _, permission := range values[name] {
+			value, ok := pb.Permission_value[strings.ToUpper(permission)]
+			if !ok {
+				return nil, fmt.Errorf("invalid role %q: unknown permission %q", name, permission)
+			}
+			permissions = append(permissions, pb.Permission(value))
+		}
+		roles[name] = permissions
+	}
+	return roles, nil
+}
+
+// ParseRoleGroups parses mappings of the form NAME=GROUP[,GROUP...] from roles to the LDAP groups whose members hold them
+func ParseRoleGroups(specs []string, roles map[string][]pb.Permission) (map[string][]string, error) {
+	names, values, err := splitRoleSpecs(specs, "NAME=GROUP[,GROUP...]")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if _, ok := roles[name]; !ok {
+			return nil, fmt.Errorf("unknown role %q", name)
+		}
+	}
+	return values, nil
+}
+
+func (m *LDAPManager) roles() map[string][]pb.Permission {
+	if m.Roles != nil {
+		return m.Roles
+	}
+	return DefaultRoles()
+}
+
+// GetRoles returns the roles a user holds through the membership in LDAP groups, including nested groups
+func (m *LDAPManager) GetRoles(username string) ([]string, error) {
+	groups, err := m.GetUserGroups(&pb.GetUserGroupsRequest{Username: username, Effective: true})
+	if err != nil {
+		return nil, err
+	}
+	member := make(map[string]bool)
+	for _, group := range groups.GetGroups() {
+		member[group] = true
+	}
+	owned, err := m.GetOwnedGroups(&pb.GetOwnedGroupsRequest{Username: username})
+	if err != nil {
+		return nil, err
+	}
+	var roles []string
+	for role := range m.roles() {
+		if role == RoleGroupOwner && len(owned.GetGroups()) > 0 {
+			roles = append(roles, role)
+			continue
+		}
+		roleGroups := m.RoleGroups[role]
+		if role == RoleAdmin {
+			roleGroups = append([]string{m.DefaultAdminGroup}, roleGroups...)
+		}
+		for _, group := range roleGroups {
+			if member[group] {
+				roles = append(roles, role)
+				break
+			}
+		}
+	}
+	sort.Strings(roles)
+	return roles, nil
+}
+
+// Permissions returns the permissions granted by the roles
+func (m *LDAPManager) Permissions(roles []string) []pb.Permission {
+	granted := make(map[pb.Permission]bool)
+	definitions := m.roles()
+	for _, role := range roles {
+		for _, permission := range definitions[role] {
+			granted[permission] = true
+		}
+	}
+	permissions := make([]pb.Permission, 0, len(granted))
+	for permission := range granted {
+		permissions = append(permissions, permission)
+	}
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i] < permissions[j] })
+	return permissions
+}
+
+// HasPermission reports if one of the roles grants the permission
+func (m *LDAPManager) HasPermission(roles []string, permission pb.Permission) bool {
+	if permission == pb.Permission_AUTHENTICATED {
+		return true
+	}
+	definitions := m.roles()
+	for _, role := range roles {
+		for _, granted := range definitions[role] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasAllPermissions reports if the roles grant every permission of the other roles
+func (m *LDAPManager) HasAllPermissions(roles, otherRoles []string) bool {
+	for _, permission := range m.Permissions(otherRoles) {
+		if !m.HasPermission(roles, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsGroupOwner reports if the user is listed in the owner attribute of the group
+func (m *LDAPManager) IsGroupOwner(group, username string) (bool, error) {
+	if group == "" || username == "" {
+		return false, nil
+	}
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.GroupNamed(group),
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(owner=%s)", escapeFilter(m.AccountNamed(username))),
+		[]string{"dn"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check owners of group %q: %v", group, err)
+	}
+	return len(result.Entries) == 1, nil
+}
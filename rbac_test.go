@@ -0,0 +1,146 @@
+package ldapmanager
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestParseRoles ...
+func TestParseRoles(t *testing.T) {
+	roles, err := ParseRoles([]string{"operator=read_accounts,UNLOCK_ACCOUNTS", "auditor=READ_AUDIT_LOG"})
+	if err != nil {
+		t.Fatalf("failed to parse roles: %v", err)
+	}
+	if expected := []pb.Permission{pb.Permission_READ_ACCOUNTS, pb.Permission_UNLOCK_ACCOUNTS}; !reflect.DeepEqual(roles["operator"], expected) {
+		t.Errorf("expected operator to have %v but got %v", expected, roles["operator"])
+	}
+	if expected := []pb.Permission{pb.Permission_READ_AUDIT_LOG}; !reflect.DeepEqual(roles[RoleAuditor], expected) {
+		t.Errorf("expected auditor to be overridden with %v but got %v", expected, roles[RoleAuditor])
+	}
+	if _, ok := roles[RoleHelpdesk]; !ok {
+		t.Error("expected built-in roles to be kept")
+	}
+	// lists from environment variables are split at commas
+	roles, err = ParseRoles([]string{"operator=READ_ACCOUNTS", "UNLOCK_ACCOUNTS"})
+	if err != nil {
+		t.Fatalf("failed to parse split roles: %v", err)
+	}
+	if len(roles["operator"]) != 2 {
+		t.Errorf("expected split permissions to belong to operator but got %v", roles["operator"])
+	}
+	for _, invalid := range [][]string{{"READ_ACCOUNTS"}, {"=READ_ACCOUNTS"}, {"operator="}, {"operator=FLY"}} {
+		if _, err := ParseRoles(invalid); err == nil {
+			t.Errorf("expected %v to be invalid", invalid)
+		}
+	}
+}
+
+// TestParseRoleGroups ...
+func TestParseRoleGroups(t *testing.T) {
+	mapping, err := ParseRoleGroups([]string{"helpdesk=support,servicedesk", "auditor=audit", "helpdesk=oncall"}, DefaultRoles())
+	if err != nil {
+		t.Fatalf("failed to parse role groups: %v", err)
+	}
+	expected := map[string][]string{
+		RoleHelpdesk: {"support", "servicedesk", "oncall"},
+		RoleAuditor:  {"audit"},
+	}
+	if !reflect.DeepEqual(mapping, expected) {
+		t.Errorf("expected %v but got %v", expected, mapping)
+	}
+	if _, err := ParseRoleGroups([]string{"janitor=cleaning"}, DefaultRoles()); err == nil {
+		t.Error("expected unknown role to be rejected")
+	}
+}
+
+// TestPermissions ...
+func TestPermissions(t *testing.T) {
+	manager := &LDAPManager{Roles: DefaultRoles()}
+	for permission := range pb.Permission_name {
+		if !manager.HasPermission([]string{RoleAdmin}, pb.Permission(permission)) {
+			t.Errorf("expected admin to have %s", pb.Permission(permission))
+		}
+	}
+	if !manager.HasPermission(nil, pb.Permission_AUTHENTICATED) {
+		t.Error("expected users without roles to be authenticated")
+	}
+	if manager.HasPermission(nil, pb.Permission_READ_ACCOUNTS) {
+		t.Error("expected users without roles to have no permissions")
+	}
+	helpdesk := []string{RoleHelpdesk}
+	if !manager.HasPermission(helpdesk, pb.Permission_UNLOCK_ACCOUNTS) || manager.HasPermission(helpdesk, pb.Permission_MANAGE_ACCOUNTS) {
+		t.Error("expected helpdesk to unlock but not manage accounts")
+	}
+	auditor := []string{RoleAuditor}
+	for _, permission := range manager.Permissions(auditor) {
+		if permission != pb.Permission_READ_ACCOUNTS && permission != pb.Permission_READ_GROUPS &&
+			permission != pb.Permission_READ_AUDIT_LOG && permission != pb.Permission_READ_PASSWORD_POLICIES {
+			t.Errorf("expected auditor to be read-only but got %s", permission)
+		}
+	}
+	combined := manager.Permissions([]string{RoleHelpdesk, RoleGroupOwner, "unknown"})
+	if len(combined) != 6 {
+		t.Errorf("expected the union of the permissions but got %v", combined)
+	}
+	if manager.HasAllPermissions(helpdesk, []string{RoleAdmin}) || manager.HasAllPermissions(helpdesk, auditor) {
+		t.Error("expected helpdesk to lack permissions of admins and auditors")
+	}
+	if !manager.HasAllPermissions([]string{RoleAdmin}, helpdesk) || !manager.HasAllPermissions(helpdesk, nil) {
+		t.Error("expected admins to hold the permissions of the helpdesk")
+	}
+}
+
+// TestGetRoles ...
+func TestGetRoles(t *testing.T) {
+	if skipRBACTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	test.Manager.RoleGroups = map[string][]string{RoleHelpdesk: {"support"}, RoleGroupOwner: {"leads"}}
+	if err := test.Manager.NewAccount(&pb.NewAccountRequest{Account: &pb.Account{
+		Username:  "helper",
+		Password:  "Hallo Welt",
+		Email:     "helper@example.org",
+		FirstName: "roman",
+		LastName:  "d",
+	}}, pb.HashingAlgorithm_DEFAULT); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+	if err := test.Manager.NewGroup(&pb.NewGroupRequest{Name: "support", Members: []string{"helper"}}, false); err != nil {
+		t.Fatalf("failed to add group: %v", err)
+	}
+	roles, err := test.Manager.GetRoles("helper")
+	if err != nil {
+		t.Fatalf("failed to get roles: %v", err)
+	}
+	if !reflect.DeepEqual(roles, []string{RoleHelpdesk}) {
+		t.Errorf("expected helper to be helpdesk but got %v", roles)
+	}
+	roles, err = test.Manager.GetRoles(test.Manager.DefaultAdminUsername)
+	if err != nil {
+		t.Fatalf("failed to get roles: %v", err)
+	}
+	if !reflect.DeepEqual(roles, []string{RoleAdmin}) {
+		t.Errorf("expected the default admin to be admin but got %v", roles)
+	}
+
+	if owner, err := test.Manager.IsGroupOwner("support", "helper"); err != nil || owner {
+		t.Errorf("expected helper to not own support (err=%v)", err)
+	}
+	modifyRequest := ldap.NewModifyRequest(test.Manager.GroupNamed("support"), []ldap.Control{})
+	modifyRequest.Add("owner", []string{test.Manager.AccountNamed("helper")})
+	if err := test.Manager.modify(modifyRequest); err != nil {
+		t.Fatalf("failed to add group owner: %v", err)
+	}
+	if owner, err := test.Manager.IsGroupOwner("support", "helper"); err != nil || !owner {
+		t.Errorf("expected helper to own support (err=%v)", err)
+	}
+	if owner, err := test.Manager.IsGroupOwner("missing", "helper"); err != nil || owner {
+		t.Errorf("expected missing group to have no owners (err=%v)", err)
+	}
+}
@@ -0,0 +1,308 @@
+package ldapmanager
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	encodinghex "encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// Sessions are kept in a dedicated subtree with one device entry per session, the entry's owner is the account.
+// A refresh token is the session ID and a secret, only the SHA-256 hash of the secret is stored as serialNumber
+// and the description is the expiration time. Revoking a session deletes its entry.
+
+const (
+	// DefaultSessionTTL is the default validity of a refresh token
+	DefaultSessionTTL = 7 * 24 * time.Hour
+
+	sessionIDSize     = 16
+	sessionSecretSize = 32
+)
+
+// Session ...
+type Session struct {
+	ID           string
+	RefreshToken string
+	Expires      time.Time
+}
+
+// InvalidRefreshTokenError is returned when a refresh token is unknown, expired, revoked or was already used
+type InvalidRefreshTokenError struct {
+	ApplicationError
+}
+
+// Error ...
+func (e *InvalidRefreshTokenError) Error() string {
+	return "invalid or expired refresh token"
+}
+
+// Code ...
+func (e *InvalidRefreshTokenError) Code() codes.Code {
+	return codes.Unauthenticated
+}
+
+// SessionsEnabled reports if tokens are issued with refresh tokens and can be revoked
+func (m *LDAPManager) SessionsEnabled() bool {
+	return m.SessionDN != ""
+}
+
+func (m *LDAPManager) setupSessionOU() error {
+	ou := strings.TrimPrefix(strings.SplitN(m.SessionDN, ",", 2)[0], "ou=")
+	return m.setupOU(m.SessionDN, ou)
+}
+
+func (m *LDAPManager) sessionNamed(id string) string {
+	return fmt.Sprintf("cn=%s,%s", escapeDN(id), m.SessionDN)
+}
+
+func (m *LDAPManager) sessionTTL() time.Duration {
+	if m.SessionTTL > 0 {
+		return m.SessionTTL
+	}
+	return DefaultSessionTTL
+}
+
+func hashSessionSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return encodinghex.EncodeToString(hash[:])
+}
+
+func newSessionSecret() (string, error) {
+	secret := make([]byte, sessionSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// parseRefreshToken splits a refresh token into the session ID and secret
+func parseRefreshToken(token string) (string, string, bool) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	if _, err := encodinghex.DecodeString(parts[0]); err != nil {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// NewSession starts a session for an authenticated user
+func (m *LDAPManager) NewSession(username string) (*Session, error) {
+	entry, err := m.findAccount(username, []string{"dn"})
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, sessionIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	secret, err := newSessionSecret()
+	if err != nil {
+		return nil, err
+	}
+	session := &Session{
+		ID:      encodinghex.EncodeToString(id),
+		Expires: time.Now().Add(m.sessionTTL()),
+	}
+	session.RefreshToken = session.ID + "." + secret
+	if err := m.add(&ldap.AddRequest{
+		DN: m.sessionNamed(session.ID),
+		Attributes: []ldap.Attribute{
+			{Type: "objectClass", Vals: []string{"device", "top"}},
+			{Type: "cn", Vals: []string{session.ID}},
+			{Type: "owner", Vals: []string{entry.DN}},
+			{Type: "serialNumber", Vals: []string{hashSessionSecret(secret)}},
+			{Type: "description", Vals: []string{strconv.FormatInt(session.Expires.Unix(), 10)}},
+		},
+		Controls: []ldap.Control{},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to add session for %q: %v", username, err)
+	}
+	if err := m.deleteSessions(entry.DN, true); err != nil {
+		log.Warnf("failed to delete expired sessions of %q: %v", username, err)
+	}
+	return session, nil
+}
+
+// RefreshSession replaces the refresh token of a session and returns the account of the session.
+// Refresh tokens can only be used once, using a refresh token again revokes the session.
+func (m *LDAPManager) RefreshSession(refreshToken string) (*ldap.Entry, *Session, error) {
+	id, secret, ok := parseRefreshToken(refreshToken)
+	if !m.SessionsEnabled() || !ok {
+		return nil, nil, &InvalidRefreshTokenError{}
+	}
+	entry, err := m.getSession(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if entry == nil || !time.Now().Before(sessionExpires(entry)) {
+		return nil, nil, &InvalidRefreshTokenError{}
+	}
+	hash := entry.GetAttributeValue("serialNumber")
+	if subtle.ConstantTimeCompare([]byte(hashSessionSecret(secret)), []byte(hash)) != 1 {
+		// the refresh token was replaced before, so it was likely stolen
+		log.Warnf("refresh token of session %q was used again", id)
+		if err := m.RevokeSession(id); err != nil {
+			log.Warn(err)
+		}
+		return nil, nil, &InvalidRefreshTokenError{}
+	}
+
+	user, err := m.ldap.Search(ldap.NewSearchRequest(
+		entry.GetAttributeValue("owner"),
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=posixAccount)",
+		m.defaultUserFields(),
+		[]ldap.Control{},
+	))
+	if err != nil || len(user.Entries) != 1 {
+		// the account was deleted after the session started
+		return nil, nil, &InvalidRefreshTokenError{}
+	}
+	account := user.Entries[0]
+	if err := checkAccountStatus(account.GetAttributeValue(m.AccountAttribute), account); err != nil {
+		return nil, nil, err
+	}
+
+	newSecret, err := newSessionSecret()
+	if err != nil {
+		return nil, nil, err
+	}
+	session := &Session{ID: id, RefreshToken: id + "." + newSecret, Expires: time.Now().Add(m.sessionTTL())}
+	// removing the old hash fails if a concurrent refresh already replaced it
+	modifyRequest := ldap.NewModifyRequest(entry.DN, []ldap.Control{})
+	modifyRequest.Delete("serialNumber", []string{hash})
+	modifyRequest.Add("serialNumber", []string{hashSessionSecret(newSecret)})
+	modifyRequest.Replace("description", []string{strconv.FormatInt(session.Expires.Unix(), 10)})
+	if err := m.As(account.GetAttributeValue(m.AccountAttribute)).modify(modifyRequest); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) || ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return nil, nil, &InvalidRefreshTokenError{}
+		}
+		return nil, nil, fmt.Errorf("failed to refresh session %q: %v", id, err)
+	}
+	return account, session, nil
+}
+
+func (m *LDAPManager) getSession(id string) (*ldap.Entry, error) {
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.sessionNamed(id),
+		ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=device)",
+		[]string{"owner", "serialNumber", "description"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find session %q: %v", id, err)
+	}
+	if len(result.Entries) != 1 {
+		return nil, nil
+	}
+	return result.Entries[0], nil
+}
+
+// SessionActive reports if a session was neither revoked nor expired
+func (m *LDAPManager) SessionActive(id string) (bool, error) {
+	if id == "" {
+		return false, nil
+	}
+	entry, err := m.getSession(id)
+	if err != nil || entry == nil {
+		return false, err
+	}
+	return time.Now().Before(sessionExpires(entry)), nil
+}
+
+// RevokeSession ends a single session
+func (m *LDAPManager) RevokeSession(id string) error {
+	if err := m.del(ldap.NewDelRequest(m.sessionNamed(id), []ldap.Control{})); err != nil &&
+		!ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return fmt.Errorf("failed to revoke session %q: %v", id, err)
+	}
+	return nil
+}
+
+// RevokeSessions ends all sessions of an account
+func (m *LDAPManager) RevokeSessions(req *pb.RevokeSessionsRequest) error {
+	if !m.SessionsEnabled() {
+		return nil
+	}
+	if req.GetUsername() == "" {
+		return &ValidationError{Message: "username must not be empty"}
+	}
+	if err := m.deleteSessions(m.AccountNamed(req.GetUsername()), false); err != nil {
+		return fmt.Errorf("failed to revoke sessions of %q: %v", req.GetUsername(), err)
+	}
+	log.Infof("revoked sessions of %q", req.GetUsername())
+	return nil
+}
+
+// deleteSessions removes the sessions of an account, optionally only the expired ones
+func (m *LDAPManager) deleteSessions(userDN string, onlyExpired bool) error {
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.SessionDN,
+		ldap.ScopeSingleLevel, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&(objectClass=device)(owner=%s))", escapeFilter(userDN)),
+		[]string{"description"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return err
+	}
+	for _, entry := range result.Entries {
+		if onlyExpired && time.Now().Before(sessionExpires(entry)) {
+			continue
+		}
+		if err := m.del(ldap.NewDelRequest(entry.DN, []ldap.Control{})); err != nil &&
+			!ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveSessions keeps the sessions of a renamed account
+func (m *LDAPManager) moveSessions(userDN, newUserDN string) error {
+	if !m.SessionsEnabled() {
+		return nil
+	}
+	result, err := m.ldap.Search(ldap.NewSearchRequest(
+		m.SessionDN,
+		ldap.ScopeSingleLevel, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf("(&(objectClass=device)(owner=%s))", escapeFilter(userDN)),
+		[]string{"dn"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return fmt.Errorf("failed to find sessions of %q: %v", userDN, err)
+	}
+	for _, entry := range result.Entries {
+		modifyRequest := ldap.NewModifyRequest(entry.DN, []ldap.Control{})
+		modifyRequest.Replace("owner", []string{newUserDN})
+		if err := m.modify(modifyRequest); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return fmt.Errorf("failed to move session %q: %v", entry.DN, err)
+		}
+	}
+	return nil
+}
+
+func sessionExpires(entry *ldap.Entry) time.Time {
+	expires, err := strconv.ParseInt(entry.GetAttributeValue("description"), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(expires, 0)
+}
@@ -0,0 +1,128 @@
+package ldapmanager
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestParseRefreshToken ...
+func TestParseRefreshToken(t *testing.T) {
+	cases := []struct {
+		token  string
+		id     string
+		secret string
+		valid  bool
+	}{
+		{token: "0a1b2c.secret", id: "0a1b2c", secret: "secret", valid: true},
+		{token: "0a1b2c.sec.ret", id: "0a1b2c", secret: "sec.ret", valid: true},
+		{token: "", valid: false},
+		{token: "0a1b2c", valid: false},
+		{token: ".secret", valid: false},
+		{token: "0a1b2c.", valid: false},
+		{token: "not-hex.secret", valid: false},
+		{token: "cn=x,ou=sessions.secret", valid: false},
+	}
+	for _, c := range cases {
+		id, secret, valid := parseRefreshToken(c.token)
+		if valid != c.valid || id != c.id || secret != c.secret {
+			t.Errorf("parsing %q: expected (%q, %q, %t) but got (%q, %q, %t)", c.token, c.id, c.secret, c.valid, id, secret, valid)
+		}
+	}
+}
+
+// TestSessionExpires ...
+func TestSessionExpires(t *testing.T) {
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	entry := ldap.NewEntry("cn=0a1b2c,ou=sessions,dc=example,dc=org", map[string][]string{
+		"description": {strconv.FormatInt(expires.Unix(), 10)},
+	})
+	if !sessionExpires(entry).Equal(expires) {
+		t.Errorf("expected session to expire at %v but got %v", expires, sessionExpires(entry))
+	}
+	invalid := ldap.NewEntry("cn=0a1b2c,ou=sessions,dc=example,dc=org", map[string][]string{"description": {"soon"}})
+	if time.Now().Before(sessionExpires(invalid)) {
+		t.Error("expected session with an invalid expiration to be expired")
+	}
+}
+
+// TestSessions ...
+func TestSessions(t *testing.T) {
+	if skipSessionTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	if err := test.Manager.NewAccount(&pb.NewAccountRequest{Account: &pb.Account{
+		Username:  "romnn",
+		Password:  "Hallo Welt",
+		Email:     "romnn@example.org",
+		FirstName: "roman",
+		LastName:  "d",
+	}}, pb.HashingAlgorithm_DEFAULT); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+
+	session, err := test.Manager.NewSession("romnn")
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	if active, err := test.Manager.SessionActive(session.ID); err != nil || !active {
+		t.Fatalf("expected new session to be active (err=%v)", err)
+	}
+	user, refreshed, err := test.Manager.RefreshSession(session.RefreshToken)
+	if err != nil {
+		t.Fatalf("failed to refresh session: %v", err)
+	}
+	if username := user.GetAttributeValue(test.Manager.AccountAttribute); username != "romnn" {
+		t.Errorf("expected refreshed session to belong to %q but got %q", "romnn", username)
+	}
+	if refreshed.ID != session.ID || refreshed.RefreshToken == session.RefreshToken {
+		t.Errorf("expected the refresh token of session %q to be replaced", session.ID)
+	}
+
+	// using a replaced refresh token again revokes the session
+	if _, _, err := test.Manager.RefreshSession(session.RefreshToken); err == nil {
+		t.Error("expected replaced refresh token to be rejected")
+	}
+	if active, _ := test.Manager.SessionActive(session.ID); active {
+		t.Error("expected session to be revoked after its refresh token was used again")
+	}
+	if _, _, err := test.Manager.RefreshSession(refreshed.RefreshToken); err == nil {
+		t.Error("expected refresh token of a revoked session to be rejected")
+	}
+
+	// revoking all sessions
+	var sessions []*Session
+	for i := 0; i < 2; i++ {
+		session, err := test.Manager.NewSession("romnn")
+		if err != nil {
+			t.Fatalf("failed to start session: %v", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := test.Manager.RevokeSessions(&pb.RevokeSessionsRequest{Username: "romnn"}); err != nil {
+		t.Fatalf("failed to revoke sessions: %v", err)
+	}
+	for _, session := range sessions {
+		if active, _ := test.Manager.SessionActive(session.ID); active {
+			t.Errorf("expected session %q to be revoked", session.ID)
+		}
+	}
+
+	// locked accounts can not refresh their sessions
+	session, err = test.Manager.NewSession("romnn")
+	if err != nil {
+		t.Fatalf("failed to start session: %v", err)
+	}
+	if err := test.Manager.LockAccount(&pb.LockAccountRequest{Username: "romnn"}); err != nil {
+		t.Fatalf("failed to lock account: %v", err)
+	}
+	if _, _, err := test.Manager.RefreshSession(session.RefreshToken); err == nil {
+		t.Error("expected session of a locked account to be rejected")
+	}
+}
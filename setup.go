@@ -3,7 +3,6 @@ package ldapmanager
 import (
 	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/go-ldap/ldap"
 	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
@@ -11,8 +10,8 @@ import (
 )
 
 // BindAdmin ...
-func (m *LDAPManager) BindAdmin() error {
-	return m.ldap.Bind(fmt.Sprintf("cn=%s,%s", "admin", m.OpenLDAPConfig.BaseDN), m.OpenLDAPConfig.AdminPassword)
+func (m *LDAPManager) BindAdmin(conn *ldap.Conn) error {
+	return conn.Bind(fmt.Sprintf("cn=%s,%s", "admin", m.OpenLDAPConfig.BaseDN), m.OpenLDAPConfig.AdminPassword)
 }
 
 func (m *LDAPManager) setupOU(dn, ou string) error {
@@ -25,7 +24,7 @@ func (m *LDAPManager) setupOU(dn, ou string) error {
 		Controls: []ldap.Control{},
 	}
 	log.Debugf("addOURequest=%v", addOURequest)
-	return m.ldap.Add(addOURequest)
+	return m.add(addOURequest)
 }
 
 func (m *LDAPManager) setupGroupsOU() error {
@@ -36,36 +35,20 @@ func (m *LDAPManager) setupUsersOU() error {
 	return m.setupOU(m.UserGroupDN, m.UsersOU)
 }
 
-func (m *LDAPManager) setupLastID(attribute, cn string, desc string) error {
-	highestID, err := m.getHighestID(attribute)
+func (m *LDAPManager) setupLastGID() error {
+	counter, err := m.gidCounter("")
 	if err != nil {
 		return err
 	}
-	addLastIDRequest := &ldap.AddRequest{
-		DN: fmt.Sprintf("cn=%s,%s", cn, m.BaseDN),
-		Attributes: []ldap.Attribute{
-			{Type: "objectClass", Vals: []string{"device", "top"}},
-			{Type: "serialNumber", Vals: []string{strconv.Itoa(highestID)}},
-			{Type: "description", Vals: []string{desc}},
-		},
-		Controls: []ldap.Control{},
-	}
-	log.Debugf("addLastIDRequest=%v", addLastIDRequest)
-	return m.ldap.Add(addLastIDRequest)
-}
-
-func (m *LDAPManager) setupLastGID() error {
-	return m.setupLastID(
-		m.GroupAttribute, "lastGID",
-		"Records the last GID used to create a Posix group. This prevents the re-use of a GID from a deleted group.",
-	)
+	return m.setupCounter(counter)
 }
 
 func (m *LDAPManager) setupLastUID() error {
-	return m.setupLastID(
-		m.AccountAttribute, "lastUID",
-		"Records the last UID used to create a Posix account. This prevents the re-use of a UID from a deleted account.",
-	)
+	counter, err := m.uidCounter("")
+	if err != nil {
+		return err
+	}
+	return m.setupCounter(counter)
 }
 
 func (m *LDAPManager) setupDefaultGroup() error {
@@ -152,6 +135,56 @@ func (m *LDAPManager) SetupLDAP() error {
 		log.Debug("completed setup of users organizational unit")
 	}
 
+	if m.passwordResetEnabled() {
+		if err := m.setupPasswordResetOU(); err != nil {
+			if !ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) {
+				return fmt.Errorf("failed to setup password reset organizational unit (OU): %v", err)
+			}
+		} else {
+			log.Debug("completed setup of password reset organizational unit")
+		}
+	}
+
+	if m.SessionsEnabled() {
+		if err := m.setupSessionOU(); err != nil {
+			if !ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) {
+				return fmt.Errorf("failed to setup sessions organizational unit (OU): %v", err)
+			}
+		} else {
+			log.Debug("completed setup of sessions organizational unit")
+		}
+	}
+
+	if m.joinRequestsEnabled() {
+		if err := m.setupJoinRequestOU(); err != nil {
+			if !ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) {
+				return fmt.Errorf("failed to setup join requests organizational unit (OU): %v", err)
+			}
+		} else {
+			log.Debug("completed setup of join requests organizational unit")
+		}
+	}
+
+	if m.pwdPolicyEnabled() {
+		if err := m.setupPwdPolicyOU(); err != nil {
+			if !ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) {
+				return fmt.Errorf("failed to setup password policy organizational unit (OU): %v", err)
+			}
+		} else {
+			log.Debug("completed setup of password policy organizational unit")
+		}
+	}
+
+	if m.passwordHistoryEnabled() {
+		if err := m.setupPasswordHistoryOU(); err != nil {
+			if !ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) {
+				return fmt.Errorf("failed to setup password history organizational unit (OU): %v", err)
+			}
+		} else {
+			log.Debug("completed setup of password history organizational unit")
+		}
+	}
+
 	if err := m.setupLastGID(); err != nil {
 		if !ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
 			return fmt.Errorf("failed to setup the last GID: %v", err)
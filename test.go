@@ -17,11 +17,29 @@ const (
 	parallel        = false
 	enableDebugLogs = false
 
-	skipAccountTests        = false
-	skipChangePasswordTests = false
-	skipGroupTests          = false
-	skipGroupMemberTests    = false
-	skipSetupTests          = false
+	skipAccountTests         = false
+	skipChangePasswordTests  = false
+	skipGroupTests           = false
+	skipGroupMemberTests     = false
+	skipSetupTests           = false
+	skipPoolTests            = false
+	skipIDTests              = false
+	skipAuditTests           = false
+	skipLDIFTests            = false
+	skipBulkTests            = false
+	skipPasswordPolicyTests  = false
+	skipPasswordHistoryTests = false
+	skipHashUpgradeTests     = false
+	skipPasswordResetTests   = false
+	skipTwoFactorTests       = false
+	skipAccountStatusTests   = false
+	skipPPolicyTests         = false
+	skipSessionTests         = false
+	skipRBACTests            = false
+	skipGroupOwnerTests      = false
+	skipJoinRequestTests     = false
+	skipNestedGroupTests     = false
+	skipMembershipSyncTests  = false
 )
 
 // Test ...
@@ -0,0 +1,93 @@
+package ldapmanager
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Time-based one-time passwords (RFC 6238) with the parameters supported by all common authenticator apps:
+// HMAC-SHA1, 6 digits and a period of 30 seconds.
+
+const (
+	totpDigits = 6
+	totpPeriod = 30 * time.Second
+	// totpSkew is the number of periods before and after the current one that are accepted
+	totpSkew       = 1
+	totpSecretSize = 20
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateTOTPSecret returns a random base32 encoded secret
+func generateTOTPSecret() (string, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// hotp computes the HOTP value (RFC 4226) of the counter
+func hotp(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+func totpCounter(t time.Time) uint64 {
+	return uint64(t.Unix()) / uint64(totpPeriod/time.Second)
+}
+
+// totpCode returns the code of the secret at the given time
+func totpCode(secret string, t time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("invalid TOTP secret: %v", err)
+	}
+	return hotp(key, totpCounter(t)), nil
+}
+
+// validateTOTP checks the code against the periods around the given time and returns the matching counter.
+// Codes of a counter less than or equal to the last used counter are rejected to prevent replays.
+func validateTOTP(secret, code string, t time.Time, lastCounter uint64) (uint64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+	current := totpCounter(t)
+	for skew := -totpSkew; skew <= totpSkew; skew++ {
+		counter := uint64(int64(current) + int64(skew))
+		if counter <= lastCounter {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(hotp(key, counter)), []byte(code)) == 1 {
+			return counter, true
+		}
+	}
+	return 0, false
+}
+
+// totpURI returns the otpauth:// URI of the secret that can be imported by authenticator apps (e.g. as a QR code)
+func totpURI(issuer, username, secret string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(username)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", totpDigits))
+	params.Set("period", fmt.Sprintf("%d", int(totpPeriod/time.Second)))
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
@@ -0,0 +1,76 @@
+package ldapmanager
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestTOTPReferenceVectors checks the SHA-1 test vectors of RFC 6238 truncated to 6 digits
+func TestTOTPReferenceVectors(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	cases := []struct {
+		time int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, c := range cases {
+		code, err := totpCode(secret, time.Unix(c.time, 0))
+		if err != nil {
+			t.Fatalf("failed to compute TOTP code: %v", err)
+		}
+		if code != c.code {
+			t.Errorf("expected code %s at %d but got %s", c.code, c.time, code)
+		}
+	}
+}
+
+// TestValidateTOTP ...
+func TestValidateTOTP(t *testing.T) {
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1600000000, 0)
+	previous, _ := totpCode(secret, now.Add(-totpPeriod))
+	tooOld, _ := totpCode(secret, now.Add(-3*totpPeriod))
+	current, _ := totpCode(secret, now)
+
+	counter, valid := validateTOTP(secret, previous, now, 0)
+	if !valid || counter != totpCounter(now)-1 {
+		t.Errorf("expected the code of the previous period to be accepted")
+	}
+	if _, valid := validateTOTP(secret, tooOld, now, 0); valid {
+		t.Errorf("expected a code outside of the allowed skew to be rejected")
+	}
+	if _, valid := validateTOTP(secret, previous, now, counter); valid {
+		t.Errorf("expected a used code to be rejected")
+	}
+	if _, valid := validateTOTP(secret, current, now, counter); !valid {
+		t.Errorf("expected the current code to be accepted after the previous one")
+	}
+	if _, valid := validateTOTP(secret, "12345", now, 0); valid {
+		t.Errorf("expected a code with the wrong length to be rejected")
+	}
+}
+
+// TestTOTPURI ...
+func TestTOTPURI(t *testing.T) {
+	uri, err := url.Parse(totpURI("ACME Corp", "romnn", "JBSWY3DPEHPK3PXP"))
+	if err != nil {
+		t.Fatalf("invalid otpauth uri: %v", err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/ACME Corp:romnn" {
+		t.Errorf("unexpected otpauth uri %q", uri)
+	}
+	query := uri.Query()
+	if query.Get("secret") != "JBSWY3DPEHPK3PXP" || query.Get("issuer") != "ACME Corp" || query.Get("digits") != "6" || query.Get("period") != "30" {
+		t.Errorf("unexpected otpauth parameters %v", query)
+	}
+}
@@ -0,0 +1,449 @@
+package ldapmanager
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	encodinghex "encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap"
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// The TOTP secret, the hashed recovery codes and the last used TOTP counter of an account are stored
+// AES-GCM encrypted in a single attribute of the account's entry, e.g. "totp:v1:<base64 nonce and ciphertext>".
+
+const (
+	// DefaultTwoFactorAttribute is an attribute of inetOrgPerson that is not used otherwise
+	DefaultTwoFactorAttribute = "carLicense"
+	// DefaultTwoFactorIssuer is shown in authenticator apps
+	DefaultTwoFactorIssuer = "ldap-manager"
+	// LoginChallengeTTL is the time a user has to enter the one-time password after the password was accepted
+	LoginChallengeTTL = 5 * time.Minute
+
+	twoFactorValuePrefix  = "totp:v1:"
+	recoveryCodeCount     = 10
+	recoveryCodeAlphabet  = "abcdefghjkmnpqrstuvwxyz23456789"
+	recoveryCodeLength    = 10
+	otpAttemptLimit       = 10
+	otpAttemptLimitWindow = 5 * time.Minute
+	// maxOTPVerificationAttempts limits the number of compare-and-swap attempts when recording a used code
+	maxOTPVerificationAttempts = 5
+)
+
+// TwoFactorDisabledError is returned when no key to encrypt TOTP secrets is configured
+type TwoFactorDisabledError struct {
+	ApplicationError
+}
+
+// Error ...
+func (e *TwoFactorDisabledError) Error() string {
+	return "two-factor authentication is not enabled"
+}
+
+// Code ...
+func (e *TwoFactorDisabledError) Code() codes.Code {
+	return codes.FailedPrecondition
+}
+
+// InvalidOTPError is returned when a one-time password, recovery code or login challenge is not accepted
+type InvalidOTPError struct {
+	ApplicationError
+	Message string
+}
+
+// Error ...
+func (e *InvalidOTPError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return "invalid one-time password"
+}
+
+// Code ...
+func (e *InvalidOTPError) Code() codes.Code {
+	return codes.Unauthenticated
+}
+
+type twoFactorState struct {
+	Secret  string `json:"secret"`
+	Enabled bool   `json:"enabled"`
+	// LastCounter is the TOTP counter of the last accepted code
+	LastCounter uint64 `json:"last_counter,omitempty"`
+	// RecoveryCodes are the SHA-256 hashes of the unused recovery codes
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	// LastChallenge is the ID of the last used login challenge, challenges issued before it are no longer accepted
+	LastChallenge int64 `json:"last_challenge,omitempty"`
+}
+
+func (m *LDAPManager) twoFactorEnabled() bool {
+	return m.TwoFactorKey != "" && m.TwoFactorAttribute != ""
+}
+
+// twoFactorKey derives a key for the given purpose from the configured two-factor key
+func (m *LDAPManager) twoFactorKey(purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(m.TwoFactorKey))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+func (m *LDAPManager) twoFactorIssuer() string {
+	if m.TwoFactorIssuer != "" {
+		return m.TwoFactorIssuer
+	}
+	return DefaultTwoFactorIssuer
+}
+
+func (m *LDAPManager) twoFactorCipher() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(m.twoFactorKey("totp-secret"))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (m *LDAPManager) encryptTwoFactorState(state *twoFactorState) (string, error) {
+	plaintext, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	aead, err := m.twoFactorCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(twoFactorValuePrefix))
+	return twoFactorValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (m *LDAPManager) decryptTwoFactorState(value string) (*twoFactorState, error) {
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, twoFactorValuePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid two-factor state: %v", err)
+	}
+	aead, err := m.twoFactorCipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("invalid two-factor state")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(twoFactorValuePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt two-factor state: %v", err)
+	}
+	var state twoFactorState
+	if err := json.Unmarshal(plaintext, &state); err != nil {
+		return nil, fmt.Errorf("invalid two-factor state: %v", err)
+	}
+	return &state, nil
+}
+
+// getTwoFactorState returns the DN of the account and its two-factor state, which is nil if the account has not enrolled
+func (m *LDAPManager) getTwoFactorState(username string) (string, *twoFactorState, error) {
+	userDN, _, state, err := m.readTwoFactorState(username)
+	return userDN, state, err
+}
+
+// readTwoFactorState also returns the stored (encrypted) value of the two-factor state for swapTwoFactorState
+func (m *LDAPManager) readTwoFactorState(username string) (string, string, *twoFactorState, error) {
+	if !m.twoFactorEnabled() {
+		return "", "", nil, &TwoFactorDisabledError{}
+	}
+	entry, err := m.findAccount(username, []string{m.TwoFactorAttribute})
+	if err != nil {
+		return "", "", nil, err
+	}
+	for _, value := range entry.GetAttributeValues(m.TwoFactorAttribute) {
+		if strings.HasPrefix(value, twoFactorValuePrefix) {
+			state, err := m.decryptTwoFactorState(value)
+			return entry.DN, value, state, err
+		}
+	}
+	return entry.DN, "", nil, nil
+}
+
+// putTwoFactorState stores the two-factor state of an account or removes it if the state is nil
+func (m *LDAPManager) putTwoFactorState(userDN string, state *twoFactorState) error {
+	modifyRequest := ldap.NewModifyRequest(userDN, []ldap.Control{})
+	if state == nil {
+		modifyRequest.Replace(m.TwoFactorAttribute, []string{})
+	} else {
+		value, err := m.encryptTwoFactorState(state)
+		if err != nil {
+			return err
+		}
+		modifyRequest.Replace(m.TwoFactorAttribute, []string{value})
+	}
+	if err := m.modify(modifyRequest); err != nil {
+		return fmt.Errorf("failed to update two-factor authentication of %q: %v", userDN, err)
+	}
+	return nil
+}
+
+// swapTwoFactorState atomically replaces the old two-factor state with the new state.
+// Deleting the old value acts as an assertion: when the state was changed in the meantime,
+// e.g. because the same code was used concurrently, the modify fails with noSuchAttribute.
+func (m *LDAPManager) swapTwoFactorState(userDN, old string, state *twoFactorState) error {
+	value, err := m.encryptTwoFactorState(state)
+	if err != nil {
+		return err
+	}
+	modifyRequest := ldap.NewModifyRequest(userDN, []ldap.Control{})
+	modifyRequest.Delete(m.TwoFactorAttribute, []string{old})
+	modifyRequest.Add(m.TwoFactorAttribute, []string{value})
+	return m.modify(modifyRequest)
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	hash := sha256.Sum256([]byte(normalized))
+	return encodinghex.EncodeToString(hash[:])
+}
+
+func generateRecoveryCodes() ([]string, []string, error) {
+	var codes, hashes []string
+	for i := 0; i < recoveryCodeCount; i++ {
+		random := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(random); err != nil {
+			return nil, nil, err
+		}
+		code := make([]byte, recoveryCodeLength)
+		for j, b := range random {
+			code[j] = recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)]
+		}
+		formatted := fmt.Sprintf("%s-%s", code[:recoveryCodeLength/2], code[recoveryCodeLength/2:])
+		codes = append(codes, formatted)
+		hashes = append(hashes, hashRecoveryCode(formatted))
+	}
+	return codes, hashes, nil
+}
+
+// TOTPEnabled checks if the account has confirmed its TOTP enrollment. It is always false if two-factor authentication is disabled.
+func (m *LDAPManager) TOTPEnabled(username string) (bool, error) {
+	if !m.twoFactorEnabled() {
+		return false, nil
+	}
+	_, state, err := m.getTwoFactorState(username)
+	if err != nil {
+		return false, err
+	}
+	return state != nil && state.Enabled, nil
+}
+
+// EnrollTOTP creates a new TOTP secret and recovery codes for the account.
+// The secret is only used for logins after it was confirmed with a valid code using ConfirmTOTP.
+func (m *LDAPManager) EnrollTOTP(req *pb.EnrollTOTPRequest) (*pb.TOTPEnrollment, error) {
+	if req.GetUsername() == "" {
+		return nil, &ValidationError{Message: "username must not be empty"}
+	}
+	userDN, state, err := m.getTwoFactorState(req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+	if state != nil && state.Enabled {
+		return nil, &ValidationError{Message: "two-factor authentication is already enabled"}
+	}
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.putTwoFactorState(userDN, &twoFactorState{Secret: secret, RecoveryCodes: hashes}); err != nil {
+		return nil, err
+	}
+	log.Infof("started TOTP enrollment of %q", req.GetUsername())
+	return &pb.TOTPEnrollment{
+		Secret:        secret,
+		Uri:           totpURI(m.twoFactorIssuer(), req.GetUsername(), secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// ConfirmTOTP enables two-factor authentication for the account after checking a code of the enrolled secret
+func (m *LDAPManager) ConfirmTOTP(req *pb.ConfirmTOTPRequest) error {
+	userDN, state, err := m.getTwoFactorState(req.GetUsername())
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return &ValidationError{Message: "two-factor authentication is not enrolled"}
+	}
+	if state.Enabled {
+		return &ValidationError{Message: "two-factor authentication is already enabled"}
+	}
+	counter, valid := validateTOTP(state.Secret, req.GetCode(), time.Now(), 0)
+	if !valid {
+		return &ValidationError{Message: "invalid one-time password", Field: "code"}
+	}
+	state.Enabled = true
+	state.LastCounter = counter
+	if err := m.putTwoFactorState(userDN, state); err != nil {
+		return err
+	}
+	log.Infof("enabled two-factor authentication for %q", req.GetUsername())
+	return nil
+}
+
+// DisableTOTP removes the TOTP secret and recovery codes of the account.
+// Unless requireCode is false (e.g. for admins), a valid one-time password or recovery code is required.
+func (m *LDAPManager) DisableTOTP(req *pb.DisableTOTPRequest, requireCode bool) error {
+	userDN, state, err := m.getTwoFactorState(req.GetUsername())
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return nil
+	}
+	if requireCode && state.Enabled {
+		if err := m.VerifyOTP(req.GetUsername(), req.GetCode()); err != nil {
+			return err
+		}
+	}
+	if err := m.putTwoFactorState(userDN, nil); err != nil {
+		return err
+	}
+	log.Infof("disabled two-factor authentication for %q", req.GetUsername())
+	return nil
+}
+
+// VerifyOTP checks a one-time password or recovery code of an account. Each code can only be used once.
+func (m *LDAPManager) VerifyOTP(username, code string) error {
+	return m.verifyOTP(username, code, 0)
+}
+
+// VerifyLoginOTP checks the login challenge and the one-time password or recovery code of its user
+// and returns the username. Each challenge can only be used once.
+func (m *LDAPManager) VerifyLoginOTP(challenge, code string) (string, error) {
+	username, challengeID, err := m.parseLoginChallenge(challenge)
+	if err != nil {
+		return "", err
+	}
+	return username, m.verifyOTP(username, code, challengeID)
+}
+
+// verifyOTP records the used code and the login challenge (if not zero) in the two-factor state of the account
+func (m *LDAPManager) verifyOTP(username, code string, challengeID int64) error {
+	if m.otpLimiter != nil && !m.otpLimiter.Allow(username) {
+		return &InvalidOTPError{Message: "too many one-time password attempts"}
+	}
+	for attempt := 0; attempt < maxOTPVerificationAttempts; attempt++ {
+		userDN, old, state, err := m.readTwoFactorState(username)
+		if err != nil {
+			return err
+		}
+		if state == nil || !state.Enabled {
+			return &InvalidOTPError{}
+		}
+		if challengeID != 0 {
+			if challengeID <= state.LastChallenge {
+				return &InvalidOTPError{Message: "invalid or expired login challenge"}
+			}
+			state.LastChallenge = challengeID
+		}
+		usedRecoveryCode := false
+		if counter, valid := validateTOTP(state.Secret, strings.TrimSpace(code), time.Now(), state.LastCounter); valid {
+			state.LastCounter = counter
+		} else {
+			hash := hashRecoveryCode(code)
+			for i, recoveryCode := range state.RecoveryCodes {
+				if subtle.ConstantTimeCompare([]byte(recoveryCode), []byte(hash)) == 1 {
+					state.RecoveryCodes = append(state.RecoveryCodes[:i], state.RecoveryCodes[i+1:]...)
+					usedRecoveryCode = true
+					break
+				}
+			}
+			if !usedRecoveryCode {
+				return &InvalidOTPError{}
+			}
+		}
+		if err := m.swapTwoFactorState(userDN, old, state); err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchAttribute) {
+				// the state was changed concurrently, check the code against the new state
+				continue
+			}
+			return fmt.Errorf("failed to update two-factor authentication of %q: %v", username, err)
+		}
+		if usedRecoveryCode {
+			log.Infof("%q used a recovery code, %d remaining", username, len(state.RecoveryCodes))
+		}
+		return nil
+	}
+	return &InvalidOTPError{}
+}
+
+// NewLoginChallenge returns a signed challenge proving that the password of the user was accepted
+// and the time it expires. The challenge is exchanged for a token together with a one-time password.
+// The ID of a challenge is the time it was issued, so that using a challenge invalidates all older ones.
+func (m *LDAPManager) NewLoginChallenge(username string) (string, time.Time) {
+	issued := time.Now()
+	expires := issued.Add(LoginChallengeTTL)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d:%s", expires.Unix(), issued.UnixNano(), username)))
+	mac := hmac.New(sha256.New, m.twoFactorKey("login-challenge"))
+	mac.Write([]byte(payload))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), expires
+}
+
+// VerifyLoginChallenge returns the username of a valid login challenge.
+// It does not check if the challenge was already used, which is done by VerifyLoginOTP.
+func (m *LDAPManager) VerifyLoginChallenge(challenge string) (string, error) {
+	username, _, err := m.parseLoginChallenge(challenge)
+	return username, err
+}
+
+// parseLoginChallenge returns the username and ID of a valid login challenge
+func (m *LDAPManager) parseLoginChallenge(challenge string) (string, int64, error) {
+	invalid := &InvalidOTPError{Message: "invalid or expired login challenge"}
+	if !m.twoFactorEnabled() {
+		return "", 0, &TwoFactorDisabledError{}
+	}
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 2 {
+		return "", 0, invalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", 0, invalid
+	}
+	mac := hmac.New(sha256.New, m.twoFactorKey("login-challenge"))
+	mac.Write([]byte(parts[0]))
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return "", 0, invalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", 0, invalid
+	}
+	fields := strings.SplitN(string(payload), ":", 3)
+	if len(fields) != 3 {
+		return "", 0, invalid
+	}
+	expires, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || time.Now().Unix() >= expires {
+		return "", 0, invalid
+	}
+	id, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || id <= 0 {
+		return "", 0, invalid
+	}
+	return fields[2], id, nil
+}
@@ -0,0 +1,138 @@
+package ldapmanager
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/romnn/ldap-manager/grpc/ldap-manager"
+)
+
+// TestTwoFactorStateEncryption ...
+func TestTwoFactorStateEncryption(t *testing.T) {
+	manager := &LDAPManager{TwoFactorKey: "secret key", TwoFactorAttribute: DefaultTwoFactorAttribute}
+	state := &twoFactorState{Secret: "JBSWY3DPEHPK3PXP", Enabled: true, LastCounter: 42, RecoveryCodes: []string{"a", "b"}}
+	value, err := manager.encryptTwoFactorState(state)
+	if err != nil {
+		t.Fatalf("failed to encrypt two-factor state: %v", err)
+	}
+	if !strings.HasPrefix(value, twoFactorValuePrefix) || strings.Contains(value, state.Secret) {
+		t.Errorf("unexpected encrypted two-factor state %q", value)
+	}
+	decrypted, err := manager.decryptTwoFactorState(value)
+	if err != nil {
+		t.Fatalf("failed to decrypt two-factor state: %v", err)
+	}
+	if decrypted.Secret != state.Secret || !decrypted.Enabled || decrypted.LastCounter != 42 || len(decrypted.RecoveryCodes) != 2 {
+		t.Errorf("expected %+v but got %+v", state, decrypted)
+	}
+	other := &LDAPManager{TwoFactorKey: "other key", TwoFactorAttribute: DefaultTwoFactorAttribute}
+	if _, err := other.decryptTwoFactorState(value); err == nil {
+		t.Error("expected decryption with another key to fail")
+	}
+}
+
+// TestLoginChallenge ...
+func TestLoginChallenge(t *testing.T) {
+	manager := &LDAPManager{TwoFactorKey: "secret key", TwoFactorAttribute: DefaultTwoFactorAttribute}
+	challenge, expires := manager.NewLoginChallenge("romnn")
+	if expires.Before(time.Now()) {
+		t.Errorf("expected the challenge to expire in the future but got %v", expires)
+	}
+	username, err := manager.VerifyLoginChallenge(challenge)
+	if err != nil || username != "romnn" {
+		t.Errorf("expected challenge of romnn to be valid but got %q (%v)", username, err)
+	}
+	other := &LDAPManager{TwoFactorKey: "other key", TwoFactorAttribute: DefaultTwoFactorAttribute}
+	if _, err := other.VerifyLoginChallenge(challenge); err == nil {
+		t.Error("expected challenge signed with another key to be invalid")
+	}
+	forged, _ := manager.NewLoginChallenge("admin")
+	tampered := strings.SplitN(forged, ".", 2)[0] + "." + strings.SplitN(challenge, ".", 2)[1]
+	if _, err := manager.VerifyLoginChallenge(tampered); err == nil {
+		t.Error("expected challenge with a mismatching signature to be invalid")
+	}
+}
+
+// TestTwoFactorAuthentication ...
+func TestTwoFactorAuthentication(t *testing.T) {
+	if skipTwoFactorTests {
+		t.Skip()
+	}
+	test := new(Test).Setup(t)
+	defer test.Teardown()
+
+	test.Manager.TwoFactorKey = "secret key"
+	if err := test.Manager.NewAccount(&pb.NewAccountRequest{
+		Account: &pb.Account{
+			Username:  "romnn",
+			Password:  "Hallo Welt",
+			Email:     "romnn@example.org",
+			FirstName: "roman",
+			LastName:  "d",
+		},
+	}, pb.HashingAlgorithm_DEFAULT); err != nil {
+		t.Fatalf("failed to add user: %v", err)
+	}
+
+	enrollment, err := test.Manager.EnrollTOTP(&pb.EnrollTOTPRequest{Username: "romnn"})
+	if err != nil {
+		t.Fatalf("failed to enroll TOTP: %v", err)
+	}
+	if len(enrollment.GetRecoveryCodes()) != recoveryCodeCount || !strings.HasPrefix(enrollment.GetUri(), "otpauth://totp/") {
+		t.Errorf("unexpected enrollment %+v", enrollment)
+	}
+	// unconfirmed enrollments are not used for logins
+	if enabled, err := test.Manager.TOTPEnabled("romnn"); err != nil || enabled {
+		t.Errorf("expected TOTP to be disabled before confirmation but got %t (%v)", enabled, err)
+	}
+	if err := test.Manager.ConfirmTOTP(&pb.ConfirmTOTPRequest{Username: "romnn", Code: "000000"}); err == nil {
+		t.Error("expected confirmation with an invalid code to fail")
+	}
+	code, err := totpCode(enrollment.GetSecret(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := test.Manager.ConfirmTOTP(&pb.ConfirmTOTPRequest{Username: "romnn", Code: code}); err != nil {
+		t.Fatalf("failed to confirm TOTP: %v", err)
+	}
+	if enabled, err := test.Manager.TOTPEnabled("romnn"); err != nil || !enabled {
+		t.Errorf("expected TOTP to be enabled but got %t (%v)", enabled, err)
+	}
+	// the code used for the confirmation can not be replayed
+	if err := test.Manager.VerifyOTP("romnn", code); err == nil {
+		t.Error("expected a used code to be rejected")
+	}
+
+	// recovery codes can be used once
+	recoveryCode := strings.ToUpper(enrollment.GetRecoveryCodes()[0])
+	if err := test.Manager.VerifyOTP("romnn", recoveryCode); err != nil {
+		t.Errorf("failed to verify recovery code: %v", err)
+	}
+	if err := test.Manager.VerifyOTP("romnn", recoveryCode); err == nil {
+		t.Error("expected a used recovery code to be rejected")
+	}
+
+	// login challenges can be used once and using one invalidates all older challenges
+	older, _ := test.Manager.NewLoginChallenge("romnn")
+	challenge, _ := test.Manager.NewLoginChallenge("romnn")
+	if username, err := test.Manager.VerifyLoginOTP(challenge, enrollment.GetRecoveryCodes()[2]); err != nil || username != "romnn" {
+		t.Errorf("expected login challenge of romnn to be accepted but got %q (%v)", username, err)
+	}
+	if _, err := test.Manager.VerifyLoginOTP(challenge, enrollment.GetRecoveryCodes()[3]); err == nil {
+		t.Error("expected a used login challenge to be rejected")
+	}
+	if _, err := test.Manager.VerifyLoginOTP(older, enrollment.GetRecoveryCodes()[3]); err == nil {
+		t.Error("expected a login challenge issued before the used challenge to be rejected")
+	}
+
+	if err := test.Manager.DisableTOTP(&pb.DisableTOTPRequest{Username: "romnn", Code: "000000"}, true); err == nil {
+		t.Error("expected disabling with an invalid code to fail")
+	}
+	if err := test.Manager.DisableTOTP(&pb.DisableTOTPRequest{Username: "romnn", Code: enrollment.GetRecoveryCodes()[1]}, true); err != nil {
+		t.Fatalf("failed to disable TOTP: %v", err)
+	}
+	if enabled, err := test.Manager.TOTPEnabled("romnn"); err != nil || enabled {
+		t.Errorf("expected TOTP to be disabled but got %t (%v)", enabled, err)
+	}
+}
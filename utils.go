@@ -3,11 +3,9 @@ package ldapmanager
 import (
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/go-ldap/ldap"
-	log "github.com/sirupsen/logrus"
 )
 
 const (
@@ -157,75 +155,15 @@ func (m *LDAPManager) getGroupByGID(gid int) (string, int, error) {
 	return cn, gid, nil
 }
 
-func (m *LDAPManager) updateLastID(cn string, newID int) error {
-	modifyRequest := ldap.NewModifyRequest(
-		fmt.Sprintf("cn=%s,%s", cn, m.BaseDN),
-		[]ldap.Control{},
-	)
-	modifyRequest.Replace("serialNumber", []string{strconv.Itoa(newID)})
-	log.Debugf("modifyRequest=%v", modifyRequest)
-	if err := m.ldap.Modify(modifyRequest); err != nil {
-		return fmt.Errorf("failed to update cn=%s: %v", cn, err)
-	}
-	log.Debugf("updated cn=%s with %d", cn, newID)
-	return nil
-}
-
-func (m *LDAPManager) getHighestID(attribute string) (int, error) {
-	var highestID int
-	var entryBaseDN, entryFilter, entryAttribute string
-	switch strings.ToUpper(attribute) {
-	case strings.ToUpper(m.GroupAttribute):
-		highestID = MinGID
-		entryBaseDN = m.GroupsDN
-		entryFilter = "(objectClass=posixGroup)"
-		entryAttribute = "gidNumber"
-	case strings.ToUpper(m.AccountAttribute):
-		highestID = MinUID
-		entryBaseDN = m.UserGroupDN
-		entryFilter = fmt.Sprintf("(%s=*)", m.AccountAttribute)
-		entryAttribute = "uidNumber"
-	default:
-		return highestID, fmt.Errorf("unknown id attribute %q", attribute)
-	}
-
-	filter := fmt.Sprintf("(&(objectClass=device)(cn=last%s))", strings.ToUpper(attribute))
-	result, err := m.ldap.Search(ldap.NewSearchRequest(
-		m.BaseDN,
-		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
-		filter,
-		[]string{"serialNumber"},
-		[]ldap.Control{},
-	))
-	if err != nil {
-		return highestID, err
-	}
-	// Check for cached lastUID / lastGID value first
-	if len(result.Entries) > 0 {
-		if fetchedID, err := strconv.Atoi(result.Entries[0].GetAttributeValue("serialNumber")); err == nil && fetchedID >= highestID {
-			return fetchedID, nil
-		}
-	}
+// assertionControlOID is the LDAP assertion control (RFC 4528)
+const assertionControlOID = "1.3.6.1.1.12"
 
-	// cache miss requires traversing all entries
-	result, err = m.ldap.Search(ldap.NewSearchRequest(
-		entryBaseDN,
-		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
-		entryFilter,
-		[]string{entryAttribute},
-		[]ldap.Control{},
-	))
+// newAssertionControl returns a control that makes an operation fail with assertionFailed
+// unless the target entry matches the filter
+func newAssertionControl(filter string) (ldap.Control, error) {
+	packet, err := ldap.CompileFilter(filter)
 	if err != nil {
-		return highestID, err
-	}
-	for _, entry := range result.Entries {
-		if entryAttrValue := entry.GetAttributeValue(entryAttribute); entryAttrValue != "" {
-			if entryAttrNumericValue, err := strconv.Atoi(entryAttrValue); err == nil {
-				if entryAttrNumericValue > highestID {
-					highestID = entryAttrNumericValue
-				}
-			}
-		}
+		return nil, err
 	}
-	return highestID, nil
+	return ldap.NewControlString(assertionControlOID, true, string(packet.Bytes())), nil
 }